@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"movie-rent/db"
+	userModel "movie-rent/pkg/user/model"
+	"movie-rent/pkg/user/repository"
+	"movie-rent/pkg/user/service"
+	"os"
+)
+
+// Promotes a registered account to admin. The API only lets admins change
+// roles, so the first one has to be made from here.
+func main() {
+	email := flag.String("email", "", "email of the registered user to promote")
+	flag.Parse()
+	if *email == "" {
+		fmt.Println("usage: go run cmd/admin/main.go -email=<email>")
+		os.Exit(2)
+	}
+
+	database := db.NewDatabase().Instance()
+	defer database.Close()
+
+	userRepository := repository.NewUserRepository(database)
+	userService := service.NewUserService(userRepository)
+
+	user, err := userRepository.GetUserByEmail(userModel.NormalizeEmail(*email))
+	if err != nil {
+		fmt.Println("failed to find user:", err.Error())
+		os.Exit(1)
+	}
+	if _, err = userService.SetRole(user.Id, userModel.RoleAdmin); err != nil {
+		fmt.Println("failed to promote user:", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println("Promoted", user.Email, "to admin")
+}
@@ -1,17 +1,66 @@
 package main
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"log"
+	"movie-rent/config"
 	"movie-rent/db"
+	controller11 "movie-rent/pkg/apikey/controller"
+	repository11 "movie-rent/pkg/apikey/repository"
+	service11 "movie-rent/pkg/apikey/service"
+	controller10 "movie-rent/pkg/auth/controller"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
+	repository10 "movie-rent/pkg/auth/repository"
+	service10 "movie-rent/pkg/auth/service"
+	"movie-rent/pkg/auth/token"
 	controller2 "movie-rent/pkg/cart/controller"
 	repository2 "movie-rent/pkg/cart/repository"
 	service2 "movie-rent/pkg/cart/service"
+	controller6 "movie-rent/pkg/fine/controller"
+	repository6 "movie-rent/pkg/fine/repository"
+	service6 "movie-rent/pkg/fine/service"
+	controller12 "movie-rent/pkg/genre/controller"
+	repository12 "movie-rent/pkg/genre/repository"
+	service12 "movie-rent/pkg/genre/service"
+	controller5 "movie-rent/pkg/hold/controller"
+	repository5 "movie-rent/pkg/hold/repository"
+	service5 "movie-rent/pkg/hold/service"
+	controller4 "movie-rent/pkg/inventory/controller"
+	repository4 "movie-rent/pkg/inventory/repository"
+	service4 "movie-rent/pkg/inventory/service"
 	"movie-rent/pkg/movie/clients/rapid"
 	"movie-rent/pkg/movie/controller"
 	"movie-rent/pkg/movie/repository"
 	"movie-rent/pkg/movie/service"
+	"movie-rent/pkg/movie/suggest"
+	"movie-rent/pkg/payments/clients/gateway"
+	repository8 "movie-rent/pkg/payments/repository"
+	service8 "movie-rent/pkg/payments/service"
+	controller13 "movie-rent/pkg/person/controller"
+	repository13 "movie-rent/pkg/person/repository"
+	service13 "movie-rent/pkg/person/service"
+	"movie-rent/pkg/pricing/engine"
+	controller7 "movie-rent/pkg/promotion/controller"
+	repository7 "movie-rent/pkg/promotion/repository"
+	service7 "movie-rent/pkg/promotion/service"
+	controller3 "movie-rent/pkg/rental/controller"
+	repository3 "movie-rent/pkg/rental/repository"
+	service3 "movie-rent/pkg/rental/service"
+	controller14 "movie-rent/pkg/review/controller"
+	repository14 "movie-rent/pkg/review/repository"
+	service14 "movie-rent/pkg/review/service"
+	"movie-rent/pkg/scheduler"
+	controller9 "movie-rent/pkg/user/controller"
+	repository9 "movie-rent/pkg/user/repository"
+	service9 "movie-rent/pkg/user/service"
+	controller15 "movie-rent/pkg/wishlist/controller"
+	repository15 "movie-rent/pkg/wishlist/repository"
+	service15 "movie-rent/pkg/wishlist/service"
 	"net/http"
+	"time"
 )
 
 func main() {
@@ -19,26 +68,183 @@ func main() {
 	httpClient := &http.Client{}
 	database := db.NewDatabase().Instance()
 
+	userRepository := repository9.NewUserRepository(database)
+	userService := service9.NewUserService(userRepository)
+	userController := controller9.NewUserController(userService)
+
+	authConfig := config.LoadAuthConfig()
+	authRepository := repository10.NewAuthRepository(database)
+	authService := service10.NewAuthService(authRepository, userRepository,
+		token.NewSigner(authConfig.SigningKey, authConfig.Issuer), authConfig)
+	authController := controller10.NewAuthController(authService)
+
+	apiKeyRepository := repository11.NewApiKeyRepository(database)
+	apiKeyService := service11.NewApiKeyService(apiKeyRepository)
+	apiKeyController := controller11.NewApiKeyController(apiKeyService)
+	requireAuth := middleware.RequireAuth(authService)
+	requireAccess := func(permission string) gin.HandlerFunc {
+		return middleware.RequireAccess(authService, apiKeyService, permission)
+	}
+
+	genreRepository := repository12.NewGenreRepository(database)
+	genreService := service12.NewGenreService(genreRepository)
+	genreController := controller12.NewGenreController(genreService)
+
 	movieRepository := repository.NewMovieRepository(database)
 	rapidClient := rapid.NewRapidClient(httpClient)
-	movieService := service.NewMovieService(movieRepository, rapidClient)
+	titleIndex := suggest.NewIndex(movieRepository)
+	if err := titleIndex.Refresh(); err != nil {
+		fmt.Println("failed to build title suggestions:", err.Error())
+	}
+
+	personRepository := repository13.NewPersonRepository(database)
+	personService := service13.NewPersonService(personRepository, movieRepository)
+	personController := controller13.NewPersonController(personService)
+
+	movieService := service.NewMovieService(movieRepository, rapidClient, titleIndex, genreService, personService)
 	movieController := controller.NewMovieController(movieService)
 
+	reviewRepository := repository14.NewReviewRepository(database)
+	reviewService := service14.NewReviewService(reviewRepository, movieRepository, config.LoadReviewConfig())
+	reviewController := controller14.NewReviewController(reviewService)
+
+	inventoryRepository := repository4.NewInventoryRepository(database)
+	inventoryService := service4.NewInventoryService(inventoryRepository, movieRepository)
+	inventoryController := controller4.NewInventoryController(inventoryService)
+
+	rentalRepository := repository3.NewRentalRepository(database)
+
+	paymentConfig := config.LoadPaymentConfig()
+	var paymentGateway gateway.PaymentGateway
+	switch {
+	case paymentConfig.UseFakeGateway:
+		paymentGateway = gateway.NewFakeGateway()
+	case paymentConfig.GatewayURL != "":
+		paymentGateway = gateway.NewHTTPGateway(&http.Client{Timeout: paymentConfig.GatewayTimeout},
+			paymentConfig.GatewayURL, paymentConfig.GatewayAPIKey)
+	default:
+		log.Fatal("PAYMENT_GATEWAY_URL is not set; set PAYMENT_GATEWAY=fake to run with the fake gateway")
+	}
+	paymentRepository := repository8.NewPaymentRepository(database)
+	paymentService := service8.NewPaymentService(paymentRepository, paymentGateway)
+
+	fineRepository := repository6.NewFineRepository(database)
+	fineService := service6.NewFineService(fineRepository, rentalRepository, paymentService, config.LoadFeeConfig())
+	fineController := controller6.NewFineController(fineService)
+
+	pricingConfig := config.LoadPricingConfig()
+	pricingEngine := engine.NewEngine(pricingConfig.TaxRateBps,
+		engine.DefaultLineRules(pricingConfig.NewReleaseYears, pricingConfig.NewReleasePremiumPercent)...)
+
+	promotionRepository := repository7.NewPromotionRepository(database)
+	promotionService := service7.NewPromotionService(promotionRepository)
+	promotionController := controller7.NewPromotionController(promotionService)
+
 	cartRepository := repository2.NewCartRepository(database)
-	cartService := service2.NewCartService(cartRepository)
+	cartService := service2.NewCartService(cartRepository, movieRepository, rentalRepository, fineService, pricingEngine,
+		promotionService, paymentService)
 	cartController := controller2.NewCartController(cartService)
 
+	holdRepository := repository5.NewHoldRepository(database)
+	holdService := service5.NewHoldService(holdRepository, movieRepository, cartService)
+	holdController := controller5.NewHoldController(holdService)
+
+	rentalService := service3.NewRentalService(rentalRepository, holdService, fineService)
+	rentalController := controller3.NewRentalController(rentalService)
+
+	wishlistRepository := repository15.NewWishlistRepository(database)
+	wishlistService := service15.NewWishlistService(wishlistRepository, movieRepository, cartService)
+	wishlistController := controller15.NewWishlistController(wishlistService)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	scheduler.Every(time.Minute, "expire holds", holdService.ExpireAllocations, stop)
+	scheduler.Every(time.Minute, "expire cart reservations", cartService.ExpireReservations, stop)
+	scheduler.Every(time.Hour, "accrue late fees", fineService.AccrueLateFees, stop)
+	scheduler.Every(time.Hour, "purge revoked tokens", authService.PurgeRevoked, stop)
+	scheduler.Every(time.Hour, "refresh title suggestions", titleIndex.Refresh, stop)
+
 	route.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"Greetings": "Hello world"})
 	})
 
+	route.POST("/auth/login", authController.Login)
+	route.POST("/auth/refresh", authController.Refresh)
+	route.POST("/auth/logout", requireAuth, authController.Logout)
+
+	route.POST("/users", userController.Register)
+	route.GET("/users/:id", requireAuth, middleware.RequireSelf("id"), userController.GetUser)
+	route.PUT("/users/:id", requireAuth, middleware.RequireSelf("id"), userController.UpdateProfile)
+	route.PUT("/users/:id/role", requireAccess(authModel.PermissionManageUsers), userController.SetRole)
+
+	apiKeys := route.Group("/api-keys", requireAccess(authModel.PermissionManageApiKeys))
+	apiKeys.POST("", apiKeyController.CreateKey)
+	apiKeys.GET("", apiKeyController.GetKeys)
+	apiKeys.DELETE("/:id", apiKeyController.RevokeKey)
+	apiKeys.POST("/:id/rotate", apiKeyController.RotateKey)
+
 	route.GET("/movies", movieController.GetMovies)
 	route.GET("/movie/:id", movieController.GetMovieBy)
 	route.GET("/movies/filter", movieController.GetFilteredMovies)
-	route.POST("/movie", movieController.AddMovie)
+	route.GET("/movies/search", movieController.SearchMovies)
+	route.GET("/movies/suggest", movieController.SuggestTitles)
+
+	route.GET("/movie/:id/credits", personController.GetMovieCredits)
+	route.GET("/people/:id", personController.GetPerson)
+	route.GET("/genres", genreController.GetGenres)
+	route.GET("/movie/:id/reviews", reviewController.GetReviews)
+	route.POST("/movie/:id/reviews", requireAuth, reviewController.AddReview)
+	route.POST("/reviews/:id/votes", requireAuth, reviewController.VoteReview)
+	route.POST("/reviews/:id/reports", requireAuth, reviewController.ReportReview)
+	moderation := route.Group("/reviews", requireAccess(authModel.PermissionModerateReviews))
+	moderation.GET("/moderation", reviewController.GetModerationQueue)
+	moderation.GET("/:id/moderation", reviewController.GetModerations)
+	moderation.POST("/:id/moderation", reviewController.ModerateReview)
+
+	route.POST("/movie", requireAccess(authModel.PermissionImportCatalog), movieController.AddMovie)
+	catalog := route.Group("", requireAccess(authModel.PermissionManageCatalog))
+	catalog.POST("/movies", movieController.CreateMovie)
+	catalog.PUT("/movie/:id", movieController.UpdateMovie)
+	catalog.PATCH("/movie/:id", movieController.PatchMovie)
+	catalog.DELETE("/movie/:id", movieController.DeleteMovie)
+
+	route.GET("/movie/:id/copies", inventoryController.GetCopies)
+	inventory := route.Group("", requireAccess(authModel.PermissionManageInventory))
+	inventory.POST("/movie/:id/copies", inventoryController.AddCopy)
+	inventory.POST("/copies/:id/retire", inventoryController.RetireCopy)
+
+	cart := route.Group("/cart", requireAccess(authModel.PermissionCartWrite))
+	cart.POST("/add", cartController.AddToCart)
+	cart.GET("/items", cartController.GetCartItems)
+	cart.DELETE("/items", cartController.ClearCart)
+	cart.DELETE("/items/:itemId", cartController.RemoveFromCart)
+	cart.PATCH("/items/:itemId", cartController.UpdateRentalDays)
+	cart.POST("/checkout", cartController.Checkout)
+	cart.POST("/promo", cartController.ApplyPromotion)
+	cart.DELETE("/promo", cartController.RemovePromotion)
+
+	wishlist := route.Group("/users/:id/wishlist", requireAccess(authModel.PermissionCartWrite), middleware.RequireSelf("id"))
+	wishlist.GET("", wishlistController.GetWishlist)
+	wishlist.POST("/:movieId", wishlistController.AddToWishlist)
+	wishlist.PATCH("/:movieId", wishlistController.UpdateItem)
+	wishlist.DELETE("/:movieId", wishlistController.RemoveFromWishlist)
+	wishlist.POST("/:movieId/cart", wishlistController.MoveToCart)
+
+	route.POST("/promotions", requireAccess(authModel.PermissionManagePromotions), promotionController.CreatePromotion)
+	route.GET("/promotions", requireAccess(authModel.PermissionManagePromotions), promotionController.GetPromotions)
+
+	route.POST("/movie/:id/holds", requireAuth, holdController.PlaceHold)
+	route.GET("/users/:id/holds", requireAuth, middleware.RequireSelf("id"), holdController.GetUserHolds)
+	route.POST("/holds/:id/cart", requireAccess(authModel.PermissionCartWrite), holdController.ConvertToCart)
+
+	rentals := route.Group("/rentals", requireAuth)
+	rentals.POST("/:id/return", rentalController.ReturnRental)
+	rentals.POST("/:id/lost", rentalController.ReportLost)
+	rentals.GET("", rentalController.GetRentals)
 
-	route.POST("/cart/add", cartController.AddToCart)
-	route.GET("/cart/items/:userId", cartController.GetCartItems)
+	fines := route.Group("/users/:id", requireAuth, middleware.RequireSelf("id"))
+	fines.GET("/balance", fineController.GetBalance)
+	fines.POST("/payments", fineController.Pay)
 
 	route.Run(":8080")
 
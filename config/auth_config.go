@@ -0,0 +1,42 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"github.com/joho/godotenv"
+	"log"
+	"os"
+	"time"
+)
+
+// AuthConfig holds the Ed25519 key tokens are signed with. JWT_SIGNING_KEY
+// is the base64 encoded 32 byte seed, for example from
+// `openssl rand -base64 32`; the server refuses to start without it.
+type AuthConfig struct {
+	SigningKey ed25519.PrivateKey
+	Issuer     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+func LoadAuthConfig() AuthConfig {
+	_ = godotenv.Load() // Load .env if exists
+
+	return AuthConfig{
+		SigningKey: loadSigningKey(os.Getenv("JWT_SIGNING_KEY")),
+		Issuer:     "movie-rent",
+		AccessTTL:  time.Duration(getEnvInt("JWT_ACCESS_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTTL: time.Duration(getEnvInt("JWT_REFRESH_TTL_HOURS", 168)) * time.Hour,
+	}
+}
+
+func loadSigningKey(encoded string) ed25519.PrivateKey {
+	if encoded == "" {
+		log.Fatal("JWT_SIGNING_KEY is not set; generate one with `openssl rand -base64 32`")
+	}
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		log.Fatal("JWT_SIGNING_KEY is not a base64 encoded 32 byte seed")
+	}
+	return ed25519.NewKeyFromSeed(seed)
+}
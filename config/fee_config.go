@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/joho/godotenv"
+	"os"
+	"strconv"
+)
+
+// FeeConfig holds the late fee policy. All amounts are in cents.
+type FeeConfig struct {
+	DailyFeeCents     int
+	GracePeriodDays   int
+	MaxLateFeeCents   int
+	LostItemCents     int
+	BalanceLimitCents int
+}
+
+func LoadFeeConfig() FeeConfig {
+	_ = godotenv.Load() // Load .env if exists
+
+	return FeeConfig{
+		DailyFeeCents:     getEnvInt("FEE_DAILY_CENTS", 100),
+		GracePeriodDays:   getEnvInt("FEE_GRACE_PERIOD_DAYS", 1),
+		MaxLateFeeCents:   getEnvInt("FEE_MAX_LATE_CENTS", 1500),
+		LostItemCents:     getEnvInt("FEE_LOST_ITEM_CENTS", 2500),
+		BalanceLimitCents: getEnvInt("FEE_BALANCE_LIMIT_CENTS", 1000),
+	}
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/joho/godotenv"
+	"os"
+	"time"
+)
+
+// PaymentConfig points at the card processor. The in-memory fake gateway is
+// only used when PAYMENT_GATEWAY=fake is set explicitly, for local development.
+type PaymentConfig struct {
+	UseFakeGateway bool
+	GatewayURL     string
+	GatewayAPIKey  string
+	GatewayTimeout time.Duration
+}
+
+func LoadPaymentConfig() PaymentConfig {
+	_ = godotenv.Load() // Load .env if exists
+
+	return PaymentConfig{
+		UseFakeGateway: os.Getenv("PAYMENT_GATEWAY") == "fake",
+		GatewayURL:     os.Getenv("PAYMENT_GATEWAY_URL"),
+		GatewayAPIKey:  os.Getenv("PAYMENT_GATEWAY_API_KEY"),
+		GatewayTimeout: time.Duration(getEnvInt("PAYMENT_GATEWAY_TIMEOUT_SECONDS", 10)) * time.Second,
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import "github.com/joho/godotenv"
+
+type PricingConfig struct {
+	TaxRateBps               int
+	NewReleaseYears          int
+	NewReleasePremiumPercent int
+}
+
+func LoadPricingConfig() PricingConfig {
+	_ = godotenv.Load() // Load .env if exists
+
+	return PricingConfig{
+		TaxRateBps:               getEnvInt("PRICING_TAX_RATE_BPS", 800),
+		NewReleaseYears:          getEnvInt("PRICING_NEW_RELEASE_YEARS", 2),
+		NewReleasePremiumPercent: getEnvInt("PRICING_NEW_RELEASE_PREMIUM_PERCENT", 50),
+	}
+}
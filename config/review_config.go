@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/joho/godotenv"
+	"os"
+	"strings"
+)
+
+// ReviewConfig holds the review moderation policy. REVIEW_BANNED_WORDS is a
+// comma separated list; reviews using any of them wait for a moderator.
+// An approved review goes back to the queue once ReportThreshold customers
+// have reported it.
+type ReviewConfig struct {
+	BannedWords     []string
+	ReportThreshold int
+}
+
+func LoadReviewConfig() ReviewConfig {
+	_ = godotenv.Load() // Load .env if exists
+
+	return ReviewConfig{
+		BannedWords:     splitList(os.Getenv("REVIEW_BANNED_WORDS")),
+		ReportThreshold: getEnvInt("REVIEW_REPORT_THRESHOLD", 3),
+	}
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,6 +1,21 @@
 package constants
 
+import "time"
+
 const (
 	RapidBaseURL = "https://www.rapid.io"
 	RapidPathURL = "/movies"
+
+	// ManualMovieIdStart is where ids of titles added by hand begin. The Rapid
+	// feed supplies its own ids and must stay below it.
+	ManualMovieIdStart = 1000000
+)
+
+const (
+	DefaultRentalDays     = 3
+	HoldPickupWindow      = 48 * time.Hour
+	CartReservationWindow = 30 * time.Minute
+	Currency              = "USD"
+
+	ApiKeyRotationOverlap = 24 * time.Hour
 )
@@ -0,0 +1,30 @@
+// Package postgres holds what every repository needs to read Postgres
+// results: the error codes they translate into domain errors and the row
+// interface their scan helpers take.
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+)
+
+const (
+	UniqueViolation     = "23505"
+	ForeignKeyViolation = "23503"
+)
+
+// Scanner is satisfied by *sql.Row and *sql.Rows, so one scan helper serves
+// single-row and list queries.
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// IsViolation reports whether err is a Postgres error with the code. A
+// non-empty constraint must match too.
+func IsViolation(err error, code string, constraint ...string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || string(pqErr.Code) != code {
+		return false
+	}
+	return len(constraint) == 0 || pqErr.Constraint == constraint[0]
+}
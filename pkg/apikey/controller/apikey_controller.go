@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"movie-rent/constants"
+	"movie-rent/pkg/apikey/model"
+	"movie-rent/pkg/apikey/service"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
+	userModel "movie-rent/pkg/user/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ApiKeyController struct {
+	service service.ApiKeyService
+}
+
+func NewApiKeyController(service service.ApiKeyService) ApiKeyController {
+	return ApiKeyController{service: service}
+}
+
+func (m *ApiKeyController) CreateKey(ctx *gin.Context) {
+	adminId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	var request model.CreateKeyRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	key, err := m.service.CreateKey(request, adminId)
+	if errors.Is(err, model.ErrInvalidScope) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, userModel.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, key)
+}
+
+func (m *ApiKeyController) GetKeys(ctx *gin.Context) {
+	keys, err := m.service.GetKeys()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, keys)
+}
+
+func (m *ApiKeyController) RevokeKey(ctx *gin.Context) {
+	keyId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "id is invalid")
+		return
+	}
+
+	err = m.service.RevokeKey(keyId)
+	if errors.Is(err, model.ErrApiKeyNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (m *ApiKeyController) RotateKey(ctx *gin.Context) {
+	adminId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	keyId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "id is invalid")
+		return
+	}
+	var request model.RotateKeyRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+	overlap := constants.ApiKeyRotationOverlap
+	if request.OverlapMinutes != nil {
+		overlap = time.Duration(*request.OverlapMinutes) * time.Minute
+	}
+
+	key, err := m.service.RotateKey(keyId, overlap, adminId)
+	if errors.Is(err, model.ErrApiKeyNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrApiKeyRevoked) || errors.Is(err, model.ErrApiKeyExpired) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, key)
+}
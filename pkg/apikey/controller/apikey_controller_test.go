@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/constants"
+	"movie-rent/pkg/apikey/mocks"
+	"movie-rent/pkg/apikey/model"
+	"movie-rent/pkg/auth/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ApiKeyControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockApiKeyService *mocks.MockApiKeyService
+	testController    ApiKeyController
+}
+
+func TestApiKeyControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyControllerTestSuite))
+}
+
+func (suite *ApiKeyControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Set(middleware.UserIdKey, 1)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockApiKeyService = mocks.NewMockApiKeyService(suite.mockController)
+	suite.testController = NewApiKeyController(suite.mockApiKeyService)
+}
+
+func (suite *ApiKeyControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *ApiKeyControllerTestSuite) Test_CreateKey_ShouldReturnBadRequestForInvalidScope() {
+	request := model.CreateKeyRequest{Name: "kiosk", UserId: 1001, Scopes: []string{"users:manage"}}
+	body := `{"name":"kiosk","userId":1001,"scopes":["users:manage"]}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/api-keys", strings.NewReader(body))
+	suite.mockApiKeyService.EXPECT().CreateKey(request, 1).Return(model.IssuedKey{}, model.ErrInvalidScope).Times(1)
+
+	suite.testController.CreateKey(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ApiKeyControllerTestSuite) Test_CreateKey_ShouldReturnPlainKeyOnce() {
+	request := model.CreateKeyRequest{Name: "kiosk", UserId: 1001, Scopes: []string{"catalog:manage"}}
+	body := `{"name":"kiosk","userId":1001,"scopes":["catalog:manage"]}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/api-keys", strings.NewReader(body))
+	issued := model.IssuedKey{ApiKey: model.ApiKey{Id: 7, KeyHash: "stored-hash"}, Key: "mr_abc_secret"}
+	suite.mockApiKeyService.EXPECT().CreateKey(request, 1).Return(issued, nil).Times(1)
+
+	suite.testController.CreateKey(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), "mr_abc_secret")
+	suite.NotContains(suite.recorder.Body.String(), "stored-hash")
+}
+
+func (suite *ApiKeyControllerTestSuite) Test_RevokeKey_ShouldReturnNotFound() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/api-keys/7", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockApiKeyService.EXPECT().RevokeKey(7).Return(model.ErrApiKeyNotFound).Times(1)
+
+	suite.testController.RevokeKey(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *ApiKeyControllerTestSuite) Test_RotateKey_ShouldUseDefaultOverlapWithoutBody() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/api-keys/7/rotate", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockApiKeyService.EXPECT().RotateKey(7, constants.ApiKeyRotationOverlap, 1).Return(model.IssuedKey{}, nil).Times(1)
+
+	suite.testController.RotateKey(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *ApiKeyControllerTestSuite) Test_RotateKey_ShouldReturnConflictForRevokedKey() {
+	body := `{"overlapMinutes":30}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/api-keys/7/rotate", strings.NewReader(body))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockApiKeyService.EXPECT().RotateKey(7, 30*time.Minute, 1).Return(model.IssuedKey{}, model.ErrApiKeyRevoked).Times(1)
+
+	suite.testController.RotateKey(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
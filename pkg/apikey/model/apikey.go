@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// ApiKey is a credential for kiosks and partner integrations. Only a hash of
+// the secret is stored; the prefix identifies the key without revealing it.
+type ApiKey struct {
+	Id          int        `json:"id"`
+	Name        string     `json:"name"`
+	UserId      int        `json:"userId"`
+	Prefix      string     `json:"prefix"`
+	KeyHash     string     `json:"-"`
+	OwnerRole   string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	RevokedAt   *time.Time `json:"revokedAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	RotatedFrom *int       `json:"rotatedFrom,omitempty"`
+	CreatedBy   int        `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// IssuedKey carries the plain key, which is shown once and never stored.
+type IssuedKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+type CreateKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	UserId    int        `json:"userId" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type RotateKeyRequest struct {
+	OverlapMinutes *int `json:"overlapMinutes" binding:"omitempty,min=0"`
+}
+
+func (k ApiKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,11 @@
+package model
+
+import "errors"
+
+var (
+	ErrApiKeyNotFound = errors.New("api key not found")
+	ErrInvalidApiKey  = errors.New("api key is invalid")
+	ErrApiKeyExpired  = errors.New("api key has expired")
+	ErrApiKeyRevoked  = errors.New("api key has been revoked")
+	ErrInvalidScope   = errors.New("scope cannot be granted to an api key")
+)
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/apikey/model"
+	userModel "movie-rent/pkg/user/model"
+	"time"
+)
+
+const (
+	ApiKeyColumns   = `id, name, user_id, prefix, key_hash, scopes, expires_at, revoked_at, last_used_at, rotated_from, created_by, created_at`
+	InsertApiKeySQL = `INSERT INTO api_keys(name, user_id, prefix, key_hash, scopes, expires_at, rotated_from, created_by, created_at) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	SelectApiKeysSQL        = `SELECT ` + ApiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC`
+	SelectApiKeyByIdSQL     = `SELECT ` + ApiKeyColumns + ` FROM api_keys WHERE id = $1`
+	SelectApiKeyByPrefixSQL = `SELECT ` + ApiKeyColumns + `, COALESCE((SELECT role FROM users WHERE users.id = api_keys.user_id), '') ` +
+		`FROM api_keys WHERE prefix = $1`
+	RevokeApiKeySQL  = `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2`
+	ShortenApiKeySQL = `UPDATE api_keys SET expires_at = LEAST(COALESCE(expires_at, $1), $1) WHERE id = $2 AND revoked_at IS NULL`
+	TouchApiKeySQL   = `UPDATE api_keys SET last_used_at = $1 WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)`
+)
+
+type ApiKeyRepository interface {
+	CreateKey(key model.ApiKey) (int, error)
+	GetKeys() ([]model.ApiKey, error)
+	GetKey(keyId int) (model.ApiKey, error)
+	GetKeyByPrefix(prefix string) (model.ApiKey, error)
+	RevokeKey(keyId int, revokedAt time.Time) error
+	RotateKey(oldKeyId int, oldExpiresAt time.Time, key model.ApiKey) (int, error)
+	TouchLastUsed(keyId int, usedAt time.Time, staleBefore time.Time) error
+}
+
+type apiKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewApiKeyRepository(db *sqlx.DB) ApiKeyRepository {
+	return &apiKeyRepo{db: db}
+}
+
+func (m apiKeyRepo) CreateKey(key model.ApiKey) (int, error) {
+	return insertKey(m.db, key)
+}
+
+// RotateKey stores the replacement key and brings the old key's expiry
+// forward to the end of the overlap window, in one transaction.
+func (m apiKeyRepo) RotateKey(oldKeyId int, oldExpiresAt time.Time, key model.ApiKey) (int, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin key rotation: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(ShortenApiKeySQL, oldExpiresAt, oldKeyId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire rotated key: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		return 0, model.ErrApiKeyRevoked
+	}
+
+	id, err := insertKey(tx, key)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+	return id, nil
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func insertKey(db queryRower, key model.ApiKey) (int, error) {
+	var id int
+	err := db.QueryRow(InsertApiKeySQL, key.Name, key.UserId, key.Prefix, key.KeyHash, pq.Array(key.Scopes),
+		key.ExpiresAt, key.RotatedFrom, key.CreatedBy, key.CreatedAt).Scan(&id)
+
+	if postgres.IsViolation(err, postgres.ForeignKeyViolation) {
+		return 0, userModel.ErrUserNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert api key: %w", err)
+	}
+	fmt.Println("Successfully inserted api key. Id:", id)
+	return id, nil
+}
+
+func (m apiKeyRepo) GetKeys() ([]model.ApiKey, error) {
+	rows, err := m.db.Query(SelectApiKeysSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []model.ApiKey{}
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (m apiKeyRepo) GetKey(keyId int) (model.ApiKey, error) {
+	return m.getKey(SelectApiKeyByIdSQL, keyId)
+}
+
+// GetKeyByPrefix also loads the owner's current role, which caps what the key
+// may do.
+func (m apiKeyRepo) GetKeyByPrefix(prefix string) (model.ApiKey, error) {
+	var ownerRole string
+	key, err := m.getKey(SelectApiKeyByPrefixSQL, prefix, &ownerRole)
+	key.OwnerRole = ownerRole
+	return key, err
+}
+
+func (m apiKeyRepo) getKey(query string, arg any, extra ...any) (model.ApiKey, error) {
+	key, err := scanKey(m.db.QueryRow(query, arg), extra...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ApiKey{}, model.ErrApiKeyNotFound
+	}
+	if err != nil {
+		return model.ApiKey{}, fmt.Errorf("failed to fetch api key: %w", err)
+	}
+	return key, nil
+}
+
+func (m apiKeyRepo) RevokeKey(keyId int, revokedAt time.Time) error {
+	res, err := m.db.Exec(RevokeApiKeySQL, revokedAt, keyId)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records use of the key, skipping the write when it was
+// already recorded after staleBefore.
+func (m apiKeyRepo) TouchLastUsed(keyId int, usedAt time.Time, staleBefore time.Time) error {
+	if _, err := m.db.Exec(TouchApiKeySQL, usedAt, keyId, staleBefore); err != nil {
+		return fmt.Errorf("failed to record api key use: %w", err)
+	}
+	return nil
+}
+
+func scanKey(row postgres.Scanner, extra ...any) (model.ApiKey, error) {
+	var k model.ApiKey
+	dest := append([]any{&k.Id, &k.Name, &k.UserId, &k.Prefix, &k.KeyHash, pq.Array(&k.Scopes), &k.ExpiresAt, &k.RevokedAt,
+		&k.LastUsedAt, &k.RotatedFrom, &k.CreatedBy, &k.CreatedAt}, extra...)
+	err := row.Scan(dest...)
+	return k, err
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/apikey/model"
+	userModel "movie-rent/pkg/user/model"
+	"testing"
+	"time"
+)
+
+type ApiKeyRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository ApiKeyRepository
+}
+
+func TestApiKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyRepositoryTestSuite))
+}
+
+func (suite *ApiKeyRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewApiKeyRepository(suite.mockedDB)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_CreateKey_ShouldReturnUserNotFoundForUnknownOwner() {
+	now := time.Now()
+	key := model.ApiKey{Name: "kiosk", UserId: 404, Prefix: "abc", KeyHash: "hash", Scopes: []string{"catalog:manage"},
+		CreatedBy: 1, CreatedAt: now}
+	suite.mockDB.ExpectQuery(InsertApiKeySQL).
+		WithArgs("kiosk", 404, "abc", "hash", pq.Array(key.Scopes), key.ExpiresAt, key.RotatedFrom, 1, now).
+		WillReturnError(&pq.Error{Code: postgres.ForeignKeyViolation})
+
+	_, err := suite.testRepository.CreateKey(key)
+
+	suite.ErrorIs(err, userModel.ErrUserNotFound)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_GetKeyByPrefix_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectApiKeyByPrefixSQL).WithArgs("abc").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetKeyByPrefix("abc")
+
+	suite.ErrorIs(err, model.ErrApiKeyNotFound)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_GetKeyByPrefix_ShouldLoadOwnerRole() {
+	now := time.Now()
+	suite.mockDB.ExpectQuery(SelectApiKeyByPrefixSQL).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"id", "name",
+		"user_id", "prefix", "key_hash", "scopes", "expires_at", "revoked_at", "last_used_at", "rotated_from",
+		"created_by", "created_at", "role"}).AddRow(7, "kiosk", 1001, "abc", "hash", "{cart:write}", nil, nil, nil,
+		nil, 1, now, "customer"))
+
+	key, err := suite.testRepository.GetKeyByPrefix("abc")
+
+	suite.Nil(err)
+	suite.Equal("customer", key.OwnerRole)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_GetKey_ShouldScanScopes() {
+	now := time.Now()
+	suite.mockDB.ExpectQuery(SelectApiKeyByIdSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "name",
+		"user_id", "prefix", "key_hash", "scopes", "expires_at", "revoked_at", "last_used_at", "rotated_from",
+		"created_by", "created_at"}).AddRow(7, "kiosk", 1001, "abc", "hash", "{catalog:manage,cart:write}", nil, nil, nil,
+		nil, 1, now))
+
+	key, err := suite.testRepository.GetKey(7)
+
+	suite.Nil(err)
+	suite.Equal([]string{"catalog:manage", "cart:write"}, key.Scopes)
+	suite.Nil(key.ExpiresAt)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_RevokeKey_ShouldReturnNotFound() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(RevokeApiKeySQL).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.RevokeKey(7, now)
+
+	suite.ErrorIs(err, model.ErrApiKeyNotFound)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_RotateKey_ShouldShortenOldKeyAndInsertReplacement() {
+	now := time.Now()
+	oldId := 7
+	key := model.ApiKey{Name: "kiosk", UserId: 1001, Prefix: "def", KeyHash: "hash", Scopes: []string{"cart:write"},
+		RotatedFrom: &oldId, CreatedBy: 1, CreatedAt: now}
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(ShortenApiKeySQL).WithArgs(now.Add(time.Hour), 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(InsertApiKeySQL).
+		WithArgs("kiosk", 1001, "def", "hash", pq.Array(key.Scopes), key.ExpiresAt, key.RotatedFrom, 1, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	suite.mockDB.ExpectCommit()
+
+	id, err := suite.testRepository.RotateKey(7, now.Add(time.Hour), key)
+
+	suite.Nil(err)
+	suite.Equal(8, id)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) Test_RotateKey_ShouldRejectRevokedKey() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(ShortenApiKeySQL).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.RotateKey(7, now, model.ApiKey{})
+
+	suite.ErrorIs(err, model.ErrApiKeyRevoked)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/apikey/mocks"
+	"movie-rent/pkg/apikey/model"
+	authModel "movie-rent/pkg/auth/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ApiKeyServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockApiKeyRepository
+	apiKeyService  ApiKeyService
+}
+
+func TestApiKeyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyServiceTestSuite))
+}
+
+func (suite *ApiKeyServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockApiKeyRepository(suite.mockController)
+	suite.apiKeyService = NewApiKeyService(suite.mockRepository)
+}
+
+func (suite *ApiKeyServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_CreateKey_ShouldStoreHashOnly() {
+	request := model.CreateKeyRequest{Name: "kiosk", UserId: 1001, Scopes: []string{authModel.PermissionManageCatalog}}
+	var stored model.ApiKey
+	suite.mockRepository.EXPECT().CreateKey(gomock.Any()).DoAndReturn(func(key model.ApiKey) (int, error) {
+		stored = key
+		return 7, nil
+	}).Times(1)
+
+	issued, err := suite.apiKeyService.CreateKey(request, 1)
+
+	suite.Nil(err)
+	suite.Equal(7, issued.Id)
+	suite.True(strings.HasPrefix(issued.Key, "mr_"+stored.Prefix+"_"))
+	suite.Equal(hashKey(issued.Key), stored.KeyHash)
+	suite.NotContains(stored.KeyHash, issued.Key)
+	suite.Equal(1, stored.CreatedBy)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_CreateKey_ShouldRejectAdminOnlyScope() {
+	request := model.CreateKeyRequest{Name: "kiosk", UserId: 1001, Scopes: []string{authModel.PermissionManageUsers}}
+
+	_, err := suite.apiKeyService.CreateKey(request, 1)
+
+	suite.ErrorIs(err, model.ErrInvalidScope)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_Authenticate_ShouldReturnKeyAndRecordUse() {
+	issued, err := newKey(model.ApiKey{Id: 7, UserId: 1001})
+	suite.Require().Nil(err)
+	suite.mockRepository.EXPECT().GetKeyByPrefix(issued.Prefix).Return(issued.ApiKey, nil).Times(1)
+	suite.mockRepository.EXPECT().TouchLastUsed(7, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	key, err := suite.apiKeyService.Authenticate(issued.Key)
+
+	suite.Nil(err)
+	suite.Equal(1001, key.UserId)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_Authenticate_ShouldRejectWrongSecret() {
+	issued, err := newKey(model.ApiKey{Id: 7})
+	suite.Require().Nil(err)
+	suite.mockRepository.EXPECT().GetKeyByPrefix(issued.Prefix).Return(issued.ApiKey, nil).Times(1)
+
+	_, err = suite.apiKeyService.Authenticate("mr_" + issued.Prefix + "_guessed")
+
+	suite.ErrorIs(err, model.ErrInvalidApiKey)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_Authenticate_ShouldRejectMalformedKey() {
+	_, err := suite.apiKeyService.Authenticate("not-a-key")
+
+	suite.ErrorIs(err, model.ErrInvalidApiKey)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_Authenticate_ShouldRejectRevokedKey() {
+	revokedAt := time.Now().Add(-time.Hour)
+	issued, err := newKey(model.ApiKey{Id: 7, RevokedAt: &revokedAt})
+	suite.Require().Nil(err)
+	suite.mockRepository.EXPECT().GetKeyByPrefix(issued.Prefix).Return(issued.ApiKey, nil).Times(1)
+
+	_, err = suite.apiKeyService.Authenticate(issued.Key)
+
+	suite.ErrorIs(err, model.ErrApiKeyRevoked)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_Authenticate_ShouldRejectExpiredKey() {
+	expiresAt := time.Now().Add(-time.Minute)
+	issued, err := newKey(model.ApiKey{Id: 7, ExpiresAt: &expiresAt})
+	suite.Require().Nil(err)
+	suite.mockRepository.EXPECT().GetKeyByPrefix(issued.Prefix).Return(issued.ApiKey, nil).Times(1)
+
+	_, err = suite.apiKeyService.Authenticate(issued.Key)
+
+	suite.ErrorIs(err, model.ErrApiKeyExpired)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_RotateKey_ShouldKeepOldKeyForOverlap() {
+	old := model.ApiKey{Id: 7, Name: "kiosk", UserId: 1001, Scopes: []string{authModel.PermissionCartWrite}}
+	suite.mockRepository.EXPECT().GetKey(7).Return(old, nil).Times(1)
+	var oldExpiresAt time.Time
+	var replacement model.ApiKey
+	suite.mockRepository.EXPECT().RotateKey(7, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ int, expiresAt time.Time, key model.ApiKey) (int, error) {
+			oldExpiresAt, replacement = expiresAt, key
+			return 8, nil
+		}).Times(1)
+
+	issued, err := suite.apiKeyService.RotateKey(7, time.Hour, 1)
+
+	suite.Nil(err)
+	suite.Equal(8, issued.Id)
+	suite.WithinDuration(time.Now().Add(time.Hour), oldExpiresAt, time.Minute)
+	suite.Equal(7, *replacement.RotatedFrom)
+	suite.Equal(old.Scopes, replacement.Scopes)
+	suite.Equal(1001, replacement.UserId)
+}
+
+func (suite *ApiKeyServiceTestSuite) Test_RotateKey_ShouldRejectRevokedKey() {
+	revokedAt := time.Now()
+	suite.mockRepository.EXPECT().GetKey(7).Return(model.ApiKey{Id: 7, RevokedAt: &revokedAt}, nil).Times(1)
+
+	_, err := suite.apiKeyService.RotateKey(7, time.Hour, 1)
+
+	suite.ErrorIs(err, model.ErrApiKeyRevoked)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"movie-rent/pkg/auth/middleware"
+	"movie-rent/pkg/auth/model"
+	"movie-rent/pkg/auth/service"
+	"net/http"
+)
+
+type AuthController struct {
+	service service.AuthService
+}
+
+func NewAuthController(service service.AuthService) AuthController {
+	return AuthController{service: service}
+}
+
+func (m *AuthController) Login(ctx *gin.Context) {
+	var request model.LoginRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	pair, err := m.service.Login(request)
+	if errors.Is(err, model.ErrInvalidCredentials) {
+		ctx.JSON(http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, pair)
+}
+
+func (m *AuthController) Refresh(ctx *gin.Context) {
+	var request model.RefreshRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	pair, err := m.service.Refresh(request.RefreshToken)
+	if errors.Is(err, model.ErrInvalidToken) || errors.Is(err, model.ErrTokenExpired) || errors.Is(err, model.ErrTokenRevoked) {
+		ctx.JSON(http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, pair)
+}
+
+func (m *AuthController) Logout(ctx *gin.Context) {
+	claims, ok := middleware.Claims(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, model.ErrUnauthenticated.Error())
+		return
+	}
+	var request model.LogoutRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	err := m.service.Logout(claims, request.RefreshToken)
+	if errors.Is(err, model.ErrInvalidToken) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
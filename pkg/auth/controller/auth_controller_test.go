@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/middleware"
+	"movie-rent/pkg/auth/mocks"
+	"movie-rent/pkg/auth/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type AuthControllerTestSuite struct {
+	suite.Suite
+	context         *gin.Context
+	recorder        *httptest.ResponseRecorder
+	mockController  *gomock.Controller
+	mockAuthService *mocks.MockAuthService
+	testController  AuthController
+}
+
+func TestAuthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthControllerTestSuite))
+}
+
+func (suite *AuthControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockAuthService = mocks.NewMockAuthService(suite.mockController)
+	suite.testController = NewAuthController(suite.mockAuthService)
+}
+
+func (suite *AuthControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *AuthControllerTestSuite) Test_Login_ShouldReturnUnauthorizedForBadCredentials() {
+	body := `{"email":"ada@example.com","password":"wrong horse"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	suite.mockAuthService.EXPECT().Login(model.LoginRequest{Email: "ada@example.com", Password: "wrong horse"}).
+		Return(model.TokenPair{}, model.ErrInvalidCredentials).Times(1)
+
+	suite.testController.Login(suite.context)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *AuthControllerTestSuite) Test_Login_ShouldReturnTokenPair() {
+	body := `{"email":"ada@example.com","password":"correct horse"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	suite.mockAuthService.EXPECT().Login(model.LoginRequest{Email: "ada@example.com", Password: "correct horse"}).
+		Return(model.TokenPair{AccessToken: "a", RefreshToken: "r", TokenType: "Bearer", ExpiresIn: 900}, nil).Times(1)
+
+	suite.testController.Login(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"accessToken":"a","refreshToken":"r","tokenType":"Bearer","expiresIn":900}`, suite.recorder.Body.String())
+}
+
+func (suite *AuthControllerTestSuite) Test_Refresh_ShouldReturnUnauthorizedForRevokedToken() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refreshToken":"r"}`))
+	suite.mockAuthService.EXPECT().Refresh("r").Return(model.TokenPair{}, model.ErrTokenRevoked).Times(1)
+
+	suite.testController.Refresh(suite.context)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *AuthControllerTestSuite) Test_Logout_ShouldRevokeTokens() {
+	claims := model.Claims{Subject: "1001", Id: "abc"}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(`{"refreshToken":"r"}`))
+	suite.context.Set(middleware.ClaimsKey, claims)
+	suite.mockAuthService.EXPECT().Logout(claims, "r").Return(nil).Times(1)
+
+	suite.testController.Logout(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
+
+func (suite *AuthControllerTestSuite) Test_Logout_ShouldAcceptEmptyBody() {
+	claims := model.Claims{Subject: "1001", Id: "abc"}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	suite.context.Set(middleware.ClaimsKey, claims)
+	suite.mockAuthService.EXPECT().Logout(claims, "").Return(nil).Times(1)
+
+	suite.testController.Logout(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	apiKeyMocks "movie-rent/pkg/apikey/mocks"
+	apiKeyModel "movie-rent/pkg/apikey/model"
+	"movie-rent/pkg/auth/mocks"
+	"movie-rent/pkg/auth/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+	mockController  *gomock.Controller
+	mockAuthService *mocks.MockAuthService
+	mockApiKeys     *apiKeyMocks.MockApiKeyService
+	requireAuth     gin.HandlerFunc
+	router          *gin.Engine
+	recorder        *httptest.ResponseRecorder
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockAuthService = mocks.NewMockAuthService(suite.mockController)
+	suite.mockApiKeys = apiKeyMocks.NewMockApiKeyService(suite.mockController)
+	suite.requireAuth = RequireAuth(suite.mockAuthService)
+	suite.recorder = httptest.NewRecorder()
+	suite.router = gin.New()
+	suite.router.GET("/me", suite.requireAuth, func(ctx *gin.Context) {
+		userId, _ := UserId(ctx)
+		ctx.JSON(http.StatusOK, userId)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAuth_ShouldRejectMissingToken() {
+	suite.router.ServeHTTP(suite.recorder, httptest.NewRequest(http.MethodGet, "/me", nil))
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAuth_ShouldRejectRevokedToken() {
+	request := httptest.NewRequest(http.MethodGet, "/me", nil)
+	request.Header.Set("Authorization", "Bearer revoked")
+	suite.mockAuthService.EXPECT().Authenticate("revoked").Return(model.Claims{}, model.ErrTokenRevoked).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAuth_ShouldExposeUserFromToken() {
+	request := httptest.NewRequest(http.MethodGet, "/me", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Type: model.TokenAccess}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("1001", suite.recorder.Body.String())
+}
+
+func (suite *MiddlewareTestSuite) Test_RequirePermission_ShouldForbidCustomer() {
+	suite.router.POST("/movie", suite.requireAuth, RequirePermission(model.PermissionManageCatalog),
+		func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+	request := httptest.NewRequest(http.MethodPost, "/movie", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Role: "customer"}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequirePermission_ShouldAllowStaff() {
+	suite.router.POST("/movie", suite.requireAuth, RequirePermission(model.PermissionManageCatalog),
+		func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+	request := httptest.NewRequest(http.MethodPost, "/movie", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Role: "staff"}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAuth_ShouldRejectApiKey() {
+	request := httptest.NewRequest(http.MethodGet, "/me", nil)
+	request.Header.Set("Authorization", "ApiKey mr_abc_secret")
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAccess_ShouldExposeApiKeyOwner() {
+	suite.router.POST("/cart/add", RequireAccess(suite.mockAuthService, suite.mockApiKeys, model.PermissionCartWrite),
+		func(ctx *gin.Context) {
+			userId, _ := UserId(ctx)
+			ctx.JSON(http.StatusOK, userId)
+		})
+	request := httptest.NewRequest(http.MethodPost, "/cart/add", nil)
+	request.Header.Set("Authorization", "ApiKey mr_abc_secret")
+	key := apiKeyModel.ApiKey{UserId: 1001, Prefix: "abc", OwnerRole: "customer", Scopes: []string{model.PermissionCartWrite}}
+	suite.mockApiKeys.EXPECT().Authenticate("mr_abc_secret").Return(key, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("1001", suite.recorder.Body.String())
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAccess_ShouldRejectExpiredApiKey() {
+	suite.router.POST("/cart/add", RequireAccess(suite.mockAuthService, suite.mockApiKeys, model.PermissionCartWrite),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodPost, "/cart/add", nil)
+	request.Header.Set("Authorization", "ApiKey mr_abc_secret")
+	suite.mockApiKeys.EXPECT().Authenticate("mr_abc_secret").Return(apiKeyModel.ApiKey{}, apiKeyModel.ErrApiKeyExpired).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAccess_ShouldLimitApiKeyToScopes() {
+	suite.router.POST("/cart/add", RequireAccess(suite.mockAuthService, suite.mockApiKeys, model.PermissionCartWrite),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodPost, "/cart/add", nil)
+	request.Header.Set("Authorization", "ApiKey mr_abc_secret")
+	key := apiKeyModel.ApiKey{UserId: 1001, Prefix: "abc", OwnerRole: "staff", Scopes: []string{model.PermissionManageCatalog}}
+	suite.mockApiKeys.EXPECT().Authenticate("mr_abc_secret").Return(key, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireAccess_ShouldAllowBearerToken() {
+	suite.router.POST("/cart/add", RequireAccess(suite.mockAuthService, suite.mockApiKeys, model.PermissionCartWrite),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodPost, "/cart/add", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Role: "customer"}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireSelf_ShouldForbidOtherUser() {
+	suite.router.GET("/users/:id", suite.requireAuth, RequireSelf("id"),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodGet, "/users/1002", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Type: model.TokenAccess}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *MiddlewareTestSuite) Test_RequireSelf_ShouldAllowOwnUser() {
+	suite.router.GET("/users/:id", suite.requireAuth, RequireSelf("id"),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	request := httptest.NewRequest(http.MethodGet, "/users/1001", nil)
+	request.Header.Set("Authorization", "Bearer valid")
+	suite.mockAuthService.EXPECT().Authenticate("valid").Return(model.Claims{Subject: "1001", Type: model.TokenAccess}, nil).Times(1)
+
+	suite.router.ServeHTTP(suite.recorder, request)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/auth/token/token.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/auth/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSigner is a mock of Signer interface.
+type MockSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignerMockRecorder
+}
+
+// MockSignerMockRecorder is the mock recorder for MockSigner.
+type MockSignerMockRecorder struct {
+	mock *MockSigner
+}
+
+// NewMockSigner creates a new mock instance.
+func NewMockSigner(ctrl *gomock.Controller) *MockSigner {
+	mock := &MockSigner{ctrl: ctrl}
+	mock.recorder = &MockSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigner) EXPECT() *MockSignerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockSigner) Issue(userId int, role, tokenType string, ttl time.Duration, now time.Time) (string, model.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", userId, role, tokenType, ttl, now)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(model.Claims)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockSignerMockRecorder) Issue(userId, role, tokenType, ttl, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockSigner)(nil).Issue), userId, role, tokenType, ttl, now)
+}
+
+// Parse mocks base method.
+func (m *MockSigner) Parse(token string, now time.Time) (model.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", token, now)
+	ret0, _ := ret[0].(model.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockSignerMockRecorder) Parse(token, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockSigner)(nil).Parse), token, now)
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	TokenAccess  = "access"
+	TokenRefresh = "refresh"
+	TokenApiKey  = "api_key"
+)
+
+// Claims is the payload of a signed token. Callers authenticated with an API
+// key are described by the same struct, with the key's scopes next to the
+// owner's role.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Type      string   `json:"typ"`
+	Role      string   `json:"role,omitempty"`
+	Scopes    []string `json:"scp,omitempty"`
+	Id        string   `json:"jti"`
+	Issuer    string   `json:"iss"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+func (c Claims) UserId() (int, error) {
+	return strconv.Atoi(c.Subject)
+}
+
+func (c Claims) Expiry() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Allows reports whether the caller holds the permission through its role.
+// API keys must also carry the permission as a scope, so a key never does
+// more than its owner currently may.
+func (c Claims) Allows(permission string) bool {
+	if c.Type == TokenApiKey && !contains(c.Scopes, permission) {
+		return false
+	}
+	return HasPermission(c.Role, permission)
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+var (
+	ErrInvalidCredentials = errors.New("email or password is incorrect")
+	ErrInvalidToken       = errors.New("token is invalid")
+	ErrTokenExpired       = errors.New("token has expired")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrForbidden          = errors.New("not allowed for your role")
+	ErrApiKeyNotAccepted  = errors.New("api keys are not accepted for this route")
+)
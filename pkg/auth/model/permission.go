@@ -0,0 +1,48 @@
+package model
+
+import userModel "movie-rent/pkg/user/model"
+
+const (
+	PermissionCartWrite        = "cart:write"
+	PermissionImportCatalog    = "admin:import"
+	PermissionManageCatalog    = "catalog:manage"
+	PermissionManageInventory  = "inventory:manage"
+	PermissionManageUsers      = "users:manage"
+	PermissionManageApiKeys    = "apikeys:manage"
+	PermissionModerateReviews  = "reviews:moderate"
+	PermissionManagePromotions = "promotions:manage"
+)
+
+var customerPermissions = []string{PermissionCartWrite}
+
+var staffPermissions = append([]string{PermissionImportCatalog, PermissionManageCatalog, PermissionManageInventory,
+	PermissionModerateReviews, PermissionManagePromotions}, customerPermissions...)
+
+var rolePermissions = map[string][]string{
+	userModel.RoleCustomer: customerPermissions,
+	userModel.RoleStaff:    staffPermissions,
+	userModel.RoleAdmin:    append([]string{PermissionManageUsers, PermissionManageApiKeys}, staffPermissions...),
+}
+
+// KeyScopes are the permissions an API key may be granted. Managing users and
+// keys stays with interactive admins.
+var KeyScopes = append([]string{}, staffPermissions...)
+
+// HasPermission reports whether the role grants the permission. Unknown roles
+// hold none.
+func HasPermission(role string, permission string) bool {
+	return contains(rolePermissions[role], permission)
+}
+
+func IsKeyScope(scope string) bool {
+	return contains(KeyScopes, scope)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PermissionTestSuite struct {
+	suite.Suite
+}
+
+func TestPermissionTestSuite(t *testing.T) {
+	suite.Run(t, new(PermissionTestSuite))
+}
+
+func (suite *PermissionTestSuite) Test_HasPermission_ShouldLimitCustomersToRenting() {
+	suite.True(HasPermission("customer", PermissionCartWrite))
+	suite.False(HasPermission("customer", PermissionManageCatalog))
+	suite.False(HasPermission("customer", PermissionModerateReviews))
+	suite.False(HasPermission("", PermissionCartWrite))
+}
+
+func (suite *PermissionTestSuite) Test_HasPermission_ShouldLetStaffManageCatalogOnly() {
+	suite.True(HasPermission("staff", PermissionManageCatalog))
+	suite.True(HasPermission("staff", PermissionManageInventory))
+	suite.True(HasPermission("staff", PermissionModerateReviews))
+	suite.False(HasPermission("staff", PermissionManageUsers))
+}
+
+func (suite *PermissionTestSuite) Test_HasPermission_ShouldGrantEverythingToAdmins() {
+	suite.True(HasPermission("admin", PermissionManageCatalog))
+	suite.True(HasPermission("admin", PermissionManageUsers))
+	suite.True(HasPermission("admin", PermissionManageApiKeys))
+}
+
+func (suite *PermissionTestSuite) Test_IsKeyScope_ShouldExcludeAdminPermissions() {
+	suite.True(IsKeyScope(PermissionImportCatalog))
+	suite.False(IsKeyScope(PermissionManageUsers))
+	suite.False(IsKeyScope(PermissionManageApiKeys))
+}
+
+func (suite *PermissionTestSuite) Test_Allows_ShouldUseScopesForApiKeys() {
+	key := Claims{Type: TokenApiKey, Role: "admin", Scopes: []string{PermissionCartWrite}}
+
+	suite.True(key.Allows(PermissionCartWrite))
+	suite.False(key.Allows(PermissionManageUsers))
+}
+
+func (suite *PermissionTestSuite) Test_Allows_ShouldLimitApiKeysToOwnerRole() {
+	key := Claims{Type: TokenApiKey, Role: "customer", Scopes: []string{PermissionManageCatalog, PermissionCartWrite}}
+
+	suite.False(key.Allows(PermissionManageCatalog))
+	suite.True(key.Allows(PermissionCartWrite))
+}
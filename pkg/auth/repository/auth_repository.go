@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+const (
+	InsertRevokedTokenSQL  = `INSERT INTO revoked_tokens(jti, user_id, expires_at, revoked_at) VALUES ($1, $2, $3, $4) ON CONFLICT (jti) DO NOTHING`
+	SelectTokenRevokedSQL  = `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+	DeleteExpiredTokensSQL = `DELETE FROM revoked_tokens WHERE expires_at < $1`
+)
+
+type AuthRepository interface {
+	Revoke(jti string, userId int, expiresAt time.Time, revokedAt time.Time) (bool, error)
+	IsRevoked(jti string) (bool, error)
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+type authRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) AuthRepository {
+	return &authRepo{db: db}
+}
+
+// Revoke adds the token to the revocation list and reports whether this call
+// revoked it, so a token can only be spent once.
+func (m authRepo) Revoke(jti string, userId int, expiresAt time.Time, revokedAt time.Time) (bool, error) {
+	res, err := m.db.Exec(InsertRevokedTokenSQL, jti, userId, expiresAt, revokedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke token: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return affected == 1, nil
+}
+
+func (m authRepo) IsRevoked(jti string) (bool, error) {
+	var revoked bool
+	if err := m.db.QueryRow(SelectTokenRevokedSQL, jti).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("failed to check token: %w", err)
+	}
+	return revoked, nil
+}
+
+// PurgeExpired drops revocations of tokens that have expired anyway.
+func (m authRepo) PurgeExpired(now time.Time) (int64, error) {
+	res, err := m.db.Exec(DeleteExpiredTokensSQL, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+	return res.RowsAffected()
+}
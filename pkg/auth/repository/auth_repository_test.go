@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type AuthRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository AuthRepository
+}
+
+func TestAuthRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthRepositoryTestSuite))
+}
+
+func (suite *AuthRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewAuthRepository(suite.mockedDB)
+}
+
+func (suite *AuthRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *AuthRepositoryTestSuite) Test_Revoke_ShouldReportAlreadyRevokedToken() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(InsertRevokedTokenSQL).WithArgs("abc", 1001, now.Add(time.Hour), now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	revoked, err := suite.testRepository.Revoke("abc", 1001, now.Add(time.Hour), now)
+
+	suite.Nil(err)
+	suite.False(revoked)
+}
+
+func (suite *AuthRepositoryTestSuite) Test_IsRevoked_ShouldCheckRevocationList() {
+	suite.mockDB.ExpectQuery(SelectTokenRevokedSQL).WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	revoked, err := suite.testRepository.IsRevoked("abc")
+
+	suite.Nil(err)
+	suite.True(revoked)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"movie-rent/config"
+	"movie-rent/pkg/auth/model"
+	"movie-rent/pkg/auth/repository"
+	"movie-rent/pkg/auth/token"
+	userModel "movie-rent/pkg/user/model"
+	userRepository "movie-rent/pkg/user/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/auth/service/auth_service.go -destination=pkg/auth/mocks/auth_service_mock.go -package=mocks
+
+type AuthService interface {
+	Login(request model.LoginRequest) (model.TokenPair, error)
+	Refresh(refreshToken string) (model.TokenPair, error)
+	Logout(access model.Claims, refreshToken string) error
+	Authenticate(accessToken string) (model.Claims, error)
+	PurgeRevoked() error
+}
+
+// dummyHash is compared against when the email is unknown, so a failed login
+// takes as long whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("movie-rent"), bcrypt.DefaultCost)
+
+type authService struct {
+	repository     repository.AuthRepository
+	userRepository userRepository.UserRepository
+	signer         token.Signer
+	config         config.AuthConfig
+}
+
+func NewAuthService(repository repository.AuthRepository, userRepository userRepository.UserRepository,
+	signer token.Signer, config config.AuthConfig) AuthService {
+	return authService{repository: repository, userRepository: userRepository, signer: signer, config: config}
+}
+
+func (m authService) Login(request model.LoginRequest) (model.TokenPair, error) {
+	user, err := m.userRepository.GetUserByEmail(userModel.NormalizeEmail(request.Email))
+	if errors.Is(err, userModel.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(request.Password))
+		return model.TokenPair{}, model.ErrInvalidCredentials
+	}
+	if err != nil {
+		fmt.Println("failed to find user for login:", err.Error())
+		return model.TokenPair{}, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)) != nil {
+		return model.TokenPair{}, model.ErrInvalidCredentials
+	}
+	return m.issuePair(user, time.Now())
+}
+
+// Refresh trades a refresh token for a new pair. The old refresh token is
+// revoked on the way, so each one can be used only once. The role is read
+// again, so role changes apply from the next refresh.
+func (m authService) Refresh(refreshToken string) (model.TokenPair, error) {
+	now := time.Now()
+	claims, err := m.signer.Parse(refreshToken, now)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	if claims.Type != model.TokenRefresh {
+		return model.TokenPair{}, model.ErrInvalidToken
+	}
+	userId, err := claims.UserId()
+	if err != nil {
+		return model.TokenPair{}, model.ErrInvalidToken
+	}
+	user, err := m.userRepository.GetUser(userId)
+	if errors.Is(err, userModel.ErrUserNotFound) {
+		return model.TokenPair{}, model.ErrInvalidToken
+	}
+	if err != nil {
+		fmt.Println("failed to find user for refresh:", err.Error())
+		return model.TokenPair{}, err
+	}
+
+	revoked, err := m.repository.Revoke(claims.Id, userId, claims.Expiry(), now)
+	if err != nil {
+		fmt.Println("failed to rotate refresh token:", err.Error())
+		return model.TokenPair{}, err
+	}
+	if !revoked {
+		return model.TokenPair{}, model.ErrTokenRevoked
+	}
+	return m.issuePair(user, now)
+}
+
+// Logout revokes the access token of the request and, when given, the
+// refresh token issued with it.
+func (m authService) Logout(access model.Claims, refreshToken string) error {
+	now := time.Now()
+	userId, err := access.UserId()
+	if err != nil || access.Type != model.TokenAccess {
+		return model.ErrInvalidToken
+	}
+	if _, err = m.repository.Revoke(access.Id, userId, access.Expiry(), now); err != nil {
+		fmt.Println("failed to revoke access token:", err.Error())
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	refresh, err := m.signer.Parse(refreshToken, now)
+	if errors.Is(err, model.ErrTokenExpired) {
+		return nil
+	}
+	if err != nil || refresh.Type != model.TokenRefresh || refresh.Subject != access.Subject {
+		return model.ErrInvalidToken
+	}
+	if _, err = m.repository.Revoke(refresh.Id, userId, refresh.Expiry(), now); err != nil {
+		fmt.Println("failed to revoke refresh token:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (m authService) Authenticate(accessToken string) (model.Claims, error) {
+	claims, err := m.signer.Parse(accessToken, time.Now())
+	if err != nil {
+		return model.Claims{}, err
+	}
+	if claims.Type != model.TokenAccess {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+	userId, err := claims.UserId()
+	if err != nil {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+
+	revoked, err := m.repository.IsRevoked(claims.Id)
+	if err != nil {
+		fmt.Println("failed to check token revocation:", err.Error())
+		return model.Claims{}, err
+	}
+	if revoked {
+		return model.Claims{}, model.ErrTokenRevoked
+	}
+
+	// The role in the token is only a hint; it is read again so a demotion
+	// applies to tokens that are already out.
+	user, err := m.userRepository.GetUser(userId)
+	if errors.Is(err, userModel.ErrUserNotFound) {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+	if err != nil {
+		fmt.Println("failed to find user for token:", err.Error())
+		return model.Claims{}, err
+	}
+	claims.Role = user.Role
+	return claims, nil
+}
+
+func (m authService) PurgeRevoked() error {
+	purged, err := m.repository.PurgeExpired(time.Now())
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		fmt.Println("Purged expired token revocations:", purged)
+	}
+	return nil
+}
+
+func (m authService) issuePair(user userModel.User, now time.Time) (model.TokenPair, error) {
+	access, _, err := m.signer.Issue(user.Id, user.Role, model.TokenAccess, m.config.AccessTTL, now)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	refresh, _, err := m.signer.Issue(user.Id, "", model.TokenRefresh, m.config.RefreshTTL, now)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	return model.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(m.config.AccessTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"movie-rent/config"
+	"movie-rent/pkg/auth/mocks"
+	"movie-rent/pkg/auth/model"
+	"movie-rent/pkg/auth/token"
+	userMocks "movie-rent/pkg/user/mocks"
+	userModel "movie-rent/pkg/user/model"
+	"testing"
+	"time"
+)
+
+type AuthServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockAuthRepository
+	mockUserRepo   *userMocks.MockUserRepository
+	signer         token.Signer
+	user           userModel.User
+	authService    AuthService
+}
+
+func TestAuthServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthServiceTestSuite))
+}
+
+func (suite *AuthServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockAuthRepository(suite.mockController)
+	suite.mockUserRepo = userMocks.NewMockUserRepository(suite.mockController)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	suite.Require().Nil(err)
+	suite.signer = token.NewSigner(key, "movie-rent")
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	suite.Require().Nil(err)
+	suite.user = userModel.User{Id: 1001, Email: "ada@example.com", Role: userModel.RoleStaff, PasswordHash: string(hash)}
+
+	suite.authService = NewAuthService(suite.mockRepository, suite.mockUserRepo, suite.signer,
+		config.AuthConfig{Issuer: "movie-rent", AccessTTL: 15 * time.Minute, RefreshTTL: time.Hour})
+}
+
+func (suite *AuthServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *AuthServiceTestSuite) Test_Login_ShouldIssueTokenPair() {
+	suite.mockUserRepo.EXPECT().GetUserByEmail("ada@example.com").Return(suite.user, nil).Times(1)
+
+	pair, err := suite.authService.Login(model.LoginRequest{Email: "Ada@Example.com", Password: "correct horse"})
+
+	suite.Nil(err)
+	suite.Equal("Bearer", pair.TokenType)
+	suite.Equal(900, pair.ExpiresIn)
+	access, err := suite.signer.Parse(pair.AccessToken, time.Now())
+	suite.Nil(err)
+	suite.Equal(model.TokenAccess, access.Type)
+	suite.Equal("1001", access.Subject)
+	suite.Equal(userModel.RoleStaff, access.Role)
+	refresh, err := suite.signer.Parse(pair.RefreshToken, time.Now())
+	suite.Nil(err)
+	suite.Equal(model.TokenRefresh, refresh.Type)
+}
+
+func (suite *AuthServiceTestSuite) Test_Login_ShouldRejectWrongPassword() {
+	suite.mockUserRepo.EXPECT().GetUserByEmail("ada@example.com").Return(suite.user, nil).Times(1)
+
+	_, err := suite.authService.Login(model.LoginRequest{Email: "ada@example.com", Password: "wrong horse"})
+
+	suite.ErrorIs(err, model.ErrInvalidCredentials)
+}
+
+func (suite *AuthServiceTestSuite) Test_Login_ShouldRejectUnknownEmail() {
+	suite.mockUserRepo.EXPECT().GetUserByEmail("bob@example.com").Return(userModel.User{}, userModel.ErrUserNotFound).Times(1)
+
+	_, err := suite.authService.Login(model.LoginRequest{Email: "bob@example.com", Password: "correct horse"})
+
+	suite.ErrorIs(err, model.ErrInvalidCredentials)
+}
+
+func (suite *AuthServiceTestSuite) Test_Refresh_ShouldRotateRefreshToken() {
+	refresh, claims, _ := suite.signer.Issue(1001, "customer", model.TokenRefresh, time.Hour, time.Now())
+	suite.mockUserRepo.EXPECT().GetUser(1001).Return(suite.user, nil).Times(1)
+	suite.mockRepository.EXPECT().Revoke(claims.Id, 1001, claims.Expiry(), gomock.Any()).Return(true, nil).Times(1)
+
+	pair, err := suite.authService.Refresh(refresh)
+
+	suite.Nil(err)
+	suite.NotEqual(refresh, pair.RefreshToken)
+	access, err := suite.signer.Parse(pair.AccessToken, time.Now())
+	suite.Nil(err)
+	suite.Equal(userModel.RoleStaff, access.Role)
+}
+
+func (suite *AuthServiceTestSuite) Test_Refresh_ShouldRejectReusedRefreshToken() {
+	refresh, claims, _ := suite.signer.Issue(1001, "customer", model.TokenRefresh, time.Hour, time.Now())
+	suite.mockUserRepo.EXPECT().GetUser(1001).Return(suite.user, nil).Times(1)
+	suite.mockRepository.EXPECT().Revoke(claims.Id, 1001, claims.Expiry(), gomock.Any()).Return(false, nil).Times(1)
+
+	_, err := suite.authService.Refresh(refresh)
+
+	suite.ErrorIs(err, model.ErrTokenRevoked)
+}
+
+func (suite *AuthServiceTestSuite) Test_Refresh_ShouldRejectAccessToken() {
+	access, _, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Hour, time.Now())
+
+	_, err := suite.authService.Refresh(access)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *AuthServiceTestSuite) Test_Logout_ShouldRevokeAccessAndRefreshTokens() {
+	_, access, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Hour, time.Now())
+	refresh, refreshClaims, _ := suite.signer.Issue(1001, "customer", model.TokenRefresh, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().Revoke(access.Id, 1001, access.Expiry(), gomock.Any()).Return(true, nil).Times(1)
+	suite.mockRepository.EXPECT().Revoke(refreshClaims.Id, 1001, refreshClaims.Expiry(), gomock.Any()).Return(true, nil).Times(1)
+
+	err := suite.authService.Logout(access, refresh)
+
+	suite.Nil(err)
+}
+
+func (suite *AuthServiceTestSuite) Test_Logout_ShouldRejectRefreshTokenOfAnotherUser() {
+	_, access, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Hour, time.Now())
+	refresh, _, _ := suite.signer.Issue(2002, "customer", model.TokenRefresh, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().Revoke(access.Id, 1001, access.Expiry(), gomock.Any()).Return(true, nil).Times(1)
+
+	err := suite.authService.Logout(access, refresh)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *AuthServiceTestSuite) Test_Authenticate_ShouldRejectRevokedToken() {
+	access, claims, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().IsRevoked(claims.Id).Return(true, nil).Times(1)
+
+	_, err := suite.authService.Authenticate(access)
+
+	suite.ErrorIs(err, model.ErrTokenRevoked)
+}
+
+func (suite *AuthServiceTestSuite) Test_Authenticate_ShouldRejectRefreshToken() {
+	refresh, _, _ := suite.signer.Issue(1001, "customer", model.TokenRefresh, time.Hour, time.Now())
+
+	_, err := suite.authService.Authenticate(refresh)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *AuthServiceTestSuite) Test_Authenticate_ShouldReturnClaims() {
+	access, claims, _ := suite.signer.Issue(1001, "staff", model.TokenAccess, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().IsRevoked(claims.Id).Return(false, nil).Times(1)
+	suite.mockUserRepo.EXPECT().GetUser(1001).Return(suite.user, nil).Times(1)
+
+	authenticated, err := suite.authService.Authenticate(access)
+
+	suite.Nil(err)
+	suite.Equal(claims, authenticated)
+}
+
+func (suite *AuthServiceTestSuite) Test_Authenticate_ShouldApplyCurrentRole() {
+	access, claims, _ := suite.signer.Issue(1001, "admin", model.TokenAccess, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().IsRevoked(claims.Id).Return(false, nil).Times(1)
+	suite.mockUserRepo.EXPECT().GetUser(1001).Return(userModel.User{Id: 1001, Role: userModel.RoleCustomer}, nil).Times(1)
+
+	authenticated, err := suite.authService.Authenticate(access)
+
+	suite.Nil(err)
+	suite.Equal(userModel.RoleCustomer, authenticated.Role)
+}
+
+func (suite *AuthServiceTestSuite) Test_Authenticate_ShouldRejectTokenOfDeletedUser() {
+	access, claims, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Hour, time.Now())
+	suite.mockRepository.EXPECT().IsRevoked(claims.Id).Return(false, nil).Times(1)
+	suite.mockUserRepo.EXPECT().GetUser(1001).Return(userModel.User{}, userModel.ErrUserNotFound).Times(1)
+
+	_, err := suite.authService.Authenticate(access)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
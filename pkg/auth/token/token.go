@@ -0,0 +1,103 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"movie-rent/pkg/auth/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signer issues and verifies compact JWTs signed with Ed25519 (alg EdDSA).
+type Signer interface {
+	Issue(userId int, role string, tokenType string, ttl time.Duration, now time.Time) (string, model.Claims, error)
+	Parse(token string, now time.Time) (model.Claims, error)
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type signer struct {
+	key    ed25519.PrivateKey
+	issuer string
+}
+
+func NewSigner(key ed25519.PrivateKey, issuer string) Signer {
+	return signer{key: key, issuer: issuer}
+}
+
+var encoding = base64.RawURLEncoding
+
+func (s signer) Issue(userId int, role string, tokenType string, ttl time.Duration, now time.Time) (string, model.Claims, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", model.Claims{}, fmt.Errorf("failed to generate token id: %w", err)
+	}
+	claims := model.Claims{
+		Subject:   strconv.Itoa(userId),
+		Type:      tokenType,
+		Role:      role,
+		Id:        hex.EncodeToString(jti),
+		Issuer:    s.issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	h, err := json.Marshal(header{Alg: "EdDSA", Typ: "JWT"})
+	if err != nil {
+		return "", model.Claims{}, err
+	}
+	p, err := json.Marshal(claims)
+	if err != nil {
+		return "", model.Claims{}, err
+	}
+	signingInput := encoding.EncodeToString(h) + "." + encoding.EncodeToString(p)
+	signature := ed25519.Sign(s.key, []byte(signingInput))
+	return signingInput + "." + encoding.EncodeToString(signature), claims, nil
+}
+
+// Parse verifies the signature, issuer and expiry. Only EdDSA is accepted,
+// whatever the header asks for.
+func (s signer) Parse(token string, now time.Time) (model.Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+
+	var h header
+	if err := decode(parts[0], &h); err != nil || h.Alg != "EdDSA" {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+	publicKey := s.key.Public().(ed25519.PublicKey)
+	if !ed25519.Verify(publicKey, []byte(parts[0]+"."+parts[1]), signature) {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+
+	var claims model.Claims
+	if err = decode(parts[1], &claims); err != nil || claims.Issuer != s.issuer {
+		return model.Claims{}, model.ErrInvalidToken
+	}
+	if !now.Before(claims.Expiry()) {
+		return model.Claims{}, model.ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func decode(segment string, v any) error {
+	raw, err := encoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
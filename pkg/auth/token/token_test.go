@@ -0,0 +1,97 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SignerTestSuite struct {
+	suite.Suite
+	key    ed25519.PrivateKey
+	signer Signer
+	now    time.Time
+}
+
+func TestSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(SignerTestSuite))
+}
+
+func (suite *SignerTestSuite) SetupTest() {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	suite.Require().Nil(err)
+	suite.key = key
+	suite.signer = NewSigner(key, "movie-rent")
+	suite.now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldReturnIssuedClaims() {
+	signed, issued, err := suite.signer.Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+	suite.Nil(err)
+
+	claims, err := suite.signer.Parse(signed, suite.now.Add(time.Minute))
+
+	suite.Nil(err)
+	suite.Equal(issued, claims)
+	suite.Equal("1001", claims.Subject)
+	suite.Equal(model.TokenAccess, claims.Type)
+	suite.Len(claims.Id, 32)
+}
+
+func (suite *SignerTestSuite) Test_Issue_ShouldUseUniqueTokenIds() {
+	_, first, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Minute, suite.now)
+	_, second, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, time.Minute, suite.now)
+
+	suite.NotEqual(first.Id, second.Id)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldRejectExpiredToken() {
+	signed, _, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+
+	_, err := suite.signer.Parse(signed, suite.now.Add(15*time.Minute))
+
+	suite.ErrorIs(err, model.ErrTokenExpired)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldRejectTamperedPayload() {
+	signed, _, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+	parts := strings.Split(signed, ".")
+	forged, _, _ := suite.signer.Issue(2002, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+	parts[1] = strings.Split(forged, ".")[1]
+
+	_, err := suite.signer.Parse(strings.Join(parts, "."), suite.now)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldRejectTokenFromOtherKey() {
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	signed, _, _ := NewSigner(otherKey, "movie-rent").Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+
+	_, err := suite.signer.Parse(signed, suite.now)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldRejectUnsignedToken() {
+	signed, _, _ := suite.signer.Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+	parts := strings.Split(signed, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	_, err := suite.signer.Parse(none+"."+parts[1]+".", suite.now)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
+
+func (suite *SignerTestSuite) Test_Parse_ShouldRejectOtherIssuer() {
+	signed, _, _ := NewSigner(suite.key, "someone-else").Issue(1001, "customer", model.TokenAccess, 15*time.Minute, suite.now)
+
+	_, err := suite.signer.Parse(signed, suite.now)
+
+	suite.ErrorIs(err, model.ErrInvalidToken)
+}
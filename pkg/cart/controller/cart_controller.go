@@ -1,10 +1,21 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"io"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
 	"movie-rent/pkg/cart/model"
 	"movie-rent/pkg/cart/service"
+	fineModel "movie-rent/pkg/fine/model"
+	inventoryModel "movie-rent/pkg/inventory/model"
+	movieModel "movie-rent/pkg/movie/model"
+	paymentModel "movie-rent/pkg/payments/model"
+	promotionModel "movie-rent/pkg/promotion/model"
+	rentalModel "movie-rent/pkg/rental/model"
+	userModel "movie-rent/pkg/user/model"
 	"net/http"
 	"strconv"
 )
@@ -18,6 +29,11 @@ func NewCartController(service service.CartService) CartController {
 }
 
 func (m *CartController) AddToCart(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
 	var cart model.CartRequest
 	bindErr := ctx.ShouldBindJSON(&cart)
 	if bindErr != nil {
@@ -25,7 +41,20 @@ func (m *CartController) AddToCart(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, bindErr.Error())
 		return
 	}
+	cart.UserId = userId
 	id, err := m.service.AddToCart(cart)
+	if errors.Is(err, model.ErrInvalidRentalDays) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, movieModel.ErrMovieNotFound) || errors.Is(err, userModel.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateCartItem) || errors.Is(err, inventoryModel.ErrOutOfStock) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -35,12 +64,9 @@ func (m *CartController) AddToCart(ctx *gin.Context) {
 }
 
 func (m *CartController) GetCartItems(ctx *gin.Context) {
-	var userId int
-	var err error
-	id := ctx.Param("userId")
-	userId, err = strconv.Atoi(id)
-	if id == "" || err != nil {
-		ctx.JSON(http.StatusBadRequest, "userId is empty")
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
 		return
 	}
 	res, err := m.service.GetCartItems(userId)
@@ -51,3 +77,179 @@ func (m *CartController) GetCartItems(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (m *CartController) RemoveFromCart(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(ctx.Param("itemId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "itemId is invalid")
+		return
+	}
+
+	err = m.service.RemoveFromCart(userId, itemId)
+	if errors.Is(err, model.ErrCartItemNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (m *CartController) ClearCart(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+
+	if err := m.service.ClearCart(userId); err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (m *CartController) UpdateRentalDays(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(ctx.Param("itemId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "itemId is invalid")
+		return
+	}
+	var request model.RentalDaysRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	item, err := m.service.UpdateRentalDays(userId, itemId, request.RentalDays)
+	if errors.Is(err, model.ErrInvalidRentalDays) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrCartItemNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+func (m *CartController) Checkout(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	var request model.CheckoutRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+	idempotencyKey := ctx.GetHeader("Idempotency-Key")
+	if idempotencyKey == "" {
+		ctx.JSON(http.StatusBadRequest, paymentModel.ErrMissingIdempotencyKey.Error())
+		return
+	}
+
+	order, err := m.service.Checkout(userId, request.PaymentToken, idempotencyKey)
+	if errors.Is(err, model.ErrEmptyCart) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, rentalModel.ErrCartChanged) || errors.Is(err, rentalModel.ErrMovieWithdrawn) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, promotionModel.ErrPromotionInactive) || errors.Is(err, promotionModel.ErrPromotionUsedUp) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, paymentModel.ErrPaymentVoided) || errors.Is(err, paymentModel.ErrIdempotencyKeyReused) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, fineModel.ErrBalanceLimitExceeded) || errors.Is(err, paymentModel.ErrPaymentDeclined) {
+		ctx.JSON(http.StatusPaymentRequired, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, order)
+}
+
+func (m *CartController) ApplyPromotion(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	var request promotionModel.ApplyPromotionRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	summary, err := m.service.ApplyPromotion(userId, request.Code)
+	if errors.Is(err, promotionModel.ErrPromotionNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, promotionModel.ErrPromotionInactive) || errors.Is(err, promotionModel.ErrPromotionUsedUp) ||
+		errors.Is(err, promotionModel.ErrPromotionNotApplicable) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (m *CartController) RemovePromotion(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+
+	err := m.service.RemovePromotion(userId)
+	if errors.Is(err, promotionModel.ErrNoPromotionApplied) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
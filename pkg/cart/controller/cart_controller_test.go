@@ -5,11 +5,19 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/middleware"
 	"movie-rent/pkg/cart/mocks"
 	"movie-rent/pkg/cart/model"
+	fineModel "movie-rent/pkg/fine/model"
+	inventoryModel "movie-rent/pkg/inventory/model"
+	movieModel "movie-rent/pkg/movie/model"
+	paymentModel "movie-rent/pkg/payments/model"
+	pricingModel "movie-rent/pkg/pricing/model"
+	promotionModel "movie-rent/pkg/promotion/model"
+	rentalModel "movie-rent/pkg/rental/model"
+	userModel "movie-rent/pkg/user/model"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
 	"strings"
 	"testing"
 )
@@ -33,6 +41,7 @@ func (suite *MovieControllerTestSuite) SetupTest() {
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockMovieService = mocks.NewMockCartService(suite.mockController)
 	suite.testController = NewCartController(suite.mockMovieService)
+	suite.context.Set(middleware.UserIdKey, 1001)
 }
 
 func (suite *MovieControllerTestSuite) TearDownTest() {
@@ -40,7 +49,7 @@ func (suite *MovieControllerTestSuite) TearDownTest() {
 }
 
 func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnBadRequestWhenRequiredFieldIsEmpty() {
-	invalidRequestBody := `{"userId":1001,"movieId":4563,"movieName":"Hero"}`
+	invalidRequestBody := `{"rentalDays":3}`
 	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(invalidRequestBody))
 
 	suite.testController.AddToCart(suite.context)
@@ -50,12 +59,10 @@ func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnBadRequestWhen
 
 func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
 	request := model.CartRequest{
-		UserId:      1001,
-		MovieId:     4563,
-		MovieName:   "Hero",
-		ReleaseYear: 1990,
+		UserId:  1001,
+		MovieId: 4563,
 	}
-	requestBody := `{"userId":1001,"movieId":4563,"movieName":"Hero","releaseYear":1990}`
+	requestBody := `{"movieId":4563}`
 	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(requestBody))
 	suite.mockMovieService.EXPECT().AddToCart(request).Return(0, errors.New("error")).Times(1)
 
@@ -64,14 +71,52 @@ func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnInternalServer
 	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
 }
 
+func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnNotFoundWhenMovieNotInCatalog() {
+	request := model.CartRequest{UserId: 1001, MovieId: 4563}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(`{"movieId":4563}`))
+	suite.mockMovieService.EXPECT().AddToCart(request).Return(0, movieModel.ErrMovieNotFound).Times(1)
+
+	suite.testController.AddToCart(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnNotFoundWhenUserUnknown() {
+	request := model.CartRequest{UserId: 1001, MovieId: 4563}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(`{"movieId":4563}`))
+	suite.mockMovieService.EXPECT().AddToCart(request).Return(0, userModel.ErrUserNotFound).Times(1)
+
+	suite.testController.AddToCart(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnConflictWhenMovieAlreadyInCart() {
+	request := model.CartRequest{UserId: 1001, MovieId: 4563}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(`{"movieId":4563}`))
+	suite.mockMovieService.EXPECT().AddToCart(request).Return(0, model.ErrDuplicateCartItem).Times(1)
+
+	suite.testController.AddToCart(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldReturnConflictWhenMovieOutOfStock() {
+	request := model.CartRequest{UserId: 1001, MovieId: 4563}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(`{"movieId":4563}`))
+	suite.mockMovieService.EXPECT().AddToCart(request).Return(0, inventoryModel.ErrOutOfStock).Times(1)
+
+	suite.testController.AddToCart(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
 func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldSuccessfullyAddToCart() {
 	request := model.CartRequest{
-		UserId:      1001,
-		MovieId:     4563,
-		MovieName:   "Hero",
-		ReleaseYear: 1990,
+		UserId:  1001,
+		MovieId: 4563,
 	}
-	requestBody := `{"userId":1001,"movieId":4563,"movieName":"Hero","releaseYear":1990}`
+	requestBody := `{"movieId":4563}`
 	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(requestBody))
 	suite.mockMovieService.EXPECT().AddToCart(request).Return(1, nil).Times(1)
 
@@ -81,38 +126,19 @@ func (suite *MovieControllerTestSuite) Test_AddToCart_ShouldSuccessfullyAddToCar
 	suite.Equal(1, suite.recorder.Body.Len())
 }
 
-func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldReturnBadRequestWhenUserIdNotPresentInPathParams() {
-	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items/1001", strings.NewReader(""))
+func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldReturnUnauthorizedWithoutAuthenticatedUser() {
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items", strings.NewReader(""))
 
 	suite.testController.GetCartItems(suite.context)
 
-	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
-}
-
-func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldReturnBadRequestWhenUserIdNotANumber() {
-	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items/1001", strings.NewReader(""))
-	suite.context.Params = gin.Params{
-		gin.Param{
-			Key:   "userId",
-			Value: "user",
-		},
-	}
-
-	suite.testController.GetCartItems(suite.context)
-
-	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
 }
 
 func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
 	userId := 1001
-	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items/1001", strings.NewReader(""))
-	suite.context.Params = gin.Params{
-		gin.Param{
-			Key:   "userId",
-			Value: strconv.Itoa(userId),
-		},
-	}
-	suite.mockMovieService.EXPECT().GetCartItems(userId).Return(nil, errors.New("error")).Times(1)
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items", strings.NewReader(""))
+	suite.mockMovieService.EXPECT().GetCartItems(userId).Return(model.CartSummary{}, errors.New("error")).Times(1)
 
 	suite.testController.GetCartItems(suite.context)
 
@@ -121,23 +147,207 @@ func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldReturnInternalSer
 
 func (suite *MovieControllerTestSuite) Test_GetCartItems_ShouldSuccessfullyAddToCart() {
 	userId := 1001
-	expectedResponse := []model.CartResponse{{
-		Id:          1,
-		UserId:      1001,
-		MovieId:     4563,
-		MovieName:   "Hero",
-		ReleaseYear: 1990,
-	}}
-	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items/1001", strings.NewReader(""))
-	suite.context.Params = gin.Params{
-		gin.Param{
-			Key:   "userId",
-			Value: strconv.Itoa(userId),
-		},
+	expectedResponse := model.CartSummary{
+		UserId: 1001,
+		Items: []model.CartLine{{
+			CartResponse: model.CartResponse{
+				Id:          1,
+				UserId:      1001,
+				MovieId:     4563,
+				MovieName:   "Hero",
+				ReleaseYear: 1990,
+				RentalDays:  1,
+			},
+			ListPriceCents: 299,
+			PriceCents:     299,
+			TotalCents:     299,
+			Adjustments:    []pricingModel.Adjustment{},
+		}},
+		SubtotalCents: 299,
+		TaxCents:      24,
+		TotalCents:    323,
 	}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/cart/items", strings.NewReader(""))
 	suite.mockMovieService.EXPECT().GetCartItems(userId).Return(expectedResponse, nil).Times(1)
 
 	suite.testController.GetCartItems(suite.context)
 
 	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"userId":1001,"items":[{"id":1,"userId":1001,"movieId":4563,"movieName":"Hero","releaseYear":1990,"rentalDays":1,`+
+		`"listPriceCents":299,"priceCents":299,"discountCents":0,"totalCents":299,"adjustments":[]}],`+
+		`"subtotalCents":299,"discountCents":0,"taxCents":24,"totalCents":323}`, suite.recorder.Body.String())
+}
+
+func (suite *MovieControllerTestSuite) Test_RemoveFromCart_ShouldReturnBadRequestWhenItemIdNotANumber() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/items/item", nil)
+	suite.context.Params = gin.Params{{Key: "itemId", Value: "item"}}
+
+	suite.testController.RemoveFromCart(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_RemoveFromCart_ShouldReturnNotFoundWhenItemMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/items/1", nil)
+	suite.context.Params = gin.Params{{Key: "itemId", Value: "1"}}
+	suite.mockMovieService.EXPECT().RemoveFromCart(1001, 1).Return(model.ErrCartItemNotFound).Times(1)
+
+	suite.testController.RemoveFromCart(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_RemoveFromCart_ShouldRemoveItem() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/items/1", nil)
+	suite.context.Params = gin.Params{{Key: "itemId", Value: "1"}}
+	suite.mockMovieService.EXPECT().RemoveFromCart(1001, 1).Return(nil).Times(1)
+
+	suite.testController.RemoveFromCart(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
+
+func (suite *MovieControllerTestSuite) Test_ClearCart_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/items", nil)
+	suite.mockMovieService.EXPECT().ClearCart(1001).Return(errors.New("error")).Times(1)
+
+	suite.testController.ClearCart(suite.context)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_ClearCart_ShouldClearCart() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/items", nil)
+	suite.mockMovieService.EXPECT().ClearCart(1001).Return(nil).Times(1)
+
+	suite.testController.ClearCart(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
+
+func (suite *MovieControllerTestSuite) Test_UpdateRentalDays_ShouldReturnBadRequestWhenDurationUnsupported() {
+	suite.context.Request = httptest.NewRequest(http.MethodPatch, "/cart/items/1", strings.NewReader(`{"rentalDays":2}`))
+	suite.context.Params = gin.Params{{Key: "itemId", Value: "1"}}
+	suite.mockMovieService.EXPECT().UpdateRentalDays(1001, 1, 2).Return(model.CartResponse{}, model.ErrInvalidRentalDays).Times(1)
+
+	suite.testController.UpdateRentalDays(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_UpdateRentalDays_ShouldReturnUpdatedItem() {
+	item := model.CartResponse{Id: 1, UserId: 1001, MovieId: 4563, MovieName: "Hero", ReleaseYear: 1990, RentalDays: 7}
+	suite.context.Request = httptest.NewRequest(http.MethodPatch, "/cart/items/1", strings.NewReader(`{"rentalDays":7}`))
+	suite.context.Params = gin.Params{{Key: "itemId", Value: "1"}}
+	suite.mockMovieService.EXPECT().UpdateRentalDays(1001, 1, 7).Return(item, nil).Times(1)
+
+	suite.testController.UpdateRentalDays(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnUnauthorizedWithoutAuthenticatedUser() {
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnBadRequestWhenIdempotencyKeyIsMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{}`))
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnBadRequestWhenCartIsEmpty() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(rentalModel.RentalOrder{}, model.ErrEmptyCart).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnConflictWhenCartChanged() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(rentalModel.RentalOrder{}, rentalModel.ErrCartChanged).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnPaymentRequiredWhenBalanceOverLimit() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(rentalModel.RentalOrder{}, fineModel.ErrBalanceLimitExceeded).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusPaymentRequired, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnPaymentRequiredWhenPaymentDeclined() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(rentalModel.RentalOrder{}, paymentModel.ErrPaymentDeclined).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusPaymentRequired, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldReturnConflictWhenIdempotencyKeyReused() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(rentalModel.RentalOrder{}, paymentModel.ErrIdempotencyKeyReused).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_Checkout_ShouldCreateRentalOrder() {
+	order := rentalModel.RentalOrder{Id: 10, UserId: 1001, Rentals: []rentalModel.Rental{{Id: 1, MovieId: 4563}}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/checkout", strings.NewReader(`{"paymentToken":"tok_visa"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "checkout-1")
+	suite.mockMovieService.EXPECT().Checkout(1001, "tok_visa", "checkout-1").Return(order, nil).Times(1)
+
+	suite.testController.Checkout(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_ApplyPromotion_ShouldReturnNotFoundWhenCodeUnknown() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/promo", strings.NewReader(`{"code":"NOPE"}`))
+	suite.mockMovieService.EXPECT().ApplyPromotion(1001, "NOPE").Return(model.CartSummary{}, promotionModel.ErrPromotionNotFound).Times(1)
+
+	suite.testController.ApplyPromotion(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_ApplyPromotion_ShouldReturnConflictWhenNotApplicable() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/cart/promo", strings.NewReader(`{"code":"SPOOKY"}`))
+	suite.mockMovieService.EXPECT().ApplyPromotion(1001, "SPOOKY").Return(model.CartSummary{}, promotionModel.ErrPromotionNotApplicable).Times(1)
+
+	suite.testController.ApplyPromotion(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_RemovePromotion_ShouldReturnNoContent() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/cart/promo", nil)
+	suite.mockMovieService.EXPECT().RemovePromotion(1001).Return(nil).Times(1)
+
+	suite.testController.RemovePromotion(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
 }
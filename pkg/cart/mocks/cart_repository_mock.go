@@ -7,6 +7,7 @@ package mocks
 import (
 	model "movie-rent/pkg/cart/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,7 +36,7 @@ func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
 }
 
 // AddToCart mocks base method.
-func (m *MockCartRepository) AddToCart(cart model.CartRequest) (int, error) {
+func (m *MockCartRepository) AddToCart(cart model.CartItem) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddToCart", cart)
 	ret0, _ := ret[0].(int)
@@ -49,6 +50,35 @@ func (mr *MockCartRepositoryMockRecorder) AddToCart(cart interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartRepository)(nil).AddToCart), cart)
 }
 
+// ClearCart mocks base method.
+func (m *MockCartRepository) ClearCart(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockCartRepositoryMockRecorder) ClearCart(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartRepository)(nil).ClearCart), userId)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockCartRepository) ExpireReservations(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockCartRepositoryMockRecorder) ExpireReservations(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockCartRepository)(nil).ExpireReservations), before)
+}
+
 // GetCartItems mocks base method.
 func (m *MockCartRepository) GetCartItems(userId int) ([]model.CartResponse, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +93,47 @@ func (mr *MockCartRepositoryMockRecorder) GetCartItems(userId interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItems", reflect.TypeOf((*MockCartRepository)(nil).GetCartItems), userId)
 }
+
+// IsInCart mocks base method.
+func (m *MockCartRepository) IsInCart(userId, movieId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInCart", userId, movieId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInCart indicates an expected call of IsInCart.
+func (mr *MockCartRepositoryMockRecorder) IsInCart(userId, movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInCart", reflect.TypeOf((*MockCartRepository)(nil).IsInCart), userId, movieId)
+}
+
+// RemoveFromCart mocks base method.
+func (m *MockCartRepository) RemoveFromCart(userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCart", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromCart indicates an expected call of RemoveFromCart.
+func (mr *MockCartRepositoryMockRecorder) RemoveFromCart(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartRepository)(nil).RemoveFromCart), userId, itemId)
+}
+
+// UpdateRentalDays mocks base method.
+func (m *MockCartRepository) UpdateRentalDays(userId, itemId, rentalDays int) (model.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRentalDays", userId, itemId, rentalDays)
+	ret0, _ := ret[0].(model.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRentalDays indicates an expected call of UpdateRentalDays.
+func (mr *MockCartRepositoryMockRecorder) UpdateRentalDays(userId, itemId, rentalDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRentalDays", reflect.TypeOf((*MockCartRepository)(nil).UpdateRentalDays), userId, itemId, rentalDays)
+}
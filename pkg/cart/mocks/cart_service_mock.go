@@ -6,6 +6,7 @@ package mocks
 
 import (
 	model "movie-rent/pkg/cart/model"
+	model0 "movie-rent/pkg/rental/model"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,11 +50,69 @@ func (mr *MockCartServiceMockRecorder) AddToCart(request interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartService)(nil).AddToCart), request)
 }
 
+// ApplyPromotion mocks base method.
+func (m *MockCartService) ApplyPromotion(userId int, code string) (model.CartSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromotion", userId, code)
+	ret0, _ := ret[0].(model.CartSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromotion indicates an expected call of ApplyPromotion.
+func (mr *MockCartServiceMockRecorder) ApplyPromotion(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromotion", reflect.TypeOf((*MockCartService)(nil).ApplyPromotion), userId, code)
+}
+
+// Checkout mocks base method.
+func (m *MockCartService) Checkout(userId int, paymentToken, idempotencyKey string) (model0.RentalOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", userId, paymentToken, idempotencyKey)
+	ret0, _ := ret[0].(model0.RentalOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartServiceMockRecorder) Checkout(userId, paymentToken, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCartService)(nil).Checkout), userId, paymentToken, idempotencyKey)
+}
+
+// ClearCart mocks base method.
+func (m *MockCartService) ClearCart(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockCartServiceMockRecorder) ClearCart(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartService)(nil).ClearCart), userId)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockCartService) ExpireReservations() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockCartServiceMockRecorder) ExpireReservations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockCartService)(nil).ExpireReservations))
+}
+
 // GetCartItems mocks base method.
-func (m *MockCartService) GetCartItems(userId int) ([]model.CartResponse, error) {
+func (m *MockCartService) GetCartItems(userId int) (model.CartSummary, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCartItems", userId)
-	ret0, _ := ret[0].([]model.CartResponse)
+	ret0, _ := ret[0].(model.CartSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -63,3 +122,46 @@ func (mr *MockCartServiceMockRecorder) GetCartItems(userId interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItems", reflect.TypeOf((*MockCartService)(nil).GetCartItems), userId)
 }
+
+// RemoveFromCart mocks base method.
+func (m *MockCartService) RemoveFromCart(userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCart", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromCart indicates an expected call of RemoveFromCart.
+func (mr *MockCartServiceMockRecorder) RemoveFromCart(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartService)(nil).RemoveFromCart), userId, itemId)
+}
+
+// RemovePromotion mocks base method.
+func (m *MockCartService) RemovePromotion(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePromotion", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePromotion indicates an expected call of RemovePromotion.
+func (mr *MockCartServiceMockRecorder) RemovePromotion(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePromotion", reflect.TypeOf((*MockCartService)(nil).RemovePromotion), userId)
+}
+
+// UpdateRentalDays mocks base method.
+func (m *MockCartService) UpdateRentalDays(userId, itemId, rentalDays int) (model.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRentalDays", userId, itemId, rentalDays)
+	ret0, _ := ret[0].(model.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRentalDays indicates an expected call of UpdateRentalDays.
+func (mr *MockCartServiceMockRecorder) UpdateRentalDays(userId, itemId, rentalDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRentalDays", reflect.TypeOf((*MockCartService)(nil).UpdateRentalDays), userId, itemId, rentalDays)
+}
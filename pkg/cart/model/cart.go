@@ -1,15 +1,72 @@
 package model
 
+import pricingModel "movie-rent/pkg/pricing/model"
+
+var RentalDurations = []int{1, 3, 7}
+
+// CartRequest.UserId is filled from the caller's token, never from the body.
+// HoldId is set when an allocated hold is picked up, so the line takes the
+// copy held for the user instead of one from the shelf.
 type CartRequest struct {
-	UserId      int    `json:"userId"  binding:"required"`
-	MovieId     int    `json:"movieId"  binding:"required"`
-	MovieName   string `json:"movieName"  binding:"required"`
-	ReleaseYear int    `json:"releaseYear"  binding:"required"`
+	UserId     int `json:"-"`
+	MovieId    int `json:"movieId"  binding:"required"`
+	RentalDays int `json:"rentalDays"`
+	HoldId     int `json:"-"`
+}
+
+type CartItem struct {
+	UserId      int
+	MovieId     int
+	MovieName   string
+	ReleaseYear int
+	RentalDays  int
+	HoldId      int
 }
+
 type CartResponse struct {
 	Id          int    `json:"id"`
 	UserId      int    `json:"userId"  binding:"required"`
 	MovieId     int    `json:"movieId"  binding:"required"`
 	MovieName   string `json:"movieName"  binding:"required"`
 	ReleaseYear int    `json:"releaseYear"  binding:"required"`
+	RentalDays  int    `json:"rentalDays"`
+
+	Genre          string `json:"-"`
+	BasePriceCents int    `json:"-"`
+}
+
+type CartLine struct {
+	CartResponse
+	ListPriceCents int                       `json:"listPriceCents"`
+	PriceCents     int                       `json:"priceCents"`
+	DiscountCents  int                       `json:"discountCents"`
+	TotalCents     int                       `json:"totalCents"`
+	Adjustments    []pricingModel.Adjustment `json:"adjustments"`
+}
+
+type CartSummary struct {
+	UserId        int        `json:"userId"`
+	Items         []CartLine `json:"items"`
+	PromotionCode string     `json:"promotionCode,omitempty"`
+	SubtotalCents int        `json:"subtotalCents"`
+	DiscountCents int        `json:"discountCents"`
+	TaxCents      int        `json:"taxCents"`
+	TotalCents    int        `json:"totalCents"`
+}
+
+type CheckoutRequest struct {
+	PaymentToken string `json:"paymentToken"`
+}
+
+type RentalDaysRequest struct {
+	RentalDays int `json:"rentalDays"  binding:"required"`
+}
+
+func IsValidRentalDays(days int) bool {
+	for _, d := range RentalDurations {
+		if d == days {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,10 @@
+package model
+
+import "errors"
+
+var (
+	ErrEmptyCart         = errors.New("cart is empty")
+	ErrCartItemNotFound  = errors.New("cart item not found")
+	ErrInvalidRentalDays = errors.New("rental days must be one of 1, 3 or 7")
+	ErrDuplicateCartItem = errors.New("movie is already in the cart")
+)
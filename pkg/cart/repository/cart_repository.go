@@ -1,20 +1,49 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
-	"log"
+	"movie-rent/db/postgres"
 	"movie-rent/pkg/cart/model"
+	holdModel "movie-rent/pkg/hold/model"
+	inventoryModel "movie-rent/pkg/inventory/model"
+	userModel "movie-rent/pkg/user/model"
+	"time"
 )
 
 const (
-	InsertCartDetailsSQL = `INSERT INTO movie_carts(user_id, movie_id, movie_name, release_year) VALUES ($1, $2, $3, $4) RETURNING id`
-	SelectCartListSQL    = `SELECT * FROM movie_carts where user_id = $1`
+	InsertCartDetailsSQL = `INSERT INTO movie_carts(user_id, movie_id, movie_name, release_year, rental_days, copy_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	SelectCartListSQL    = `SELECT c.id, c.user_id, c.movie_id, c.movie_name, c.release_year, c.rental_days, m.genre, m.price_cents FROM movie_carts c JOIN movies m ON m.id = c.movie_id where c.user_id = $1 ORDER BY c.id`
+	UpdateRentalDaysSQL  = `WITH touched AS (UPDATE movie_carts SET reserved_at = now() WHERE user_id = $3 AND id <> $2) ` +
+		`UPDATE movie_carts SET rental_days = $1, reserved_at = now() WHERE id = $2 AND user_id = $3 RETURNING id, user_id, movie_id, movie_name, release_year, rental_days`
+	TouchCartSQL         = `UPDATE movie_carts SET reserved_at = now() WHERE user_id = $1`
+	SelectCartItemExists = `SELECT EXISTS(SELECT 1 FROM movie_carts WHERE user_id = $1 AND movie_id = $2)`
+	ReserveCopySQL       = `UPDATE movie_copies SET status = 'reserved', updated_at = now() WHERE id = (SELECT id FROM movie_copies WHERE movie_id = $1 AND status = 'available' ` +
+		`AND NOT EXISTS (SELECT 1 FROM movie_holds WHERE movie_id = $1 AND status = 'waiting') ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING id`
+	ClaimHeldCopySQL  = `UPDATE movie_holds SET status = 'fulfilled' WHERE id = $1 AND user_id = $2 AND movie_id = $3 AND status = 'allocated' AND expires_at > now() RETURNING copy_id`
+	DeleteCartItemSQL = `WITH removed AS (DELETE FROM movie_carts WHERE id = $1 AND user_id = $2 RETURNING copy_id), ` +
+		`touched AS (UPDATE movie_carts SET reserved_at = now() WHERE user_id = $2 AND id <> $1), ` +
+		`released AS (UPDATE movie_copies SET status = 'available', updated_at = now() WHERE id IN (SELECT copy_id FROM removed) AND status = 'reserved') ` +
+		`SELECT COUNT(*) FROM removed`
+	DeleteCartItemsSQL = `WITH removed AS (DELETE FROM movie_carts WHERE user_id = $1 RETURNING copy_id), ` +
+		`released AS (UPDATE movie_copies SET status = 'available', updated_at = now() WHERE id IN (SELECT copy_id FROM removed) AND status = 'reserved') ` +
+		`SELECT COUNT(*) FROM removed`
+	ExpireReservationsSQL = `WITH removed AS (DELETE FROM movie_carts c WHERE c.reserved_at < $1 AND NOT EXISTS (SELECT 1 FROM movie_holds h ` +
+		`WHERE h.copy_id = c.copy_id AND h.user_id = c.user_id AND h.status = 'fulfilled' AND h.expires_at > now()) RETURNING c.copy_id), ` +
+		`released AS (UPDATE movie_copies SET status = 'available', updated_at = now() WHERE id IN (SELECT copy_id FROM removed) AND status = 'reserved') ` +
+		`SELECT COUNT(*) FROM removed`
 )
 
 type CartRepository interface {
-	AddToCart(cart model.CartRequest) (int, error)
+	AddToCart(cart model.CartItem) (int, error)
+	IsInCart(userId int, movieId int) (bool, error)
 	GetCartItems(userId int) ([]model.CartResponse, error)
+	RemoveFromCart(userId int, itemId int) error
+	ClearCart(userId int) error
+	UpdateRentalDays(userId int, itemId int, rentalDays int) (model.CartResponse, error)
+	ExpireReservations(before time.Time) (int, error)
 }
 
 type cartRepo struct {
@@ -25,34 +54,135 @@ func NewCartRepository(db *sqlx.DB) CartRepository {
 	return &cartRepo{db: db}
 }
 
-func (m cartRepo) AddToCart(cart model.CartRequest) (int, error) {
+// AddToCart reserves a shelf copy for the line in the same transaction that
+// inserts it. Copies are claimed row by row with SKIP LOCKED, so concurrent
+// additions never hand out the same copy and stock cannot go negative. Shelf
+// copies are left alone while people are queued for the movie; a line for a
+// picked up hold takes the held copy and fulfils the hold instead.
+func (m cartRepo) AddToCart(cart model.CartItem) (int, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin cart insert: %w", err)
+	}
+	defer tx.Rollback()
+
+	var copyId int
+	if cart.HoldId != 0 {
+		err = tx.QueryRow(ClaimHeldCopySQL, cart.HoldId, cart.UserId, cart.MovieId).Scan(&copyId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, holdModel.ErrHoldNotAllocated
+		}
+	} else {
+		err = tx.QueryRow(ReserveCopySQL, cart.MovieId).Scan(&copyId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, inventoryModel.ErrOutOfStock
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve copy: %w", err)
+	}
+
 	id := cart.MovieId
-	err := m.db.QueryRow(InsertCartDetailsSQL, cart.UserId, cart.MovieId, cart.MovieName, cart.ReleaseYear).Scan(&id)
+	err = tx.QueryRow(InsertCartDetailsSQL, cart.UserId, cart.MovieId, cart.MovieName, cart.ReleaseYear, cart.RentalDays, copyId).Scan(&id)
 
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrDuplicateCartItem
+	}
+	if postgres.IsViolation(err, postgres.ForeignKeyViolation, "fk_movie_carts_user") {
+		return 0, userModel.ErrUserNotFound
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert cart details: %w", err)
 	}
+	if _, err = tx.Exec(TouchCartSQL, cart.UserId); err != nil {
+		return 0, fmt.Errorf("failed to refresh cart reservation: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit cart details: %w", err)
+	}
 	fmt.Println("Successfully inserted cart details. Id:", id)
 	return id, nil
 }
 
+func (m cartRepo) IsInCart(userId int, movieId int) (bool, error) {
+	var exists bool
+	err := m.db.QueryRow(SelectCartItemExists, userId, movieId).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check cart item: %w", err)
+	}
+	return exists, nil
+}
+
 func (m cartRepo) GetCartItems(userId int) ([]model.CartResponse, error) {
 	rows, err := m.db.Query(SelectCartListSQL, userId)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to fetch cart items: %w", err)
 	}
 	defer rows.Close()
 
 	var cartList []model.CartResponse
 	for rows.Next() {
 		var c model.CartResponse
-		err := rows.Scan(&c.Id, &c.UserId, &c.MovieId, &c.MovieName, &c.ReleaseYear)
+		err := rows.Scan(&c.Id, &c.UserId, &c.MovieId, &c.MovieName, &c.ReleaseYear, &c.RentalDays, &c.Genre, &c.BasePriceCents)
 		if err != nil {
-			log.Println("Error scanning row:", err)
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
 		}
 		cartList = append(cartList, c)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cart items: %w", err)
+	}
 
 	fmt.Println("Successfully fetched added movies", len(cartList))
 	return cartList, nil
 }
+
+func (m cartRepo) RemoveFromCart(userId int, itemId int) error {
+	var removed int
+	err := m.db.QueryRow(DeleteCartItemSQL, itemId, userId).Scan(&removed)
+	if err != nil {
+		return fmt.Errorf("failed to remove cart item: %w", err)
+	}
+	if removed == 0 {
+		return model.ErrCartItemNotFound
+	}
+	fmt.Println("Successfully removed cart item. Id:", itemId)
+	return nil
+}
+
+func (m cartRepo) ClearCart(userId int) error {
+	var removed int
+	err := m.db.QueryRow(DeleteCartItemsSQL, userId).Scan(&removed)
+	if err != nil {
+		return fmt.Errorf("failed to clear cart: %w", err)
+	}
+	fmt.Println("Successfully cleared cart items", removed)
+	return nil
+}
+
+// ExpireReservations drops the cart lines reserved before the cutoff and puts
+// their copies back on the shelf, so an abandoned cart cannot hold stock. Any
+// change to the cart refreshes the reservation of all its lines, and a line
+// taken from a picked up hold keeps its copy until the hold's pickup window closes.
+func (m cartRepo) ExpireReservations(before time.Time) (int, error) {
+	var removed int
+	err := m.db.QueryRow(ExpireReservationsSQL, before).Scan(&removed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire cart reservations: %w", err)
+	}
+	return removed, nil
+}
+
+func (m cartRepo) UpdateRentalDays(userId int, itemId int, rentalDays int) (model.CartResponse, error) {
+	var c model.CartResponse
+	err := m.db.QueryRow(UpdateRentalDaysSQL, rentalDays, itemId, userId).
+		Scan(&c.Id, &c.UserId, &c.MovieId, &c.MovieName, &c.ReleaseYear, &c.RentalDays)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.CartResponse{}, model.ErrCartItemNotFound
+	}
+	if err != nil {
+		return model.CartResponse{}, fmt.Errorf("failed to update rental days: %w", err)
+	}
+	return c, nil
+}
@@ -2,41 +2,83 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/cart/model"
 	"testing"
 )
 
-type MovieRepositoryTestSuite struct {
+type CartRepositoryTestSuite struct {
 	suite.Suite
-	db             *sqlx.DB
 	mockedDB       *sqlx.DB
 	mockDB         sqlmock.Sqlmock
 	testRepository CartRepository
 }
 
-func TestMovieRepositoryTestSuite(t *testing.T) {
-	suite.Run(t, new(MovieRepositoryTestSuite))
+func TestCartRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CartRepositoryTestSuite))
 }
 
-func (suite *MovieRepositoryTestSuite) SetupTest() {
-	testDb, err := sqlx.Open("godror", fmt.Sprintf("host=localhost port=5432 user=postgres password=Sanjit dbname=movie_db sslmode=disable"))
-	if err != nil {
-		panic(fmt.Sprintf("failed to connect database: %v", err))
-	}
+func (suite *CartRepositoryTestSuite) SetupTest() {
 	var sqlDB *sql.DB
+	var err error
 	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
 		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
 	}
-	suite.mockedDB = sqlx.NewDb(sqlDB, "godror")
-	suite.db = testDb
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewCartRepository(suite.mockedDB)
+}
+
+func (suite *CartRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+var cartRowColumns = []string{"id", "user_id", "movie_id", "movie_name", "release_year", "rental_days", "genre", "price_cents"}
+
+func (suite *CartRepositoryTestSuite) Test_GetCartItems_ShouldReturnItems() {
+	suite.mockDB.ExpectQuery(SelectCartListSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows(cartRowColumns).
+		AddRow(1, 1001, 7, "Hero", 2002, 3, "Action", 399))
 
-	suite.testRepository = NewCartRepository(suite.db)
+	items, err := suite.testRepository.GetCartItems(1001)
+
+	suite.Nil(err)
+	suite.Equal([]model.CartResponse{{Id: 1, UserId: 1001, MovieId: 7, MovieName: "Hero", ReleaseYear: 2002,
+		RentalDays: 3, Genre: "Action", BasePriceCents: 399}}, items)
 }
 
-func (suite *MovieRepositoryTestSuite) TearDownTest() {
-	suite.db.Close()
+func (suite *CartRepositoryTestSuite) Test_GetCartItems_ShouldReturnQueryError() {
+	dbErr := errors.New("connection reset")
+	suite.mockDB.ExpectQuery(SelectCartListSQL).WithArgs(1001).WillReturnError(dbErr)
+
+	items, err := suite.testRepository.GetCartItems(1001)
+
+	suite.Nil(items)
+	suite.ErrorIs(err, dbErr)
+}
+
+func (suite *CartRepositoryTestSuite) Test_GetCartItems_ShouldReturnScanError() {
+	suite.mockDB.ExpectQuery(SelectCartListSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows(cartRowColumns).
+		AddRow("one", 1001, 7, "Hero", 2002, 3, "Action", 399))
+
+	items, err := suite.testRepository.GetCartItems(1001)
+
+	suite.Nil(items)
+	suite.ErrorContains(err, "failed to scan cart item")
+}
+
+func (suite *CartRepositoryTestSuite) Test_GetCartItems_ShouldReturnRowError() {
+	dbErr := errors.New("connection reset")
+	suite.mockDB.ExpectQuery(SelectCartListSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows(cartRowColumns).
+		AddRow(1, 1001, 7, "Hero", 2002, 3, "Action", 399).RowError(0, dbErr))
+
+	items, err := suite.testRepository.GetCartItems(1001)
+
+	suite.Nil(items)
+	suite.ErrorIs(err, dbErr)
 }
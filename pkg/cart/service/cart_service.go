@@ -1,28 +1,87 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"movie-rent/constants"
 	"movie-rent/pkg/cart/model"
 	"movie-rent/pkg/cart/repository"
+	fineService "movie-rent/pkg/fine/service"
+	movieRepository "movie-rent/pkg/movie/repository"
+	paymentModel "movie-rent/pkg/payments/model"
+	paymentService "movie-rent/pkg/payments/service"
+	"movie-rent/pkg/pricing/engine"
+	pricingModel "movie-rent/pkg/pricing/model"
+	promotionModel "movie-rent/pkg/promotion/model"
+	promotionService "movie-rent/pkg/promotion/service"
+	rentalModel "movie-rent/pkg/rental/model"
+	rentalRepository "movie-rent/pkg/rental/repository"
+	"time"
 )
 
 // go:generate mockgen -source=pkg/cart/service/cart_service.go -destination=pkg/cart/mocks/cart_service_mock.go -package=mocks
 
 type CartService interface {
 	AddToCart(request model.CartRequest) (int, error)
-	GetCartItems(userId int) ([]model.CartResponse, error)
+	GetCartItems(userId int) (model.CartSummary, error)
+	RemoveFromCart(userId int, itemId int) error
+	ClearCart(userId int) error
+	UpdateRentalDays(userId int, itemId int, rentalDays int) (model.CartResponse, error)
+	Checkout(userId int, paymentToken string, idempotencyKey string) (rentalModel.RentalOrder, error)
+	ApplyPromotion(userId int, code string) (model.CartSummary, error)
+	RemovePromotion(userId int) error
+	ExpireReservations() error
 }
 
 type cartService struct {
-	repository repository.CartRepository
+	repository       repository.CartRepository
+	movieRepository  movieRepository.MovieRepository
+	rentalRepository rentalRepository.RentalRepository
+	fineService      fineService.FineService
+	pricing          engine.Engine
+	promotionService promotionService.PromotionService
+	paymentService   paymentService.PaymentService
 }
 
-func NewCartService(repository repository.CartRepository) CartService {
-	return cartService{repository: repository}
+func NewCartService(repository repository.CartRepository, movieRepository movieRepository.MovieRepository,
+	rentalRepository rentalRepository.RentalRepository, fineService fineService.FineService,
+	pricing engine.Engine, promotionService promotionService.PromotionService,
+	paymentService paymentService.PaymentService) CartService {
+	return cartService{repository: repository, movieRepository: movieRepository, rentalRepository: rentalRepository,
+		fineService: fineService, pricing: pricing, promotionService: promotionService, paymentService: paymentService}
 }
 
 func (m cartService) AddToCart(request model.CartRequest) (int, error) {
-	id, err := m.repository.AddToCart(request)
+	if request.RentalDays == 0 {
+		request.RentalDays = constants.DefaultRentalDays
+	}
+	if !model.IsValidRentalDays(request.RentalDays) {
+		return 0, model.ErrInvalidRentalDays
+	}
+
+	movie, err := m.movieRepository.GetMovieBy(request.MovieId)
+	if err != nil {
+		fmt.Println("failed to find movie for cart:", err.Error())
+		return 0, err
+	}
+
+	exists, err := m.repository.IsInCart(request.UserId, request.MovieId)
+	if err != nil {
+		fmt.Println("failed to check cart:", err.Error())
+		return 0, err
+	}
+	if exists {
+		return 0, model.ErrDuplicateCartItem
+	}
+
+	id, err := m.repository.AddToCart(model.CartItem{
+		UserId:      request.UserId,
+		MovieId:     movie.Id,
+		MovieName:   movie.Title,
+		ReleaseYear: movie.Year,
+		RentalDays:  request.RentalDays,
+		HoldId:      request.HoldId,
+	})
 	if err != nil {
 		fmt.Println("failed to add to cart: %w", err.Error())
 		return 0, err
@@ -30,11 +89,259 @@ func (m cartService) AddToCart(request model.CartRequest) (int, error) {
 	return id, nil
 }
 
-func (m cartService) GetCartItems(userId int) ([]model.CartResponse, error) {
+func (m cartService) GetCartItems(userId int) (model.CartSummary, error) {
 	res, err := m.repository.GetCartItems(userId)
 	if err != nil {
 		fmt.Println("failed to add to cart: %w", err.Error())
-		return []model.CartResponse{}, err
+		return model.CartSummary{}, err
+	}
+	// A lapsed promotion is left out of the quote; checkout refuses it.
+	promotion, err := m.promotionService.GetCartPromotion(userId)
+	if err != nil && !errors.Is(err, promotionModel.ErrPromotionInactive) && !errors.Is(err, promotionModel.ErrPromotionUsedUp) {
+		return model.CartSummary{}, err
+	}
+	return m.priceCart(userId, res, promotion, time.Now()), nil
+}
+
+func (m cartService) RemoveFromCart(userId int, itemId int) error {
+	err := m.repository.RemoveFromCart(userId, itemId)
+	if err != nil {
+		fmt.Println("failed to remove from cart:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (m cartService) ClearCart(userId int) error {
+	err := m.repository.ClearCart(userId)
+	if err != nil {
+		fmt.Println("failed to clear cart:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// ExpireReservations is run periodically to release the copies held by carts
+// nobody checked out within the reservation window.
+func (m cartService) ExpireReservations() error {
+	removed, err := m.repository.ExpireReservations(time.Now().Add(-constants.CartReservationWindow))
+	if err != nil {
+		fmt.Println("failed to expire cart reservations:", err.Error())
+		return err
+	}
+	if removed > 0 {
+		fmt.Println("expired cart reservations", removed)
+	}
+	return nil
+}
+
+func (m cartService) UpdateRentalDays(userId int, itemId int, rentalDays int) (model.CartResponse, error) {
+	if !model.IsValidRentalDays(rentalDays) {
+		return model.CartResponse{}, model.ErrInvalidRentalDays
+	}
+
+	item, err := m.repository.UpdateRentalDays(userId, itemId, rentalDays)
+	if err != nil {
+		fmt.Println("failed to update rental days:", err.Error())
+		return model.CartResponse{}, err
+	}
+	return item, nil
+}
+
+// Checkout authorizes the cart total, places the order and captures the
+// payment. A retry with the same idempotency key returns the order the key
+// already paid for instead of charging again, including a retry that raced
+// the first attempt and lost.
+func (m cartService) Checkout(userId int, paymentToken string, idempotencyKey string) (rentalModel.RentalOrder, error) {
+	payment, err := m.paymentService.GetPaymentByKey(idempotencyKey)
+	if err == nil && payment.OrderId != nil {
+		return m.paidOrder(userId, payment)
+	}
+	if err != nil && !errors.Is(err, paymentModel.ErrPaymentNotFound) {
+		return rentalModel.RentalOrder{}, err
+	}
+
+	if err := m.fineService.CheckStanding(userId); err != nil {
+		return rentalModel.RentalOrder{}, err
+	}
+
+	items, err := m.repository.GetCartItems(userId)
+	if err != nil {
+		fmt.Println("failed to fetch cart for checkout:", err.Error())
+		return rentalModel.RentalOrder{}, err
+	}
+	if len(items) == 0 {
+		return rentalModel.RentalOrder{}, model.ErrEmptyCart
+	}
+
+	promotion, err := m.promotionService.GetCartPromotion(userId)
+	if err != nil {
+		return rentalModel.RentalOrder{}, err
+	}
+
+	rentedAt := time.Now()
+	summary := m.priceCart(userId, items, promotion, rentedAt)
+	order := rentalModel.RentalOrder{
+		UserId:        userId,
+		CreatedAt:     rentedAt,
+		SubtotalCents: summary.SubtotalCents,
+		DiscountCents: summary.DiscountCents,
+		TaxCents:      summary.TaxCents,
+		TotalCents:    summary.TotalCents,
+	}
+	// A promotion that took nothing off this cart does not use up a redemption.
+	if promotion != nil && summary.DiscountCents > 0 {
+		order.PromotionId = &promotion.Id
+		order.PromotionCode = summary.PromotionCode
+	}
+	for _, item := range items {
+		order.Rentals = append(order.Rentals, rentalModel.Rental{
+			CartItemId:  item.Id,
+			UserId:      userId,
+			MovieId:     item.MovieId,
+			MovieName:   item.MovieName,
+			ReleaseYear: item.ReleaseYear,
+			Status:      rentalModel.StatusActive,
+			RentedAt:    rentedAt,
+			DueAt:       rentedAt.AddDate(0, 0, rentalDays(item)),
+		})
+	}
+
+	if order.TotalCents > 0 {
+		payment, err := m.paymentService.Authorize(userId, order.TotalCents, paymentToken, idempotencyKey)
+		if err != nil {
+			return rentalModel.RentalOrder{}, err
+		}
+		order.PaymentId = &payment.Id
+	}
+
+	placed, err := m.rentalRepository.CreateOrder(order)
+	if errors.Is(err, paymentModel.ErrPaymentLinked) {
+		payment, err := m.paymentService.GetPaymentByKey(idempotencyKey)
+		if err != nil {
+			return rentalModel.RentalOrder{}, err
+		}
+		return m.paidOrder(userId, payment)
+	}
+	if err != nil {
+		fmt.Println("failed to checkout cart:", err.Error())
+		if order.PaymentId != nil {
+			if voidErr := m.paymentService.Void(*order.PaymentId); voidErr != nil {
+				fmt.Println("failed to release payment:", voidErr.Error())
+			}
+		}
+		return rentalModel.RentalOrder{}, err
+	}
+
+	if placed.PaymentId != nil {
+		if _, err = m.paymentService.Capture(*placed.PaymentId); err != nil {
+			return rentalModel.RentalOrder{}, err
+		}
+	}
+	return placed, nil
+}
+
+// paidOrder finishes a checkout whose order was already placed, capturing the
+// payment if the earlier attempt stopped short of it.
+func (m cartService) paidOrder(userId int, payment paymentModel.Payment) (rentalModel.RentalOrder, error) {
+	if payment.UserId != userId {
+		return rentalModel.RentalOrder{}, paymentModel.ErrIdempotencyKeyReused
+	}
+	if _, err := m.paymentService.Capture(payment.Id); err != nil {
+		return rentalModel.RentalOrder{}, err
+	}
+
+	order, err := m.rentalRepository.GetOrder(*payment.OrderId)
+	if err != nil {
+		fmt.Println("failed to fetch paid order:", err.Error())
+		return rentalModel.RentalOrder{}, err
+	}
+	order.PaymentId = &payment.Id
+	return order, nil
+}
+
+func (m cartService) ApplyPromotion(userId int, code string) (model.CartSummary, error) {
+	promotion, err := m.promotionService.Redeemable(userId, code)
+	if err != nil {
+		return model.CartSummary{}, err
+	}
+
+	items, err := m.repository.GetCartItems(userId)
+	if err != nil {
+		fmt.Println("failed to fetch cart for promotion:", err.Error())
+		return model.CartSummary{}, err
+	}
+	eligible := false
+	for _, line := range cartLines(items) {
+		eligible = eligible || promotion.IsEligible(line)
+	}
+	if !eligible {
+		return model.CartSummary{}, promotionModel.ErrPromotionNotApplicable
+	}
+
+	if err = m.promotionService.ApplyToCart(userId, promotion); err != nil {
+		return model.CartSummary{}, err
+	}
+	return m.priceCart(userId, items, &promotion, time.Now()), nil
+}
+
+func (m cartService) RemovePromotion(userId int) error {
+	return m.promotionService.RemoveFromCart(userId)
+}
+
+// priceCart quotes the cart, with the promotion as a cart rule when one is
+// applied.
+func (m cartService) priceCart(userId int, items []model.CartResponse, promotion *promotionModel.Promotion,
+	now time.Time) model.CartSummary {
+	var cartRules []engine.CartRule
+	if promotion != nil {
+		cartRules = append(cartRules, *promotion)
+	}
+	quote := m.pricing.Price(cartLines(items), now, cartRules...)
+
+	summary := model.CartSummary{
+		UserId:        userId,
+		Items:         make([]model.CartLine, 0, len(items)),
+		SubtotalCents: quote.SubtotalCents,
+		DiscountCents: quote.DiscountCents,
+		TaxCents:      quote.TaxCents,
+		TotalCents:    quote.TotalCents,
+	}
+	for i, item := range items {
+		line := quote.Lines[i]
+		summary.Items = append(summary.Items, model.CartLine{
+			CartResponse:   item,
+			ListPriceCents: line.ListPriceCents,
+			PriceCents:     line.PriceCents,
+			DiscountCents:  line.DiscountCents,
+			TotalCents:     line.TotalCents,
+			Adjustments:    line.Adjustments,
+		})
+	}
+	if promotion != nil {
+		summary.PromotionCode = promotion.Code
+	}
+	return summary
+}
+
+func cartLines(items []model.CartResponse) []pricingModel.Line {
+	lines := make([]pricingModel.Line, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, pricingModel.Line{
+			ItemId:         item.Id,
+			MovieId:        item.MovieId,
+			Genre:          item.Genre,
+			ReleaseYear:    item.ReleaseYear,
+			RentalDays:     rentalDays(item),
+			BasePriceCents: item.BasePriceCents,
+		})
+	}
+	return lines
+}
+
+func rentalDays(item model.CartResponse) int {
+	if item.RentalDays == 0 {
+		return constants.DefaultRentalDays
 	}
-	return res, nil
+	return item.RentalDays
 }
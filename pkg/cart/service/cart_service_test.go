@@ -4,16 +4,35 @@ import (
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	"movie-rent/constants"
 	"movie-rent/pkg/cart/mocks"
 	"movie-rent/pkg/cart/model"
+	fineMocks "movie-rent/pkg/fine/mocks"
+	fineModel "movie-rent/pkg/fine/model"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	paymentMocks "movie-rent/pkg/payments/mocks"
+	paymentModel "movie-rent/pkg/payments/model"
+	"movie-rent/pkg/pricing/engine"
+	promotionMocks "movie-rent/pkg/promotion/mocks"
+	promotionModel "movie-rent/pkg/promotion/model"
+	rentalMocks "movie-rent/pkg/rental/mocks"
+	rentalModel "movie-rent/pkg/rental/model"
 	"testing"
+	"time"
 )
 
 type CartServiceTestSuite struct {
 	suite.Suite
 	mockController *gomock.Controller
 	mockRepository *mocks.MockCartRepository
+	mockMovieRepo  *movieMocks.MockMovieRepository
+	mockRentalRepo *rentalMocks.MockRentalRepository
+	mockFine       *fineMocks.MockFineService
+	mockPromotion  *promotionMocks.MockPromotionService
+	mockPayment    *paymentMocks.MockPaymentService
 
+	movie       movieModel.Movie
 	cartService CartService
 }
 
@@ -24,8 +43,15 @@ func TestCartServiceTestSuite(t *testing.T) {
 func (suite *CartServiceTestSuite) SetupTest() {
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockRepository = mocks.NewMockCartRepository(suite.mockController)
+	suite.mockMovieRepo = movieMocks.NewMockMovieRepository(suite.mockController)
+	suite.mockRentalRepo = rentalMocks.NewMockRentalRepository(suite.mockController)
+	suite.mockFine = fineMocks.NewMockFineService(suite.mockController)
+	suite.mockPromotion = promotionMocks.NewMockPromotionService(suite.mockController)
+	suite.mockPayment = paymentMocks.NewMockPaymentService(suite.mockController)
+	suite.movie = movieModel.Movie{Id: 4563, Title: "Hero", Year: 1990, Genre: "Action"}
 
-	suite.cartService = NewCartService(suite.mockRepository)
+	suite.cartService = NewCartService(suite.mockRepository, suite.mockMovieRepo, suite.mockRentalRepo, suite.mockFine,
+		engine.NewEngine(800, engine.DefaultLineRules(2, 50)...), suite.mockPromotion, suite.mockPayment)
 }
 
 func (suite *CartServiceTestSuite) TearDownTest() {
@@ -34,12 +60,12 @@ func (suite *CartServiceTestSuite) TearDownTest() {
 
 func (suite *CartServiceTestSuite) Test_AddToCart_ShouldReturnErrorAddToCartFailed() {
 	request := model.CartRequest{
-		UserId:      1001,
-		MovieId:     4563,
-		MovieName:   "Hero",
-		ReleaseYear: 1990,
+		UserId:  1001,
+		MovieId: 4563,
 	}
-	suite.mockRepository.EXPECT().AddToCart(request).Return(0, fmt.Errorf("error"))
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(suite.movie, nil).Times(1)
+	suite.mockRepository.EXPECT().IsInCart(1001, 4563).Return(false, nil).Times(1)
+	suite.mockRepository.EXPECT().AddToCart(gomock.Any()).Return(0, fmt.Errorf("error"))
 
 	id, err := suite.cartService.AddToCart(request)
 
@@ -49,12 +75,19 @@ func (suite *CartServiceTestSuite) Test_AddToCart_ShouldReturnErrorAddToCartFail
 
 func (suite *CartServiceTestSuite) Test_AddToCart_ShouldSuccessfullyAddToCart() {
 	request := model.CartRequest{
+		UserId:  1001,
+		MovieId: 4563,
+	}
+	stored := model.CartItem{
 		UserId:      1001,
 		MovieId:     4563,
 		MovieName:   "Hero",
 		ReleaseYear: 1990,
+		RentalDays:  3,
 	}
-	suite.mockRepository.EXPECT().AddToCart(request).Return(1, nil).Times(1)
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(suite.movie, nil).Times(1)
+	suite.mockRepository.EXPECT().IsInCart(1001, 4563).Return(false, nil).Times(1)
+	suite.mockRepository.EXPECT().AddToCart(stored).Return(1, nil).Times(1)
 
 	id, err := suite.cartService.AddToCart(request)
 
@@ -62,6 +95,43 @@ func (suite *CartServiceTestSuite) Test_AddToCart_ShouldSuccessfullyAddToCart()
 	suite.Equal(1, id)
 }
 
+func (suite *CartServiceTestSuite) Test_AddToCart_ShouldReturnErrorWhenRentalDaysInvalid() {
+	request := model.CartRequest{
+		UserId:     1001,
+		MovieId:    4563,
+		RentalDays: 5,
+	}
+
+	_, err := suite.cartService.AddToCart(request)
+
+	suite.ErrorIs(err, model.ErrInvalidRentalDays)
+}
+
+func (suite *CartServiceTestSuite) Test_AddToCart_ShouldReturnErrorWhenMovieNotInCatalog() {
+	request := model.CartRequest{
+		UserId:  1001,
+		MovieId: 4563,
+	}
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(movieModel.Movie{}, movieModel.ErrMovieNotFound).Times(1)
+
+	_, err := suite.cartService.AddToCart(request)
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *CartServiceTestSuite) Test_AddToCart_ShouldReturnErrorWhenMovieAlreadyInCart() {
+	request := model.CartRequest{
+		UserId:  1001,
+		MovieId: 4563,
+	}
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(suite.movie, nil).Times(1)
+	suite.mockRepository.EXPECT().IsInCart(1001, 4563).Return(true, nil).Times(1)
+
+	_, err := suite.cartService.AddToCart(request)
+
+	suite.ErrorIs(err, model.ErrDuplicateCartItem)
+}
+
 func (suite *CartServiceTestSuite) Test_GetCartItems_ShouldReturnErrorWhenGetCartItemsFailed() {
 	userId := 1001
 	suite.mockRepository.EXPECT().GetCartItems(userId).Return(nil, fmt.Errorf("error")).Times(1)
@@ -74,16 +144,321 @@ func (suite *CartServiceTestSuite) Test_GetCartItems_ShouldReturnErrorWhenGetCar
 func (suite *CartServiceTestSuite) Test_GetCartItems_ShouldSuccessfullyFetchCartList() {
 	userId := 1001
 	response := []model.CartResponse{{
-		Id:          1,
-		UserId:      1001,
-		MovieId:     4563,
-		MovieName:   "Hero",
-		ReleaseYear: 1990,
+		Id:             1,
+		UserId:         1001,
+		MovieId:        4563,
+		MovieName:      "Hero",
+		ReleaseYear:    1990,
+		RentalDays:     3,
+		Genre:          "Action",
+		BasePriceCents: 200,
 	}}
 	suite.mockRepository.EXPECT().GetCartItems(userId).Return(response, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
 
 	actualResponse, err := suite.cartService.GetCartItems(userId)
 
 	suite.Nil(err)
-	suite.Equal(response, actualResponse)
+	suite.Len(actualResponse.Items, 1)
+	suite.Equal(response[0], actualResponse.Items[0].CartResponse)
+	suite.Equal(600, actualResponse.Items[0].ListPriceCents)
+	suite.Equal(500, actualResponse.Items[0].TotalCents)
+	suite.Equal(600, actualResponse.SubtotalCents)
+	suite.Equal(100, actualResponse.DiscountCents)
+	suite.Equal(40, actualResponse.TaxCents)
+	suite.Equal(540, actualResponse.TotalCents)
+}
+
+func (suite *CartServiceTestSuite) Test_RemoveFromCart_ShouldReturnErrorWhenItemNotFound() {
+	suite.mockRepository.EXPECT().RemoveFromCart(1001, 1).Return(model.ErrCartItemNotFound).Times(1)
+
+	err := suite.cartService.RemoveFromCart(1001, 1)
+
+	suite.ErrorIs(err, model.ErrCartItemNotFound)
+}
+
+func (suite *CartServiceTestSuite) Test_RemoveFromCart_ShouldRemoveItem() {
+	suite.mockRepository.EXPECT().RemoveFromCart(1001, 1).Return(nil).Times(1)
+
+	err := suite.cartService.RemoveFromCart(1001, 1)
+
+	suite.Nil(err)
+}
+
+func (suite *CartServiceTestSuite) Test_ClearCart_ShouldReturnErrorWhenClearFailed() {
+	suite.mockRepository.EXPECT().ClearCart(1001).Return(fmt.Errorf("error")).Times(1)
+
+	err := suite.cartService.ClearCart(1001)
+
+	suite.NotNil(err)
+}
+
+func (suite *CartServiceTestSuite) Test_ClearCart_ShouldClearCart() {
+	suite.mockRepository.EXPECT().ClearCart(1001).Return(nil).Times(1)
+
+	err := suite.cartService.ClearCart(1001)
+
+	suite.Nil(err)
+}
+
+func (suite *CartServiceTestSuite) Test_ExpireReservations_ShouldReleaseLinesOlderThanWindow() {
+	suite.mockRepository.EXPECT().ExpireReservations(gomock.Any()).DoAndReturn(func(before time.Time) (int, error) {
+		suite.WithinDuration(time.Now().Add(-constants.CartReservationWindow), before, time.Second)
+		return 2, nil
+	}).Times(1)
+
+	err := suite.cartService.ExpireReservations()
+
+	suite.Nil(err)
+}
+
+func (suite *CartServiceTestSuite) Test_UpdateRentalDays_ShouldRejectUnsupportedDuration() {
+	_, err := suite.cartService.UpdateRentalDays(1001, 1, 2)
+
+	suite.ErrorIs(err, model.ErrInvalidRentalDays)
+}
+
+func (suite *CartServiceTestSuite) Test_UpdateRentalDays_ShouldUpdateCartItem() {
+	expected := model.CartResponse{Id: 1, UserId: 1001, MovieId: 4563, MovieName: "Hero", ReleaseYear: 1990, RentalDays: 7}
+	suite.mockRepository.EXPECT().UpdateRentalDays(1001, 1, 7).Return(expected, nil).Times(1)
+
+	item, err := suite.cartService.UpdateRentalDays(1001, 1, 7)
+
+	suite.Nil(err)
+	suite.Equal(expected, item)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnErrorWhenBalanceOverLimit() {
+	userId := 1001
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(fineModel.ErrBalanceLimitExceeded).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, fineModel.ErrBalanceLimitExceeded)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnErrorWhenCartIsEmpty() {
+	userId := 1001
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(nil, nil).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, model.ErrEmptyCart)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnErrorWhenCreateOrderFailed() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, MovieName: "Hero", ReleaseYear: 1990}}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).Return(rentalModel.RentalOrder{}, rentalModel.ErrCartChanged).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, rentalModel.ErrCartChanged)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldCreateRentalPerCartItem() {
+	userId := 1001
+	items := []model.CartResponse{
+		{Id: 1, UserId: 1001, MovieId: 4563, MovieName: "Hero", ReleaseYear: 1990},
+		{Id: 2, UserId: 1001, MovieId: 4564, MovieName: "Villain", ReleaseYear: 1992, RentalDays: 7},
+	}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).DoAndReturn(func(order rentalModel.RentalOrder) (rentalModel.RentalOrder, error) {
+		order.Id = 10
+		return order, nil
+	}).Times(1)
+
+	order, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.Nil(err)
+	suite.Equal(10, order.Id)
+	suite.Len(order.Rentals, 2)
+	suite.Equal(1, order.Rentals[0].CartItemId)
+	suite.Equal(4564, order.Rentals[1].MovieId)
+	suite.Equal(rentalModel.StatusActive, order.Rentals[0].Status)
+	suite.Equal(3*24*time.Hour, order.Rentals[0].DueAt.Sub(order.Rentals[0].RentedAt))
+	suite.Equal(7*24*time.Hour, order.Rentals[1].DueAt.Sub(order.Rentals[1].RentedAt))
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldRecordPromotionAndTotalsOnOrder() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, Genre: "Horror", BasePriceCents: 300}}
+	promotion := &promotionModel.Promotion{Id: 3, Code: "SPOOKY", Kind: promotionModel.KindPercentage, PercentOff: 20}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(promotion, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(userId, 259, "tok_visa", "checkout-1").Return(paymentModel.Payment{Id: 5}, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).DoAndReturn(func(order rentalModel.RentalOrder) (rentalModel.RentalOrder, error) {
+		return order, nil
+	}).Times(1)
+	suite.mockPayment.EXPECT().Capture(5).Return(paymentModel.Payment{Id: 5, Status: paymentModel.StatusCaptured}, nil).Times(1)
+
+	order, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.Nil(err)
+	suite.Equal(3, *order.PromotionId)
+	suite.Equal("SPOOKY", order.PromotionCode)
+	suite.Equal(300, order.SubtotalCents)
+	suite.Equal(60, order.DiscountCents)
+	suite.Equal(19, order.TaxCents)
+	suite.Equal(259, order.TotalCents)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnErrorWhenPromotionLapsed() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, Genre: "Horror", BasePriceCents: 300}}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, promotionModel.ErrPromotionInactive).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, promotionModel.ErrPromotionInactive)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldNotRedeemPromotionThatTookNothingOff() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, Genre: "Drama", BasePriceCents: 300}}
+	genre := "Horror"
+	promotion := &promotionModel.Promotion{Id: 3, Code: "SPOOKY", Kind: promotionModel.KindPercentage, PercentOff: 20, Genre: &genre}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(promotion, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(userId, 324, "tok_visa", "checkout-1").Return(paymentModel.Payment{Id: 5}, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).DoAndReturn(func(order rentalModel.RentalOrder) (rentalModel.RentalOrder, error) {
+		return order, nil
+	}).Times(1)
+	suite.mockPayment.EXPECT().Capture(5).Return(paymentModel.Payment{Id: 5, Status: paymentModel.StatusCaptured}, nil).Times(1)
+
+	order, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.Nil(err)
+	suite.Nil(order.PromotionId)
+	suite.Empty(order.PromotionCode)
+	suite.Equal(0, order.DiscountCents)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldNotPlaceOrderWhenPaymentDeclined() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(userId, 324, "tok_visa", "checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentDeclined).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, paymentModel.ErrPaymentDeclined)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldVoidPaymentWhenCreateOrderFailed() {
+	userId := 1001
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(userId, 324, "tok_visa", "checkout-1").Return(paymentModel.Payment{Id: 5}, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).Return(rentalModel.RentalOrder{}, rentalModel.ErrCartChanged).Times(1)
+	suite.mockPayment.EXPECT().Void(5).Return(nil).Times(1)
+
+	_, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, rentalModel.ErrCartChanged)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnWinningOrderWhenConcurrentRetryLost() {
+	userId := 1001
+	orderId := 10
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}
+	authorized := paymentModel.Payment{Id: 5, UserId: userId, Status: paymentModel.StatusAuthorized}
+	linked := paymentModel.Payment{Id: 5, UserId: userId, OrderId: &orderId, Status: paymentModel.StatusAuthorized}
+	gomock.InOrder(
+		suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound),
+		suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(linked, nil),
+	)
+	suite.mockFine.EXPECT().CheckStanding(userId).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().GetCartPromotion(userId).Return(nil, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(userId, 324, "tok_visa", "checkout-1").Return(authorized, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().CreateOrder(gomock.Any()).Return(rentalModel.RentalOrder{}, paymentModel.ErrPaymentLinked).Times(1)
+	suite.mockPayment.EXPECT().Capture(5).Return(paymentModel.Payment{Id: 5, Status: paymentModel.StatusCaptured}, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().GetOrder(orderId).Return(rentalModel.RentalOrder{Id: orderId, UserId: userId}, nil).Times(1)
+
+	order, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.Nil(err)
+	suite.Equal(orderId, order.Id)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldReturnPaidOrderOnRetry() {
+	userId := 1001
+	orderId := 10
+	payment := paymentModel.Payment{Id: 5, UserId: userId, OrderId: &orderId, Status: paymentModel.StatusAuthorized}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(payment, nil).Times(1)
+	suite.mockPayment.EXPECT().Capture(5).Return(paymentModel.Payment{Id: 5, Status: paymentModel.StatusCaptured}, nil).Times(1)
+	suite.mockRentalRepo.EXPECT().GetOrder(orderId).Return(rentalModel.RentalOrder{Id: orderId, UserId: userId}, nil).Times(1)
+
+	order, err := suite.cartService.Checkout(userId, "tok_visa", "checkout-1")
+
+	suite.Nil(err)
+	suite.Equal(orderId, order.Id)
+	suite.Equal(5, *order.PaymentId)
+}
+
+func (suite *CartServiceTestSuite) Test_Checkout_ShouldRejectKeyOfAnotherUser() {
+	orderId := 10
+	payment := paymentModel.Payment{Id: 5, UserId: 2002, OrderId: &orderId, Status: paymentModel.StatusCaptured}
+	suite.mockPayment.EXPECT().GetPaymentByKey("checkout-1").Return(payment, nil).Times(1)
+
+	_, err := suite.cartService.Checkout(1001, "tok_visa", "checkout-1")
+
+	suite.ErrorIs(err, paymentModel.ErrIdempotencyKeyReused)
+}
+
+func (suite *CartServiceTestSuite) Test_ApplyPromotion_ShouldReturnErrorWhenNoItemEligible() {
+	userId := 1001
+	genre := "Horror"
+	items := []model.CartResponse{{Id: 1, UserId: 1001, MovieId: 4563, Genre: "Action", BasePriceCents: 300}}
+	promotion := promotionModel.Promotion{Id: 3, Code: "SPOOKY", Kind: promotionModel.KindPercentage, PercentOff: 20, Genre: &genre}
+	suite.mockPromotion.EXPECT().Redeemable(userId, "spooky").Return(promotion, nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+
+	_, err := suite.cartService.ApplyPromotion(userId, "spooky")
+
+	suite.ErrorIs(err, promotionModel.ErrPromotionNotApplicable)
+}
+
+func (suite *CartServiceTestSuite) Test_ApplyPromotion_ShouldReturnDiscountedCart() {
+	userId := 1001
+	items := []model.CartResponse{
+		{Id: 1, UserId: 1001, MovieId: 4563, ReleaseYear: 1990, RentalDays: 1, Genre: "Action", BasePriceCents: 300},
+		{Id: 2, UserId: 1001, MovieId: 4564, ReleaseYear: 1990, RentalDays: 1, Genre: "Action", BasePriceCents: 200},
+	}
+	promotion := promotionModel.Promotion{Id: 4, Code: "TWOFORONE", Kind: promotionModel.KindBuyXGetY, BuyQuantity: 1, GetQuantity: 1}
+	suite.mockPromotion.EXPECT().Redeemable(userId, "twoforone").Return(promotion, nil).Times(1)
+	suite.mockRepository.EXPECT().GetCartItems(userId).Return(items, nil).Times(1)
+	suite.mockPromotion.EXPECT().ApplyToCart(userId, promotion).Return(nil).Times(1)
+
+	summary, err := suite.cartService.ApplyPromotion(userId, "twoforone")
+
+	suite.Nil(err)
+	suite.Equal("TWOFORONE", summary.PromotionCode)
+	suite.Equal(500, summary.SubtotalCents)
+	suite.Equal(200, summary.DiscountCents)
+	suite.Equal(200, summary.Items[1].DiscountCents)
 }
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/fine/model"
+	"movie-rent/pkg/fine/service"
+	paymentModel "movie-rent/pkg/payments/model"
+	"net/http"
+	"strconv"
+)
+
+type FineController struct {
+	service service.FineService
+}
+
+func NewFineController(service service.FineService) FineController {
+	return FineController{service: service}
+}
+
+func (m *FineController) GetBalance(ctx *gin.Context) {
+	fmt.Println("Fetching balance")
+	id := ctx.Param("id")
+	userId, err := strconv.Atoi(id)
+	if id == "" || err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	balance, err := m.service.GetBalance(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, balance)
+}
+
+func (m *FineController) Pay(ctx *gin.Context) {
+	fmt.Println("Recording payment")
+	id := ctx.Param("id")
+	userId, err := strconv.Atoi(id)
+	if id == "" || err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	var request model.PaymentRequest
+	if bindErr := ctx.ShouldBindJSON(&request); bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+	idempotencyKey := ctx.GetHeader("Idempotency-Key")
+	if idempotencyKey == "" {
+		ctx.JSON(http.StatusBadRequest, paymentModel.ErrMissingIdempotencyKey.Error())
+		return
+	}
+
+	balance, err := m.service.Pay(userId, request.AmountCents, request.PaymentToken, idempotencyKey)
+	if errors.Is(err, model.ErrOverpayment) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, paymentModel.ErrPaymentVoided) || errors.Is(err, paymentModel.ErrIdempotencyKeyReused) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, paymentModel.ErrPaymentDeclined) {
+		ctx.JSON(http.StatusPaymentRequired, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, balance)
+}
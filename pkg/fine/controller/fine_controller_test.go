@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/fine/mocks"
+	"movie-rent/pkg/fine/model"
+	paymentModel "movie-rent/pkg/payments/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type FineControllerTestSuite struct {
+	suite.Suite
+	context         *gin.Context
+	recorder        *httptest.ResponseRecorder
+	mockController  *gomock.Controller
+	mockFineService *mocks.MockFineService
+	testController  FineController
+}
+
+func TestFineControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(FineControllerTestSuite))
+}
+
+func (suite *FineControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockFineService = mocks.NewMockFineService(suite.mockController)
+	suite.testController = NewFineController(suite.mockFineService)
+}
+
+func (suite *FineControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *FineControllerTestSuite) Test_GetBalance_ShouldReturnBadRequestWhenIdInvalid() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/abc/balance", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	suite.testController.GetBalance(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_GetBalance_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/1001/balance", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockFineService.EXPECT().GetBalance(1001).Return(model.Balance{}, errors.New("error")).Times(1)
+
+	suite.testController.GetBalance(suite.context)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_GetBalance_ShouldReturnBalance() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/1001/balance", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockFineService.EXPECT().GetBalance(1001).Return(model.Balance{UserId: 1001, BalanceCents: 300, Entries: []model.Entry{}}, nil).Times(1)
+
+	suite.testController.GetBalance(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"userId":1001,"balanceCents":300,"entries":[]}`, suite.recorder.Body.String())
+}
+
+func (suite *FineControllerTestSuite) Test_Pay_ShouldReturnBadRequestWhenAmountNotPositive() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/payments", strings.NewReader(`{"amountCents":-5}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+
+	suite.testController.Pay(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_Pay_ShouldReturnBadRequestWhenIdempotencyKeyMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/payments", strings.NewReader(`{"amountCents":200}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+
+	suite.testController.Pay(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_Pay_ShouldReturnPaymentRequiredWhenCardDeclined() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/payments", strings.NewReader(`{"amountCents":200,"paymentToken":"tok"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "key-1")
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockFineService.EXPECT().Pay(1001, 200, "tok", "key-1").Return(model.Balance{}, paymentModel.ErrPaymentDeclined).Times(1)
+
+	suite.testController.Pay(suite.context)
+
+	suite.Equal(http.StatusPaymentRequired, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_Pay_ShouldReturnBadRequestWhenOverpaying() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/payments", strings.NewReader(`{"amountCents":500}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "key-1")
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockFineService.EXPECT().Pay(1001, 500, "", "key-1").Return(model.Balance{}, model.ErrOverpayment).Times(1)
+
+	suite.testController.Pay(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *FineControllerTestSuite) Test_Pay_ShouldReturnUpdatedBalance() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/payments", strings.NewReader(`{"amountCents":200,"paymentToken":"tok"}`))
+	suite.context.Request.Header.Set("Idempotency-Key", "key-1")
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockFineService.EXPECT().Pay(1001, 200, "tok", "key-1").Return(model.Balance{UserId: 1001, BalanceCents: 100}, nil).Times(1)
+
+	suite.testController.Pay(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/fine/repository/fine_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/fine/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFineRepository is a mock of FineRepository interface.
+type MockFineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineRepositoryMockRecorder
+}
+
+// MockFineRepositoryMockRecorder is the mock recorder for MockFineRepository.
+type MockFineRepositoryMockRecorder struct {
+	mock *MockFineRepository
+}
+
+// NewMockFineRepository creates a new mock instance.
+func NewMockFineRepository(ctrl *gomock.Controller) *MockFineRepository {
+	mock := &MockFineRepository{ctrl: ctrl}
+	mock.recorder = &MockFineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineRepository) EXPECT() *MockFineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEntry mocks base method.
+func (m *MockFineRepository) AddEntry(entry model.Entry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntry", entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEntry indicates an expected call of AddEntry.
+func (mr *MockFineRepositoryMockRecorder) AddEntry(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntry", reflect.TypeOf((*MockFineRepository)(nil).AddEntry), entry)
+}
+
+// GetBalance mocks base method.
+func (m *MockFineRepository) GetBalance(userId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockFineRepositoryMockRecorder) GetBalance(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockFineRepository)(nil).GetBalance), userId)
+}
+
+// GetEntries mocks base method.
+func (m *MockFineRepository) GetEntries(userId int) ([]model.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", userId)
+	ret0, _ := ret[0].([]model.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockFineRepositoryMockRecorder) GetEntries(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockFineRepository)(nil).GetEntries), userId)
+}
+
+// RecordPayment mocks base method.
+func (m *MockFineRepository) RecordPayment(entry model.Entry, capture func() error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", entry, capture)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockFineRepositoryMockRecorder) RecordPayment(entry, capture interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockFineRepository)(nil).RecordPayment), entry, capture)
+}
+
+// TopUpLateFee mocks base method.
+func (m *MockFineRepository) TopUpLateFee(entry model.Entry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopUpLateFee", entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopUpLateFee indicates an expected call of TopUpLateFee.
+func (mr *MockFineRepositoryMockRecorder) TopUpLateFee(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUpLateFee", reflect.TypeOf((*MockFineRepository)(nil).TopUpLateFee), entry)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/fine/service/fine_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/fine/model"
+	model0 "movie-rent/pkg/rental/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFineService is a mock of FineService interface.
+type MockFineService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineServiceMockRecorder
+}
+
+// MockFineServiceMockRecorder is the mock recorder for MockFineService.
+type MockFineServiceMockRecorder struct {
+	mock *MockFineService
+}
+
+// NewMockFineService creates a new mock instance.
+func NewMockFineService(ctrl *gomock.Controller) *MockFineService {
+	mock := &MockFineService{ctrl: ctrl}
+	mock.recorder = &MockFineServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineService) EXPECT() *MockFineServiceMockRecorder {
+	return m.recorder
+}
+
+// AccrueLateFee mocks base method.
+func (m *MockFineService) AccrueLateFee(rental model0.Rental, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueLateFee", rental, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AccrueLateFee indicates an expected call of AccrueLateFee.
+func (mr *MockFineServiceMockRecorder) AccrueLateFee(rental, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueLateFee", reflect.TypeOf((*MockFineService)(nil).AccrueLateFee), rental, until)
+}
+
+// AccrueLateFees mocks base method.
+func (m *MockFineService) AccrueLateFees() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueLateFees")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AccrueLateFees indicates an expected call of AccrueLateFees.
+func (mr *MockFineServiceMockRecorder) AccrueLateFees() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueLateFees", reflect.TypeOf((*MockFineService)(nil).AccrueLateFees))
+}
+
+// ChargeLostItem mocks base method.
+func (m *MockFineService) ChargeLostItem(rental model0.Rental) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeLostItem", rental)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChargeLostItem indicates an expected call of ChargeLostItem.
+func (mr *MockFineServiceMockRecorder) ChargeLostItem(rental interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeLostItem", reflect.TypeOf((*MockFineService)(nil).ChargeLostItem), rental)
+}
+
+// CheckStanding mocks base method.
+func (m *MockFineService) CheckStanding(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckStanding", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckStanding indicates an expected call of CheckStanding.
+func (mr *MockFineServiceMockRecorder) CheckStanding(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStanding", reflect.TypeOf((*MockFineService)(nil).CheckStanding), userId)
+}
+
+// GetBalance mocks base method.
+func (m *MockFineService) GetBalance(userId int) (model.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", userId)
+	ret0, _ := ret[0].(model.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockFineServiceMockRecorder) GetBalance(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockFineService)(nil).GetBalance), userId)
+}
+
+// Pay mocks base method.
+func (m *MockFineService) Pay(userId, amountCents int, paymentToken, idempotencyKey string) (model.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", userId, amountCents, paymentToken, idempotencyKey)
+	ret0, _ := ret[0].(model.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockFineServiceMockRecorder) Pay(userId, amountCents, paymentToken, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockFineService)(nil).Pay), userId, amountCents, paymentToken, idempotencyKey)
+}
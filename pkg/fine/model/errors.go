@@ -0,0 +1,10 @@
+package model
+
+import "errors"
+
+var (
+	ErrOverpayment          = errors.New("payment exceeds outstanding balance")
+	ErrBalanceLimitExceeded = errors.New("outstanding balance is over the limit")
+	ErrAlreadyCharged       = errors.New("rental is already charged as lost")
+	ErrPaymentRecorded      = errors.New("payment is already booked against the balance")
+)
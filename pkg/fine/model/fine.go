@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+const (
+	KindLateFee  = "late_fee"
+	KindLostItem = "lost_item"
+	KindPayment  = "payment"
+)
+
+// Entry is a single line on a user's fines ledger. Charges are positive and
+// payments negative, so the balance is the sum of all entries.
+type Entry struct {
+	Id          int       `json:"id"`
+	UserId      int       `json:"userId"`
+	RentalId    *int      `json:"rentalId"`
+	PaymentId   *int      `json:"paymentId"`
+	Kind        string    `json:"kind"`
+	AmountCents int       `json:"amountCents"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Balance struct {
+	UserId       int     `json:"userId"`
+	BalanceCents int     `json:"balanceCents"`
+	Entries      []Entry `json:"entries"`
+}
+
+type PaymentRequest struct {
+	AmountCents  int    `json:"amountCents"  binding:"required,gt=0"`
+	PaymentToken string `json:"paymentToken"`
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/fine/model"
+)
+
+const (
+	InsertEntrySQL        = `INSERT INTO fines(user_id, rental_id, payment_id, kind, amount_cents, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	SelectEntriesSQL      = `SELECT id, user_id, rental_id, payment_id, kind, amount_cents, created_at FROM fines WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+	SelectBalanceSQL      = `SELECT COALESCE(SUM(amount_cents), 0) FROM fines WHERE user_id = $1`
+	SelectLateFeeTotalSQL = `SELECT COALESCE(SUM(amount_cents), 0) FROM fines WHERE rental_id = $1 AND kind = 'late_fee'`
+	LockRentalSQL         = `SELECT id FROM rentals WHERE id = $1 FOR UPDATE`
+	LockUserSQL           = `SELECT id FROM users WHERE id = $1 FOR UPDATE`
+	SelectPaymentBooked   = `SELECT EXISTS(SELECT 1 FROM fines WHERE payment_id = $1)`
+)
+
+type FineRepository interface {
+	AddEntry(entry model.Entry) (int, error)
+	GetEntries(userId int) ([]model.Entry, error)
+	GetBalance(userId int) (int, error)
+	TopUpLateFee(entry model.Entry) (int, error)
+	RecordPayment(entry model.Entry, capture func() error) (int, error)
+}
+
+type fineRepo struct {
+	db *sqlx.DB
+}
+
+func NewFineRepository(db *sqlx.DB) FineRepository {
+	return &fineRepo{db: db}
+}
+
+func (m fineRepo) AddEntry(entry model.Entry) (int, error) {
+	var id int
+	err := m.db.QueryRow(InsertEntrySQL, entry.UserId, entry.RentalId, entry.PaymentId, entry.Kind, entry.AmountCents, entry.CreatedAt).Scan(&id)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation, "uq_fines_payment") {
+		return 0, model.ErrPaymentRecorded
+	}
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrAlreadyCharged
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert fine: %w", err)
+	}
+	fmt.Println("Successfully inserted fine. Id:", id)
+	return id, nil
+}
+
+func (m fineRepo) GetEntries(userId int) ([]model.Entry, error) {
+	rows, err := m.db.Query(SelectEntriesSQL, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fines: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []model.Entry{}
+	for rows.Next() {
+		var e model.Entry
+		if err := rows.Scan(&e.Id, &e.UserId, &e.RentalId, &e.PaymentId, &e.Kind, &e.AmountCents, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan fine: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (m fineRepo) GetBalance(userId int) (int, error) {
+	var balance int
+	if err := m.db.QueryRow(SelectBalanceSQL, userId).Scan(&balance); err != nil {
+		return 0, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+	return balance, nil
+}
+
+// TopUpLateFee books the part of entry.AmountCents, the late fee owed in
+// total, that was not charged yet and returns it. The rental row is locked
+// first, so the sweep and a return running at once book the difference once.
+func (m fineRepo) TopUpLateFee(entry model.Entry) (int, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin late fee: %w", err)
+	}
+	defer tx.Rollback()
+
+	var rentalId int
+	if err = tx.QueryRow(LockRentalSQL, *entry.RentalId).Scan(&rentalId); err != nil {
+		return 0, fmt.Errorf("failed to lock rental: %w", err)
+	}
+
+	var charged int
+	if err = tx.QueryRow(SelectLateFeeTotalSQL, rentalId).Scan(&charged); err != nil {
+		return 0, fmt.Errorf("failed to fetch late fees: %w", err)
+	}
+	if entry.AmountCents <= charged {
+		return 0, nil
+	}
+
+	amount := entry.AmountCents - charged
+	var id int
+	err = tx.QueryRow(InsertEntrySQL, entry.UserId, entry.RentalId, entry.PaymentId, model.KindLateFee, amount, entry.CreatedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert late fee: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit late fee: %w", err)
+	}
+	fmt.Println("Successfully inserted late fee. Id:", id)
+	return amount, nil
+}
+
+// RecordPayment books a payment entry once capture has taken the money. The
+// user row is locked while the balance is checked, the card captured and the
+// entry written, so two payments at once cannot both pay off the same fines.
+// A payment larger than the balance is refused before it is captured.
+func (m fineRepo) RecordPayment(entry model.Entry, capture func() error) (int, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin payment entry: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userId int
+	if err = tx.QueryRow(LockUserSQL, entry.UserId).Scan(&userId); err != nil {
+		return 0, fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	var booked bool
+	if err = tx.QueryRow(SelectPaymentBooked, *entry.PaymentId).Scan(&booked); err != nil {
+		return 0, fmt.Errorf("failed to check payment entry: %w", err)
+	}
+	if booked {
+		return 0, model.ErrPaymentRecorded
+	}
+
+	var balance int
+	if err = tx.QueryRow(SelectBalanceSQL, userId).Scan(&balance); err != nil {
+		return 0, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+	if -entry.AmountCents > balance {
+		return 0, model.ErrOverpayment
+	}
+
+	if err = capture(); err != nil {
+		return 0, err
+	}
+	var id int
+	err = tx.QueryRow(InsertEntrySQL, entry.UserId, entry.RentalId, entry.PaymentId, model.KindPayment, entry.AmountCents, entry.CreatedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert payment entry: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit payment entry: %w", err)
+	}
+	fmt.Println("Successfully inserted payment entry. Id:", id)
+	return id, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/fine/model"
+	"testing"
+	"time"
+)
+
+type FineRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository FineRepository
+}
+
+func TestFineRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(FineRepositoryTestSuite))
+}
+
+func (suite *FineRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewFineRepository(suite.mockedDB)
+}
+
+func (suite *FineRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *FineRepositoryTestSuite) Test_AddEntry_ShouldReturnErrorWhenLostItemAlreadyCharged() {
+	now := time.Now()
+	rentalId := 1
+	suite.mockDB.ExpectQuery(InsertEntrySQL).WithArgs(1001, &rentalId, nil, model.KindLostItem, 2500, now).
+		WillReturnError(&pq.Error{Code: postgres.UniqueViolation, Constraint: "uq_fines_lost_item"})
+
+	_, err := suite.testRepository.AddEntry(model.Entry{UserId: 1001, RentalId: &rentalId, Kind: model.KindLostItem, AmountCents: 2500, CreatedAt: now})
+
+	suite.ErrorIs(err, model.ErrAlreadyCharged)
+}
+
+func (suite *FineRepositoryTestSuite) Test_AddEntry_ShouldReturnErrorWhenPaymentAlreadyBooked() {
+	now := time.Now()
+	paymentId := 9
+	suite.mockDB.ExpectQuery(InsertEntrySQL).WithArgs(1001, nil, &paymentId, model.KindPayment, -200, now).
+		WillReturnError(&pq.Error{Code: postgres.UniqueViolation, Constraint: "uq_fines_payment"})
+
+	_, err := suite.testRepository.AddEntry(model.Entry{UserId: 1001, PaymentId: &paymentId, Kind: model.KindPayment, AmountCents: -200, CreatedAt: now})
+
+	suite.ErrorIs(err, model.ErrPaymentRecorded)
+}
+
+func (suite *FineRepositoryTestSuite) Test_GetBalance_ShouldSumLedger() {
+	suite.mockDB.ExpectQuery(SelectBalanceSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(350))
+
+	balance, err := suite.testRepository.GetBalance(1001)
+
+	suite.Nil(err)
+	suite.Equal(350, balance)
+}
+
+func (suite *FineRepositoryTestSuite) Test_TopUpLateFee_ShouldBookOnlyTheDifferenceUnderLock() {
+	now := time.Now()
+	rentalId := 1
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockRentalSQL).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	suite.mockDB.ExpectQuery(SelectLateFeeTotalSQL).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(100))
+	suite.mockDB.ExpectQuery(InsertEntrySQL).WithArgs(1001, &rentalId, nil, model.KindLateFee, 200, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	suite.mockDB.ExpectCommit()
+
+	booked, err := suite.testRepository.TopUpLateFee(model.Entry{UserId: 1001, RentalId: &rentalId, Kind: model.KindLateFee, AmountCents: 300, CreatedAt: now})
+
+	suite.Nil(err)
+	suite.Equal(200, booked)
+}
+
+func (suite *FineRepositoryTestSuite) Test_TopUpLateFee_ShouldSkipWhenAlreadyCharged() {
+	rentalId := 1
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockRentalSQL).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	suite.mockDB.ExpectQuery(SelectLateFeeTotalSQL).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(300))
+	suite.mockDB.ExpectRollback()
+
+	booked, err := suite.testRepository.TopUpLateFee(model.Entry{UserId: 1001, RentalId: &rentalId, Kind: model.KindLateFee, AmountCents: 300, CreatedAt: time.Now()})
+
+	suite.Nil(err)
+	suite.Equal(0, booked)
+}
+
+func (suite *FineRepositoryTestSuite) Test_RecordPayment_ShouldCaptureAndBookUnderLock() {
+	now := time.Now()
+	paymentId := 9
+	captured := false
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockUserSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1001))
+	suite.mockDB.ExpectQuery(SelectPaymentBooked).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	suite.mockDB.ExpectQuery(SelectBalanceSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(300))
+	suite.mockDB.ExpectQuery(InsertEntrySQL).WithArgs(1001, nil, &paymentId, model.KindPayment, -200, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	suite.mockDB.ExpectCommit()
+
+	id, err := suite.testRepository.RecordPayment(model.Entry{UserId: 1001, PaymentId: &paymentId, Kind: model.KindPayment, AmountCents: -200, CreatedAt: now},
+		func() error { captured = true; return nil })
+
+	suite.Nil(err)
+	suite.Equal(5, id)
+	suite.True(captured)
+}
+
+func (suite *FineRepositoryTestSuite) Test_RecordPayment_ShouldRefuseOverpaymentWithoutCapturing() {
+	paymentId := 9
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockUserSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1001))
+	suite.mockDB.ExpectQuery(SelectPaymentBooked).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	suite.mockDB.ExpectQuery(SelectBalanceSQL).WithArgs(1001).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(100))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.RecordPayment(model.Entry{UserId: 1001, PaymentId: &paymentId, Kind: model.KindPayment, AmountCents: -200, CreatedAt: time.Now()},
+		func() error { suite.Fail("capture must not run"); return nil })
+
+	suite.ErrorIs(err, model.ErrOverpayment)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"movie-rent/config"
+	"movie-rent/pkg/fine/model"
+	"movie-rent/pkg/fine/repository"
+	paymentModel "movie-rent/pkg/payments/model"
+	paymentService "movie-rent/pkg/payments/service"
+	rentalModel "movie-rent/pkg/rental/model"
+	rentalRepository "movie-rent/pkg/rental/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/fine/service/fine_service.go -destination=pkg/fine/mocks/fine_service_mock.go -package=mocks
+
+type FineService interface {
+	GetBalance(userId int) (model.Balance, error)
+	Pay(userId int, amountCents int, paymentToken string, idempotencyKey string) (model.Balance, error)
+	CheckStanding(userId int) error
+	AccrueLateFee(rental rentalModel.Rental, until time.Time) error
+	ChargeLostItem(rental rentalModel.Rental) error
+	AccrueLateFees() error
+}
+
+type fineService struct {
+	repository       repository.FineRepository
+	rentalRepository rentalRepository.RentalRepository
+	paymentService   paymentService.PaymentService
+	policy           config.FeeConfig
+}
+
+func NewFineService(repository repository.FineRepository, rentalRepository rentalRepository.RentalRepository,
+	paymentService paymentService.PaymentService, policy config.FeeConfig) FineService {
+	return fineService{repository: repository, rentalRepository: rentalRepository, paymentService: paymentService, policy: policy}
+}
+
+func (m fineService) GetBalance(userId int) (model.Balance, error) {
+	entries, err := m.repository.GetEntries(userId)
+	if err != nil {
+		fmt.Println("failed to find fines:", err.Error())
+		return model.Balance{}, err
+	}
+
+	balance := model.Balance{UserId: userId, Entries: entries}
+	for _, entry := range entries {
+		balance.BalanceCents += entry.AmountCents
+	}
+	return balance, nil
+}
+
+// Pay charges the card and books the amount against the balance. A retry
+// with the same idempotency key finishes the attempt it belongs to, so the
+// card is charged and the ledger credited only once. The balance is checked
+// again while the entry is written; when another payment got there first the
+// authorization is voided instead of captured.
+func (m fineService) Pay(userId int, amountCents int, paymentToken string, idempotencyKey string) (model.Balance, error) {
+	_, err := m.paymentService.GetPaymentByKey(idempotencyKey)
+	if errors.Is(err, paymentModel.ErrPaymentNotFound) {
+		balance, err := m.repository.GetBalance(userId)
+		if err != nil {
+			fmt.Println("failed to find balance:", err.Error())
+			return model.Balance{}, err
+		}
+		if amountCents > balance {
+			return model.Balance{}, model.ErrOverpayment
+		}
+	} else if err != nil {
+		return model.Balance{}, err
+	}
+
+	payment, err := m.paymentService.Authorize(userId, amountCents, paymentToken, idempotencyKey)
+	if err != nil {
+		return model.Balance{}, err
+	}
+	if payment.OrderId != nil {
+		return model.Balance{}, paymentModel.ErrIdempotencyKeyReused
+	}
+
+	paymentId := payment.Id
+	_, err = m.repository.RecordPayment(model.Entry{
+		UserId:      userId,
+		PaymentId:   &paymentId,
+		Kind:        model.KindPayment,
+		AmountCents: -amountCents,
+		CreatedAt:   time.Now(),
+	}, func() error {
+		_, err := m.paymentService.Capture(paymentId)
+		return err
+	})
+	if errors.Is(err, model.ErrOverpayment) {
+		if voidErr := m.paymentService.Void(paymentId); voidErr != nil {
+			fmt.Println("failed to void overpayment:", voidErr.Error())
+		}
+		return model.Balance{}, err
+	}
+	if err != nil && !errors.Is(err, model.ErrPaymentRecorded) {
+		fmt.Println("failed to record payment:", err.Error())
+		return model.Balance{}, err
+	}
+	return m.GetBalance(userId)
+}
+
+// CheckStanding fails when the user owes more than the configured limit.
+func (m fineService) CheckStanding(userId int) error {
+	balance, err := m.repository.GetBalance(userId)
+	if err != nil {
+		fmt.Println("failed to find balance:", err.Error())
+		return err
+	}
+	if balance > m.policy.BalanceLimitCents {
+		return model.ErrBalanceLimitExceeded
+	}
+	return nil
+}
+
+// AccrueLateFee brings the late fee charged for the rental up to what is owed
+// at until. Only the difference is booked, so it is safe to call repeatedly.
+func (m fineService) AccrueLateFee(rental rentalModel.Rental, until time.Time) error {
+	owed := m.lateFee(rental.DueAt, until)
+	if owed == 0 {
+		return nil
+	}
+
+	rentalId := rental.Id
+	_, err := m.repository.TopUpLateFee(model.Entry{
+		UserId:      rental.UserId,
+		RentalId:    &rentalId,
+		Kind:        model.KindLateFee,
+		AmountCents: owed,
+		CreatedAt:   until,
+	})
+	if err != nil {
+		fmt.Println("failed to charge late fee:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (m fineService) ChargeLostItem(rental rentalModel.Rental) error {
+	now := time.Now()
+	if err := m.AccrueLateFee(rental, now); err != nil {
+		return err
+	}
+
+	rentalId := rental.Id
+	_, err := m.repository.AddEntry(model.Entry{
+		UserId:      rental.UserId,
+		RentalId:    &rentalId,
+		Kind:        model.KindLostItem,
+		AmountCents: m.policy.LostItemCents,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		fmt.Println("failed to charge lost item:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// AccrueLateFees is run periodically; it flags rentals that went past their
+// due date and tops up the late fee on every overdue rental.
+func (m fineService) AccrueLateFees() error {
+	now := time.Now()
+	flagged, err := m.rentalRepository.MarkOverdue(now)
+	if err != nil {
+		fmt.Println("failed to mark rentals overdue:", err.Error())
+		return err
+	}
+	if flagged > 0 {
+		fmt.Println("marked rentals overdue:", flagged)
+	}
+
+	rentals, err := m.rentalRepository.GetOverdueRentals()
+	if err != nil {
+		fmt.Println("failed to find overdue rentals:", err.Error())
+		return err
+	}
+	for _, rental := range rentals {
+		if err = m.AccrueLateFee(rental, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lateFee charges the daily fee for every started day past the due date
+// after the grace period, capped per item.
+func (m fineService) lateFee(dueAt time.Time, until time.Time) int {
+	late := until.Sub(dueAt)
+	if late <= 0 {
+		return 0
+	}
+
+	days := int((late + 24*time.Hour - 1) / (24 * time.Hour))
+	days -= m.policy.GracePeriodDays
+	if days <= 0 {
+		return 0
+	}
+
+	fee := days * m.policy.DailyFeeCents
+	if m.policy.MaxLateFeeCents > 0 && fee > m.policy.MaxLateFeeCents {
+		return m.policy.MaxLateFeeCents
+	}
+	return fee
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/config"
+	"movie-rent/pkg/fine/mocks"
+	"movie-rent/pkg/fine/model"
+	paymentMocks "movie-rent/pkg/payments/mocks"
+	paymentModel "movie-rent/pkg/payments/model"
+	rentalMocks "movie-rent/pkg/rental/mocks"
+	rentalModel "movie-rent/pkg/rental/model"
+	"testing"
+	"time"
+)
+
+type FineServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockFineRepository
+	mockRentalRepo *rentalMocks.MockRentalRepository
+	mockPayment    *paymentMocks.MockPaymentService
+
+	fineService FineService
+}
+
+func TestFineServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FineServiceTestSuite))
+}
+
+func (suite *FineServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockFineRepository(suite.mockController)
+	suite.mockRentalRepo = rentalMocks.NewMockRentalRepository(suite.mockController)
+	suite.mockPayment = paymentMocks.NewMockPaymentService(suite.mockController)
+	policy := config.FeeConfig{
+		DailyFeeCents:     100,
+		GracePeriodDays:   1,
+		MaxLateFeeCents:   500,
+		LostItemCents:     2500,
+		BalanceLimitCents: 1000,
+	}
+
+	suite.fineService = NewFineService(suite.mockRepository, suite.mockRentalRepo, suite.mockPayment, policy)
+}
+
+func (suite *FineServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *FineServiceTestSuite) Test_AccrueLateFee_ShouldNotChargeWithinGracePeriod() {
+	dueAt := time.Now()
+	rental := rentalModel.Rental{Id: 1, UserId: 1001, DueAt: dueAt}
+
+	err := suite.fineService.AccrueLateFee(rental, dueAt.Add(20*time.Hour))
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_AccrueLateFee_ShouldTopUpToAmountOwed() {
+	dueAt := time.Now()
+	rental := rentalModel.Rental{Id: 1, UserId: 1001, DueAt: dueAt}
+	suite.mockRepository.EXPECT().TopUpLateFee(gomock.Any()).DoAndReturn(func(entry model.Entry) (int, error) {
+		suite.Equal(model.KindLateFee, entry.Kind)
+		suite.Equal(200, entry.AmountCents)
+		suite.Equal(1, *entry.RentalId)
+		return 100, nil
+	}).Times(1)
+
+	// Three started days late, one of them forgiven.
+	err := suite.fineService.AccrueLateFee(rental, dueAt.Add(49*time.Hour))
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_AccrueLateFee_ShouldStopAtCap() {
+	dueAt := time.Now()
+	rental := rentalModel.Rental{Id: 1, UserId: 1001, DueAt: dueAt}
+	suite.mockRepository.EXPECT().TopUpLateFee(gomock.Any()).DoAndReturn(func(entry model.Entry) (int, error) {
+		suite.Equal(500, entry.AmountCents)
+		return 0, nil
+	}).Times(1)
+
+	err := suite.fineService.AccrueLateFee(rental, dueAt.AddDate(0, 0, 30))
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_ChargeLostItem_ShouldChargeReplacement() {
+	rental := rentalModel.Rental{Id: 1, UserId: 1001, DueAt: time.Now().Add(time.Hour)}
+	suite.mockRepository.EXPECT().AddEntry(gomock.Any()).DoAndReturn(func(entry model.Entry) (int, error) {
+		suite.Equal(model.KindLostItem, entry.Kind)
+		suite.Equal(2500, entry.AmountCents)
+		return 5, nil
+	}).Times(1)
+
+	err := suite.fineService.ChargeLostItem(rental)
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_AccrueLateFees_ShouldChargeEveryOverdueRental() {
+	dueAt := time.Now().AddDate(0, 0, -3)
+	rentals := []rentalModel.Rental{{Id: 1, UserId: 1001, DueAt: dueAt}, {Id: 2, UserId: 1002, DueAt: dueAt}}
+	suite.mockRentalRepo.EXPECT().MarkOverdue(gomock.Any()).Return(int64(1), nil).Times(1)
+	suite.mockRentalRepo.EXPECT().GetOverdueRentals().Return(rentals, nil).Times(1)
+	suite.mockRepository.EXPECT().TopUpLateFee(gomock.Any()).Return(200, nil).Times(2)
+
+	err := suite.fineService.AccrueLateFees()
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_AccrueLateFees_ShouldReturnErrorWhenMarkOverdueFailed() {
+	suite.mockRentalRepo.EXPECT().MarkOverdue(gomock.Any()).Return(int64(0), fmt.Errorf("error")).Times(1)
+
+	err := suite.fineService.AccrueLateFees()
+
+	suite.NotNil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_CheckStanding_ShouldBlockWhenOverLimit() {
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(1001, nil).Times(1)
+
+	err := suite.fineService.CheckStanding(1001)
+
+	suite.ErrorIs(err, model.ErrBalanceLimitExceeded)
+}
+
+func (suite *FineServiceTestSuite) Test_CheckStanding_ShouldAllowBalanceAtLimit() {
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(1000, nil).Times(1)
+
+	err := suite.fineService.CheckStanding(1001)
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_Pay_ShouldRejectOverpayment() {
+	suite.mockPayment.EXPECT().GetPaymentByKey("key-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(300, nil).Times(1)
+
+	_, err := suite.fineService.Pay(1001, 400, "tok", "key-1")
+
+	suite.ErrorIs(err, model.ErrOverpayment)
+}
+
+func (suite *FineServiceTestSuite) Test_Pay_ShouldNotCreditBalanceWhenCardDeclined() {
+	suite.mockPayment.EXPECT().GetPaymentByKey("key-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(300, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(1001, 200, "tok", "key-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentDeclined).Times(1)
+
+	_, err := suite.fineService.Pay(1001, 200, "tok", "key-1")
+
+	suite.ErrorIs(err, paymentModel.ErrPaymentDeclined)
+}
+
+func (suite *FineServiceTestSuite) Test_Pay_ShouldFinishRetryWithoutBookingTwice() {
+	payment := paymentModel.Payment{Id: 9, UserId: 1001, AmountCents: 200, Status: paymentModel.StatusCaptured}
+	suite.mockPayment.EXPECT().GetPaymentByKey("key-1").Return(payment, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(1001, 200, "tok", "key-1").Return(payment, nil).Times(1)
+	suite.mockRepository.EXPECT().RecordPayment(gomock.Any(), gomock.Any()).Return(0, model.ErrPaymentRecorded).Times(1)
+	suite.mockRepository.EXPECT().GetEntries(1001).Return([]model.Entry{}, nil).Times(1)
+
+	_, err := suite.fineService.Pay(1001, 200, "tok", "key-1")
+
+	suite.Nil(err)
+}
+
+func (suite *FineServiceTestSuite) Test_Pay_ShouldRecordPaymentAndReturnBalance() {
+	entries := []model.Entry{
+		{Id: 2, UserId: 1001, Kind: model.KindPayment, AmountCents: -200},
+		{Id: 1, UserId: 1001, Kind: model.KindLateFee, AmountCents: 300},
+	}
+	payment := paymentModel.Payment{Id: 9, UserId: 1001, AmountCents: 200, Status: paymentModel.StatusAuthorized}
+	suite.mockPayment.EXPECT().GetPaymentByKey("key-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(300, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(1001, 200, "tok", "key-1").Return(payment, nil).Times(1)
+	payment.Status = paymentModel.StatusCaptured
+	suite.mockPayment.EXPECT().Capture(9).Return(payment, nil).Times(1)
+	suite.mockRepository.EXPECT().RecordPayment(gomock.Any(), gomock.Any()).DoAndReturn(func(entry model.Entry, capture func() error) (int, error) {
+		suite.Equal(model.KindPayment, entry.Kind)
+		suite.Equal(-200, entry.AmountCents)
+		suite.Equal(9, *entry.PaymentId)
+		return 2, capture()
+	}).Times(1)
+	suite.mockRepository.EXPECT().GetEntries(1001).Return(entries, nil).Times(1)
+
+	balance, err := suite.fineService.Pay(1001, 200, "tok", "key-1")
+
+	suite.Nil(err)
+	suite.Equal(100, balance.BalanceCents)
+	suite.Equal(entries, balance.Entries)
+}
+
+func (suite *FineServiceTestSuite) Test_Pay_ShouldVoidWhenBalanceWasPaidMeanwhile() {
+	payment := paymentModel.Payment{Id: 9, UserId: 1001, AmountCents: 200, Status: paymentModel.StatusAuthorized}
+	suite.mockPayment.EXPECT().GetPaymentByKey("key-1").Return(paymentModel.Payment{}, paymentModel.ErrPaymentNotFound).Times(1)
+	suite.mockRepository.EXPECT().GetBalance(1001).Return(300, nil).Times(1)
+	suite.mockPayment.EXPECT().Authorize(1001, 200, "tok", "key-1").Return(payment, nil).Times(1)
+	suite.mockRepository.EXPECT().RecordPayment(gomock.Any(), gomock.Any()).Return(0, model.ErrOverpayment).Times(1)
+	suite.mockPayment.EXPECT().Void(9).Return(nil).Times(1)
+
+	_, err := suite.fineService.Pay(1001, 200, "tok", "key-1")
+
+	suite.ErrorIs(err, model.ErrOverpayment)
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/genre/service"
+	"net/http"
+)
+
+type GenreController struct {
+	service service.GenreService
+}
+
+func NewGenreController(service service.GenreService) GenreController {
+	return GenreController{service: service}
+}
+
+func (m *GenreController) GetGenres(ctx *gin.Context) {
+	genres, err := m.service.GetGenres()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, genres)
+}
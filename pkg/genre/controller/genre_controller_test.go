@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/genre/mocks"
+	"movie-rent/pkg/genre/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type GenreControllerTestSuite struct {
+	suite.Suite
+	context          *gin.Context
+	recorder         *httptest.ResponseRecorder
+	mockController   *gomock.Controller
+	mockGenreService *mocks.MockGenreService
+	testController   GenreController
+}
+
+func TestGenreControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(GenreControllerTestSuite))
+}
+
+func (suite *GenreControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/genres", nil)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockGenreService = mocks.NewMockGenreService(suite.mockController)
+	suite.testController = NewGenreController(suite.mockGenreService)
+}
+
+func (suite *GenreControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *GenreControllerTestSuite) Test_GetGenres_ShouldReturnGenresWithCounts() {
+	genres := []model.Genre{{Id: 12, Slug: "horror", Name: "Horror", MovieCount: 42}}
+	suite.mockGenreService.EXPECT().GetGenres().Return(genres, nil).Times(1)
+
+	suite.testController.GetGenres(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`[{"id":12,"slug":"horror","name":"Horror","movieCount":42}]`, suite.recorder.Body.String())
+}
+
+func (suite *GenreControllerTestSuite) Test_GetGenres_ShouldReturnInternalServerErrorWhenServiceFails() {
+	suite.mockGenreService.EXPECT().GetGenres().Return(nil, errors.New("error")).Times(1)
+
+	suite.testController.GetGenres(suite.context)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
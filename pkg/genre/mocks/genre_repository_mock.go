@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/genre/repository/genre_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/genre/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGenreRepository is a mock of GenreRepository interface.
+type MockGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepositoryMockRecorder
+}
+
+// MockGenreRepositoryMockRecorder is the mock recorder for MockGenreRepository.
+type MockGenreRepositoryMockRecorder struct {
+	mock *MockGenreRepository
+}
+
+// NewMockGenreRepository creates a new mock instance.
+func NewMockGenreRepository(ctrl *gomock.Controller) *MockGenreRepository {
+	mock := &MockGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepository) EXPECT() *MockGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetGenres mocks base method.
+func (m *MockGenreRepository) GetGenres() ([]model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres")
+	ret0, _ := ret[0].([]model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreRepositoryMockRecorder) GetGenres() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreRepository)(nil).GetGenres))
+}
+
+// GetTaxonomy mocks base method.
+func (m *MockGenreRepository) GetTaxonomy() (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxonomy")
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxonomy indicates an expected call of GetTaxonomy.
+func (mr *MockGenreRepositoryMockRecorder) GetTaxonomy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxonomy", reflect.TypeOf((*MockGenreRepository)(nil).GetTaxonomy))
+}
+
+// SetMovieGenres mocks base method.
+func (m *MockGenreRepository) SetMovieGenres(movieId int, genres []model.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMovieGenres", movieId, genres)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMovieGenres indicates an expected call of SetMovieGenres.
+func (mr *MockGenreRepositoryMockRecorder) SetMovieGenres(movieId, genres interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMovieGenres", reflect.TypeOf((*MockGenreRepository)(nil).SetMovieGenres), movieId, genres)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/genre/service/genre_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/genre/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGenreService is a mock of GenreService interface.
+type MockGenreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreServiceMockRecorder
+}
+
+// MockGenreServiceMockRecorder is the mock recorder for MockGenreService.
+type MockGenreServiceMockRecorder struct {
+	mock *MockGenreService
+}
+
+// NewMockGenreService creates a new mock instance.
+func NewMockGenreService(ctrl *gomock.Controller) *MockGenreService {
+	mock := &MockGenreService{ctrl: ctrl}
+	mock.recorder = &MockGenreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreService) EXPECT() *MockGenreServiceMockRecorder {
+	return m.recorder
+}
+
+// GetGenres mocks base method.
+func (m *MockGenreService) GetGenres() ([]model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres")
+	ret0, _ := ret[0].([]model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreServiceMockRecorder) GetGenres() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreService)(nil).GetGenres))
+}
+
+// GetTaxonomy mocks base method.
+func (m *MockGenreService) GetTaxonomy() (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxonomy")
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxonomy indicates an expected call of GetTaxonomy.
+func (mr *MockGenreServiceMockRecorder) GetTaxonomy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxonomy", reflect.TypeOf((*MockGenreService)(nil).GetTaxonomy))
+}
+
+// TagMovie mocks base method.
+func (m *MockGenreService) TagMovie(movieId int, genres []model.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagMovie", movieId, genres)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagMovie indicates an expected call of TagMovie.
+func (mr *MockGenreServiceMockRecorder) TagMovie(movieId, genres interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagMovie", reflect.TypeOf((*MockGenreService)(nil).TagMovie), movieId, genres)
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Genre struct {
+	Id         int    `json:"id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	MovieCount int    `json:"movieCount"`
+}
+
+var (
+	separators   = regexp.MustCompile(`[,/|]`)
+	nonAlphaNums = regexp.MustCompile(`[^a-z0-9]`)
+)
+
+// NormalizeAlias reduces a genre name to the key aliases are stored under,
+// so "Sci-Fi", "sci fi" and "SciFi" all become "scifi". The migration that
+// seeded genre_aliases uses the same rule.
+func NormalizeAlias(name string) string {
+	return nonAlphaNums.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// Taxonomy maps aliases onto canonical genres.
+type Taxonomy struct {
+	aliases map[string]Genre
+}
+
+func NewTaxonomy(aliases map[string]Genre) Taxonomy {
+	return Taxonomy{aliases: aliases}
+}
+
+// Match reads a free-text genre such as "Action, Sci-Fi" and returns the
+// canonical genres it names, in the order given and without repeats. Names
+// the taxonomy does not know are dropped.
+func (t Taxonomy) Match(text string) []Genre {
+	matched := []Genre{}
+	seen := map[int]bool{}
+	for _, part := range separators.Split(text, -1) {
+		genre, ok := t.aliases[NormalizeAlias(part)]
+		if !ok || seen[genre.Id] {
+			continue
+		}
+		seen[genre.Id] = true
+		matched = append(matched, genre)
+	}
+	return matched
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type GenreTestSuite struct {
+	suite.Suite
+	taxonomy Taxonomy
+}
+
+func TestGenreTestSuite(t *testing.T) {
+	suite.Run(t, new(GenreTestSuite))
+}
+
+var (
+	action         = Genre{Id: 1, Slug: "action", Name: "Action"}
+	comedy         = Genre{Id: 5, Slug: "comedy", Name: "Comedy"}
+	scienceFiction = Genre{Id: 17, Slug: "science-fiction", Name: "Science Fiction"}
+)
+
+func (suite *GenreTestSuite) SetupTest() {
+	suite.taxonomy = NewTaxonomy(map[string]Genre{
+		"action":         action,
+		"comedy":         comedy,
+		"sciencefiction": scienceFiction,
+		"scifi":          scienceFiction,
+	})
+}
+
+func (suite *GenreTestSuite) Test_NormalizeAlias_ShouldIgnoreCaseSpacesAndPunctuation() {
+	suite.Equal("scifi", NormalizeAlias("Sci-Fi"))
+	suite.Equal("scifi", NormalizeAlias(" sci fi "))
+	suite.Equal("sciencefiction", NormalizeAlias("Science Fiction"))
+}
+
+func (suite *GenreTestSuite) Test_Match_ShouldMapAliasesOntoCanonicalGenres() {
+	suite.Equal([]Genre{scienceFiction}, suite.taxonomy.Match("SciFi"))
+	suite.Equal([]Genre{scienceFiction}, suite.taxonomy.Match("science fiction"))
+}
+
+func (suite *GenreTestSuite) Test_Match_ShouldKeepOrderAndDropRepeatsAndUnknowns() {
+	genres := suite.taxonomy.Match("Comedy / Sci-Fi, Mumblecore | Science Fiction, Action")
+
+	suite.Equal([]Genre{comedy, scienceFiction, action}, genres)
+}
+
+func (suite *GenreTestSuite) Test_Match_ShouldReturnEmptyForUnknownGenre() {
+	suite.Empty(suite.taxonomy.Match("Mumblecore"))
+	suite.Empty(suite.taxonomy.Match(""))
+}
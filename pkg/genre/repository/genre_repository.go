@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/genre/model"
+	movieModel "movie-rent/pkg/movie/model"
+)
+
+const (
+	SelectGenresSQL = `SELECT g.id, g.slug, g.name, COUNT(m.id) FROM genres g ` +
+		`LEFT JOIN movie_genres mg ON mg.genre_id = g.id LEFT JOIN movies m ON m.id = mg.movie_id AND m.deleted_at IS NULL ` +
+		`GROUP BY g.id, g.slug, g.name ORDER BY g.name`
+	SelectGenreAliasesSQL = `SELECT a.alias, g.id, g.slug, g.name FROM genre_aliases a JOIN genres g ON g.id = a.genre_id`
+	DeleteMovieGenresSQL  = `DELETE FROM movie_genres WHERE movie_id = $1`
+	InsertMovieGenreSQL   = `INSERT INTO movie_genres(movie_id, genre_id, position) VALUES ($1, $2, $3)`
+	UpdatePrimaryGenreSQL = `UPDATE movies SET genre = $1 WHERE id = $2`
+)
+
+type GenreRepository interface {
+	GetGenres() ([]model.Genre, error)
+	GetTaxonomy() (model.Taxonomy, error)
+	SetMovieGenres(movieId int, genres []model.Genre) error
+}
+
+type genreRepo struct {
+	db *sqlx.DB
+}
+
+func NewGenreRepository(db *sqlx.DB) GenreRepository {
+	return &genreRepo{db: db}
+}
+
+// GetGenres lists every canonical genre with the number of movies in the
+// catalog tagged with it.
+func (m genreRepo) GetGenres() ([]model.Genre, error) {
+	rows, err := m.db.Query(SelectGenresSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch genres: %w", err)
+	}
+	defer rows.Close()
+
+	genres := []model.Genre{}
+	for rows.Next() {
+		var genre model.Genre
+		if err := rows.Scan(&genre.Id, &genre.Slug, &genre.Name, &genre.MovieCount); err != nil {
+			return nil, fmt.Errorf("failed to scan genre: %w", err)
+		}
+		genres = append(genres, genre)
+	}
+	return genres, rows.Err()
+}
+
+func (m genreRepo) GetTaxonomy() (model.Taxonomy, error) {
+	rows, err := m.db.Query(SelectGenreAliasesSQL)
+	if err != nil {
+		return model.Taxonomy{}, fmt.Errorf("failed to fetch genre aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := map[string]model.Genre{}
+	for rows.Next() {
+		var alias string
+		var genre model.Genre
+		if err := rows.Scan(&alias, &genre.Id, &genre.Slug, &genre.Name); err != nil {
+			return model.Taxonomy{}, fmt.Errorf("failed to scan genre alias: %w", err)
+		}
+		aliases[alias] = genre
+	}
+	return model.NewTaxonomy(aliases), rows.Err()
+}
+
+// SetMovieGenres replaces a movie's genres, in order, and makes the first
+// one its primary genre. An empty list clears the tags and leaves the genre
+// text alone.
+func (m genreRepo) SetMovieGenres(movieId int, genres []model.Genre) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin genre update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(DeleteMovieGenresSQL, movieId); err != nil {
+		return fmt.Errorf("failed to clear movie genres: %w", err)
+	}
+	for position, genre := range genres {
+		_, err = tx.Exec(InsertMovieGenreSQL, movieId, genre.Id, position)
+		if postgres.IsViolation(err, postgres.ForeignKeyViolation) {
+			return movieModel.ErrMovieNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert movie genre: %w", err)
+		}
+	}
+	if len(genres) > 0 {
+		if _, err = tx.Exec(UpdatePrimaryGenreSQL, genres[0].Name, movieId); err != nil {
+			return fmt.Errorf("failed to update primary genre: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit movie genres: %w", err)
+	}
+	return nil
+}
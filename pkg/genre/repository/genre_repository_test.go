@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/genre/model"
+	movieModel "movie-rent/pkg/movie/model"
+	"testing"
+)
+
+type GenreRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository GenreRepository
+}
+
+func TestGenreRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(GenreRepositoryTestSuite))
+}
+
+func (suite *GenreRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewGenreRepository(suite.mockedDB)
+}
+
+func (suite *GenreRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+var (
+	action         = model.Genre{Id: 1, Slug: "action", Name: "Action"}
+	scienceFiction = model.Genre{Id: 17, Slug: "science-fiction", Name: "Science Fiction"}
+)
+
+func (suite *GenreRepositoryTestSuite) Test_GetGenres_ShouldReturnMovieCounts() {
+	suite.mockDB.ExpectQuery(SelectGenresSQL).WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "count"}).
+		AddRow(1, "action", "Action", 42).
+		AddRow(20, "western", "Western", 0))
+
+	genres, err := suite.testRepository.GetGenres()
+
+	suite.Nil(err)
+	suite.Equal([]model.Genre{
+		{Id: 1, Slug: "action", Name: "Action", MovieCount: 42},
+		{Id: 20, Slug: "western", Name: "Western", MovieCount: 0},
+	}, genres)
+}
+
+func (suite *GenreRepositoryTestSuite) Test_GetTaxonomy_ShouldMatchByAlias() {
+	suite.mockDB.ExpectQuery(SelectGenreAliasesSQL).WillReturnRows(sqlmock.NewRows([]string{"alias", "id", "slug", "name"}).
+		AddRow("sciencefiction", 17, "science-fiction", "Science Fiction").
+		AddRow("scifi", 17, "science-fiction", "Science Fiction"))
+
+	taxonomy, err := suite.testRepository.GetTaxonomy()
+
+	suite.Nil(err)
+	suite.Equal([]model.Genre{scienceFiction}, taxonomy.Match("Sci-Fi"))
+}
+
+func (suite *GenreRepositoryTestSuite) Test_SetMovieGenres_ShouldReplaceTagsAndPrimaryGenre() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(DeleteMovieGenresSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(InsertMovieGenreSQL).WithArgs(7, 17, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(InsertMovieGenreSQL).WithArgs(7, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(UpdatePrimaryGenreSQL).WithArgs("Science Fiction", 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.SetMovieGenres(7, []model.Genre{scienceFiction, action})
+
+	suite.Nil(err)
+}
+
+func (suite *GenreRepositoryTestSuite) Test_SetMovieGenres_ShouldOnlyClearTagsWhenNoGenres() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(DeleteMovieGenresSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.SetMovieGenres(7, []model.Genre{})
+
+	suite.Nil(err)
+}
+
+func (suite *GenreRepositoryTestSuite) Test_SetMovieGenres_ShouldReturnNotFoundForUnknownMovie() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(DeleteMovieGenresSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectExec(InsertMovieGenreSQL).WithArgs(7, 1, 0).WillReturnError(&pq.Error{Code: postgres.ForeignKeyViolation})
+	suite.mockDB.ExpectRollback()
+
+	err := suite.testRepository.SetMovieGenres(7, []model.Genre{action})
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
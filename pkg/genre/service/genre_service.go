@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"movie-rent/pkg/genre/model"
+	"movie-rent/pkg/genre/repository"
+)
+
+// go:generate mockgen -source=pkg/genre/service/genre_service.go -destination=pkg/genre/mocks/genre_service_mock.go -package=mocks
+
+type GenreService interface {
+	GetGenres() ([]model.Genre, error)
+	GetTaxonomy() (model.Taxonomy, error)
+	TagMovie(movieId int, genres []model.Genre) error
+}
+
+type genreService struct {
+	repository repository.GenreRepository
+}
+
+func NewGenreService(repository repository.GenreRepository) GenreService {
+	return genreService{repository: repository}
+}
+
+func (m genreService) GetGenres() ([]model.Genre, error) {
+	genres, err := m.repository.GetGenres()
+	if err != nil {
+		fmt.Println("failed to find genres:", err.Error())
+		return nil, err
+	}
+	return genres, nil
+}
+
+func (m genreService) GetTaxonomy() (model.Taxonomy, error) {
+	return m.repository.GetTaxonomy()
+}
+
+// TagMovie records the movie's canonical genres. Movies whose genre text
+// matched nothing keep their text as it is and get no tags.
+func (m genreService) TagMovie(movieId int, genres []model.Genre) error {
+	return m.repository.SetMovieGenres(movieId, genres)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/genre/mocks"
+	"movie-rent/pkg/genre/model"
+	"testing"
+)
+
+type GenreServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockGenreRepository
+
+	genreService GenreService
+}
+
+func TestGenreServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GenreServiceTestSuite))
+}
+
+func (suite *GenreServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockGenreRepository(suite.mockController)
+
+	suite.genreService = NewGenreService(suite.mockRepository)
+}
+
+func (suite *GenreServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *GenreServiceTestSuite) Test_GetGenres_ShouldReturnError() {
+	suite.mockRepository.EXPECT().GetGenres().Return(nil, fmt.Errorf("error")).Times(1)
+
+	_, err := suite.genreService.GetGenres()
+
+	suite.NotNil(err)
+}
+
+func (suite *GenreServiceTestSuite) Test_TagMovie_ShouldSetGenresInOrder() {
+	genres := []model.Genre{{Id: 17, Name: "Science Fiction"}, {Id: 1, Name: "Action"}}
+	suite.mockRepository.EXPECT().SetMovieGenres(7, genres).Return(nil).Times(1)
+
+	err := suite.genreService.TagMovie(7, genres)
+
+	suite.Nil(err)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
+	cartModel "movie-rent/pkg/cart/model"
+	"movie-rent/pkg/hold/model"
+	"movie-rent/pkg/hold/service"
+	movieModel "movie-rent/pkg/movie/model"
+	"net/http"
+	"strconv"
+)
+
+type HoldController struct {
+	service service.HoldService
+}
+
+func NewHoldController(service service.HoldService) HoldController {
+	return HoldController{service: service}
+}
+
+func (m *HoldController) PlaceHold(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+
+	hold, err := m.service.PlaceHold(movieId, userId)
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateHold) || errors.Is(err, model.ErrCopiesAvailable) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, hold)
+}
+
+func (m *HoldController) GetUserHolds(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	holds, err := m.service.GetUserHolds(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, holds)
+}
+
+func (m *HoldController) ConvertToCart(ctx *gin.Context) {
+	holdId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	var request model.ConvertRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+	request.UserId = userId
+
+	id, err := m.service.ConvertToCart(holdId, request.UserId, request.RentalDays)
+	if errors.Is(err, cartModel.ErrInvalidRentalDays) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrHoldNotFound) || errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrHoldNotAllocated) || errors.Is(err, model.ErrHoldExpired) ||
+		errors.Is(err, cartModel.ErrDuplicateCartItem) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, id)
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/middleware"
+	"movie-rent/pkg/hold/mocks"
+	"movie-rent/pkg/hold/model"
+	movieModel "movie-rent/pkg/movie/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type HoldControllerTestSuite struct {
+	suite.Suite
+	context         *gin.Context
+	recorder        *httptest.ResponseRecorder
+	mockController  *gomock.Controller
+	mockHoldService *mocks.MockHoldService
+	testController  HoldController
+}
+
+func TestHoldControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldControllerTestSuite))
+}
+
+func (suite *HoldControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Set(middleware.UserIdKey, 1001)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockHoldService = mocks.NewMockHoldService(suite.mockController)
+	suite.testController = NewHoldController(suite.mockHoldService)
+}
+
+func (suite *HoldControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *HoldControllerTestSuite) Test_PlaceHold_ShouldReturnUnauthorizedWithoutAuthenticatedUser() {
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/4563/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "4563"}}
+
+	suite.testController.PlaceHold(suite.context)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_PlaceHold_ShouldReturnNotFoundWhenMovieMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/4563/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "4563"}}
+	suite.mockHoldService.EXPECT().PlaceHold(4563, 1001).Return(model.Hold{}, movieModel.ErrMovieNotFound).Times(1)
+
+	suite.testController.PlaceHold(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_PlaceHold_ShouldReturnConflictWhenCopiesAvailable() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/4563/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "4563"}}
+	suite.mockHoldService.EXPECT().PlaceHold(4563, 1001).Return(model.Hold{}, model.ErrCopiesAvailable).Times(1)
+
+	suite.testController.PlaceHold(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_PlaceHold_ShouldCreateHold() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/4563/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "4563"}}
+	suite.mockHoldService.EXPECT().PlaceHold(4563, 1001).Return(model.Hold{Id: 7, Position: 1}, nil).Times(1)
+
+	suite.testController.PlaceHold(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_GetUserHolds_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/1001/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockHoldService.EXPECT().GetUserHolds(1001).Return(nil, errors.New("error")).Times(1)
+
+	suite.testController.GetUserHolds(suite.context)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_GetUserHolds_ShouldReturnHolds() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/1001/holds", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockHoldService.EXPECT().GetUserHolds(1001).Return([]model.Hold{{Id: 7, Position: 2}}, nil).Times(1)
+
+	suite.testController.GetUserHolds(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_ConvertToCart_ShouldReturnConflictWhenHoldExpired() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/holds/7/cart", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockHoldService.EXPECT().ConvertToCart(7, 1001, 0).Return(0, model.ErrHoldExpired).Times(1)
+
+	suite.testController.ConvertToCart(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_ConvertToCart_ShouldIgnoreUserIdInBody() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/holds/7/cart", strings.NewReader(`{"userId":2002,"rentalDays":3}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockHoldService.EXPECT().ConvertToCart(7, 1001, 3).Return(11, nil).Times(1)
+
+	suite.testController.ConvertToCart(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *HoldControllerTestSuite) Test_ConvertToCart_ShouldReturnCartItemId() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/holds/7/cart", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockHoldService.EXPECT().ConvertToCart(7, 1001, 0).Return(11, nil).Times(1)
+
+	suite.testController.ConvertToCart(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("11", suite.recorder.Body.String())
+}
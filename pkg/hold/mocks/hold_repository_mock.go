@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/hold/repository/hold_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/hold/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHoldRepository is a mock of HoldRepository interface.
+type MockHoldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepositoryMockRecorder
+}
+
+// MockHoldRepositoryMockRecorder is the mock recorder for MockHoldRepository.
+type MockHoldRepositoryMockRecorder struct {
+	mock *MockHoldRepository
+}
+
+// NewMockHoldRepository creates a new mock instance.
+func NewMockHoldRepository(ctrl *gomock.Controller) *MockHoldRepository {
+	mock := &MockHoldRepository{ctrl: ctrl}
+	mock.recorder = &MockHoldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepository) EXPECT() *MockHoldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddHold mocks base method.
+func (m *MockHoldRepository) AddHold(hold model.Hold) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHold", hold)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHold indicates an expected call of AddHold.
+func (mr *MockHoldRepositoryMockRecorder) AddHold(hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHold", reflect.TypeOf((*MockHoldRepository)(nil).AddHold), hold)
+}
+
+// AllocateNext mocks base method.
+func (m *MockHoldRepository) AllocateNext(movieId int, allocatedAt, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateNext", movieId, allocatedAt, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateNext indicates an expected call of AllocateNext.
+func (mr *MockHoldRepositoryMockRecorder) AllocateNext(movieId, allocatedAt, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateNext", reflect.TypeOf((*MockHoldRepository)(nil).AllocateNext), movieId, allocatedAt, expiresAt)
+}
+
+// ExpireAllocations mocks base method.
+func (m *MockHoldRepository) ExpireAllocations(now time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAllocations", now)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAllocations indicates an expected call of ExpireAllocations.
+func (mr *MockHoldRepositoryMockRecorder) ExpireAllocations(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAllocations", reflect.TypeOf((*MockHoldRepository)(nil).ExpireAllocations), now)
+}
+
+// GetHold mocks base method.
+func (m *MockHoldRepository) GetHold(holdId int) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", holdId)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockHoldRepositoryMockRecorder) GetHold(holdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockHoldRepository)(nil).GetHold), holdId)
+}
+
+// GetUserHolds mocks base method.
+func (m *MockHoldRepository) GetUserHolds(userId int) ([]model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHolds", userId)
+	ret0, _ := ret[0].([]model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHolds indicates an expected call of GetUserHolds.
+func (mr *MockHoldRepositoryMockRecorder) GetUserHolds(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHolds", reflect.TypeOf((*MockHoldRepository)(nil).GetUserHolds), userId)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/hold/service/hold_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/hold/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHoldService is a mock of HoldService interface.
+type MockHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldServiceMockRecorder
+}
+
+// MockHoldServiceMockRecorder is the mock recorder for MockHoldService.
+type MockHoldServiceMockRecorder struct {
+	mock *MockHoldService
+}
+
+// NewMockHoldService creates a new mock instance.
+func NewMockHoldService(ctrl *gomock.Controller) *MockHoldService {
+	mock := &MockHoldService{ctrl: ctrl}
+	mock.recorder = &MockHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldService) EXPECT() *MockHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// AllocateNext mocks base method.
+func (m *MockHoldService) AllocateNext(movieId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateNext", movieId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AllocateNext indicates an expected call of AllocateNext.
+func (mr *MockHoldServiceMockRecorder) AllocateNext(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateNext", reflect.TypeOf((*MockHoldService)(nil).AllocateNext), movieId)
+}
+
+// ConvertToCart mocks base method.
+func (m *MockHoldService) ConvertToCart(holdId, userId, rentalDays int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertToCart", holdId, userId, rentalDays)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertToCart indicates an expected call of ConvertToCart.
+func (mr *MockHoldServiceMockRecorder) ConvertToCart(holdId, userId, rentalDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertToCart", reflect.TypeOf((*MockHoldService)(nil).ConvertToCart), holdId, userId, rentalDays)
+}
+
+// ExpireAllocations mocks base method.
+func (m *MockHoldService) ExpireAllocations() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAllocations")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireAllocations indicates an expected call of ExpireAllocations.
+func (mr *MockHoldServiceMockRecorder) ExpireAllocations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAllocations", reflect.TypeOf((*MockHoldService)(nil).ExpireAllocations))
+}
+
+// GetUserHolds mocks base method.
+func (m *MockHoldService) GetUserHolds(userId int) ([]model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHolds", userId)
+	ret0, _ := ret[0].([]model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHolds indicates an expected call of GetUserHolds.
+func (mr *MockHoldServiceMockRecorder) GetUserHolds(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHolds", reflect.TypeOf((*MockHoldService)(nil).GetUserHolds), userId)
+}
+
+// PlaceHold mocks base method.
+func (m *MockHoldService) PlaceHold(movieId, userId int) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", movieId, userId)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockHoldServiceMockRecorder) PlaceHold(movieId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockHoldService)(nil).PlaceHold), movieId, userId)
+}
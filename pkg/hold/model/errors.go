@@ -0,0 +1,11 @@
+package model
+
+import "errors"
+
+var (
+	ErrHoldNotFound     = errors.New("hold not found")
+	ErrDuplicateHold    = errors.New("user is already waiting for this movie")
+	ErrCopiesAvailable  = errors.New("copies are available, add the movie to the cart instead")
+	ErrHoldNotAllocated = errors.New("hold has no copy allocated")
+	ErrHoldExpired      = errors.New("hold pickup window has expired")
+)
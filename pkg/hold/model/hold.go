@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	StatusWaiting   = "waiting"
+	StatusAllocated = "allocated"
+	StatusFulfilled = "fulfilled"
+	StatusExpired   = "expired"
+)
+
+type Hold struct {
+	Id          int        `json:"id"`
+	MovieId     int        `json:"movieId"`
+	UserId      int        `json:"userId"`
+	Status      string     `json:"status"`
+	Position    int        `json:"position"`
+	CopyId      *int       `json:"copyId"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	AllocatedAt *time.Time `json:"allocatedAt"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// ConvertRequest.UserId is filled from the caller's token, never from the body.
+type ConvertRequest struct {
+	UserId     int `json:"-"`
+	RentalDays int `json:"rentalDays"`
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/hold/model"
+	"time"
+)
+
+const (
+	HoldColumns = `h.id, h.movie_id, h.user_id, h.status, ` +
+		`CASE WHEN h.status = 'waiting' THEN (SELECT COUNT(*) FROM movie_holds w WHERE w.movie_id = h.movie_id AND w.status = 'waiting' AND (w.created_at, w.id) <= (h.created_at, h.id)) ELSE 0 END AS position, ` +
+		`h.copy_id, h.created_at, h.allocated_at, h.expires_at`
+	InsertHoldSQL        = `INSERT INTO movie_holds(movie_id, user_id, status, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	SelectHoldByIdSQL    = `SELECT ` + HoldColumns + ` FROM movie_holds h WHERE h.id = $1`
+	SelectUserHoldsSQL   = `SELECT ` + HoldColumns + ` FROM movie_holds h WHERE h.user_id = $1 AND h.status IN ('waiting', 'allocated') ORDER BY h.created_at, h.id`
+	SelectNextWaitingSQL = `SELECT id FROM movie_holds WHERE movie_id = $1 AND status = 'waiting' ORDER BY created_at, id LIMIT 1 FOR UPDATE`
+	ReserveCopySQL       = `UPDATE movie_copies SET status = 'reserved', updated_at = now() WHERE id = (SELECT id FROM movie_copies WHERE movie_id = $1 AND status = 'available' ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING id`
+	UpdateHoldAllocated  = `UPDATE movie_holds SET status = 'allocated', copy_id = $1, allocated_at = $2, expires_at = $3 WHERE id = $4`
+	ExpireHoldsSQL       = `WITH expired AS (UPDATE movie_holds SET status = 'expired' WHERE status = 'allocated' AND expires_at < $1 RETURNING movie_id, copy_id), ` +
+		`released AS (UPDATE movie_copies SET status = 'available', updated_at = now() WHERE id IN (SELECT copy_id FROM expired) AND status = 'reserved') ` +
+		`SELECT movie_id FROM expired UNION ` +
+		`SELECT DISTINCT h.movie_id FROM movie_holds h WHERE h.status = 'waiting' AND EXISTS (SELECT 1 FROM movie_copies c WHERE c.movie_id = h.movie_id AND c.status = 'available')`
+)
+
+type HoldRepository interface {
+	AddHold(hold model.Hold) (int, error)
+	GetHold(holdId int) (model.Hold, error)
+	GetUserHolds(userId int) ([]model.Hold, error)
+	AllocateNext(movieId int, allocatedAt time.Time, expiresAt time.Time) (bool, error)
+	ExpireAllocations(now time.Time) ([]int, error)
+}
+
+type holdRepo struct {
+	db *sqlx.DB
+}
+
+func NewHoldRepository(db *sqlx.DB) HoldRepository {
+	return &holdRepo{db: db}
+}
+
+func (m holdRepo) AddHold(hold model.Hold) (int, error) {
+	var id int
+	err := m.db.QueryRow(InsertHoldSQL, hold.MovieId, hold.UserId, hold.Status, hold.CreatedAt).Scan(&id)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrDuplicateHold
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert hold: %w", err)
+	}
+	fmt.Println("Successfully inserted hold. Id:", id)
+	return id, nil
+}
+
+func (m holdRepo) GetHold(holdId int) (model.Hold, error) {
+	hold, err := scanHold(m.db.QueryRow(SelectHoldByIdSQL, holdId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Hold{}, model.ErrHoldNotFound
+	}
+	if err != nil {
+		return model.Hold{}, fmt.Errorf("failed to fetch hold: %w", err)
+	}
+	return hold, nil
+}
+
+func (m holdRepo) GetUserHolds(userId int) ([]model.Hold, error) {
+	rows, err := m.db.Query(SelectUserHoldsSQL, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []model.Hold{}
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan hold: %w", err)
+		}
+		holds = append(holds, hold)
+	}
+	return holds, rows.Err()
+}
+
+// AllocateNext hands a shelf copy of the movie to the head of its waitlist.
+// It reports false when nobody is waiting or no copy is on the shelf. The head
+// is locked without skipping, so a concurrent allocation waits for the first
+// in line instead of jumping to the second.
+func (m holdRepo) AllocateNext(movieId int, allocatedAt time.Time, expiresAt time.Time) (bool, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin allocation: %w", err)
+	}
+	defer tx.Rollback()
+
+	var holdId int
+	err = tx.QueryRow(SelectNextWaitingSQL, movieId).Scan(&holdId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find waiting hold: %w", err)
+	}
+
+	var copyId int
+	err = tx.QueryRow(ReserveCopySQL, movieId).Scan(&copyId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve copy: %w", err)
+	}
+
+	if _, err = tx.Exec(UpdateHoldAllocated, copyId, allocatedAt, expiresAt, holdId); err != nil {
+		return false, fmt.Errorf("failed to allocate hold: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit allocation: %w", err)
+	}
+	fmt.Println("Successfully allocated copy to hold. Id:", holdId)
+	return true, nil
+}
+
+// ExpireAllocations releases the copies of holds that were not picked up in
+// time and returns the movies whose queues should move on, including queues
+// that have a copy back on the shelf but missed their allocation.
+func (m holdRepo) ExpireAllocations(now time.Time) ([]int, error) {
+	rows, err := m.db.Query(ExpireHoldsSQL, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire holds: %w", err)
+	}
+	defer rows.Close()
+
+	var movieIds []int
+	for rows.Next() {
+		var movieId int
+		if err := rows.Scan(&movieId); err != nil {
+			return nil, fmt.Errorf("failed to scan expired hold: %w", err)
+		}
+		movieIds = append(movieIds, movieId)
+	}
+	return movieIds, rows.Err()
+}
+
+func scanHold(row postgres.Scanner) (model.Hold, error) {
+	var h model.Hold
+	err := row.Scan(&h.Id, &h.MovieId, &h.UserId, &h.Status, &h.Position, &h.CopyId, &h.CreatedAt, &h.AllocatedAt, &h.ExpiresAt)
+	return h, err
+}
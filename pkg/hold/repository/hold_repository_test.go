@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type HoldRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository HoldRepository
+}
+
+func TestHoldRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldRepositoryTestSuite))
+}
+
+func (suite *HoldRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewHoldRepository(suite.mockedDB)
+}
+
+func (suite *HoldRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *HoldRepositoryTestSuite) Test_AllocateNext_ShouldReportFalseWhenNobodyWaiting() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(SelectNextWaitingSQL).WithArgs(4563).WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	allocated, err := suite.testRepository.AllocateNext(4563, now, now.Add(time.Hour))
+
+	suite.Nil(err)
+	suite.False(allocated)
+}
+
+func (suite *HoldRepositoryTestSuite) Test_AllocateNext_ShouldReportFalseWhenShelfEmpty() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(SelectNextWaitingSQL).WithArgs(4563).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(ReserveCopySQL).WithArgs(4563).WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	allocated, err := suite.testRepository.AllocateNext(4563, now, now.Add(time.Hour))
+
+	suite.Nil(err)
+	suite.False(allocated)
+}
+
+func (suite *HoldRepositoryTestSuite) Test_AllocateNext_ShouldAllocateCopyToHeadOfQueue() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(SelectNextWaitingSQL).WithArgs(4563).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(ReserveCopySQL).WithArgs(4563).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+	suite.mockDB.ExpectExec(UpdateHoldAllocated).WithArgs(30, now, now.Add(time.Hour), 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	allocated, err := suite.testRepository.AllocateNext(4563, now, now.Add(time.Hour))
+
+	suite.Nil(err)
+	suite.True(allocated)
+}
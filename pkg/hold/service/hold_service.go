@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"movie-rent/constants"
+	cartModel "movie-rent/pkg/cart/model"
+	cartService "movie-rent/pkg/cart/service"
+	"movie-rent/pkg/hold/model"
+	"movie-rent/pkg/hold/repository"
+	movieRepository "movie-rent/pkg/movie/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/hold/service/hold_service.go -destination=pkg/hold/mocks/hold_service_mock.go -package=mocks
+
+type HoldService interface {
+	PlaceHold(movieId int, userId int) (model.Hold, error)
+	GetUserHolds(userId int) ([]model.Hold, error)
+	AllocateNext(movieId int) error
+	ExpireAllocations() error
+	ConvertToCart(holdId int, userId int, rentalDays int) (int, error)
+}
+
+type holdService struct {
+	repository      repository.HoldRepository
+	movieRepository movieRepository.MovieRepository
+	cartService     cartService.CartService
+}
+
+func NewHoldService(repository repository.HoldRepository, movieRepository movieRepository.MovieRepository,
+	cartService cartService.CartService) HoldService {
+	return holdService{repository: repository, movieRepository: movieRepository, cartService: cartService}
+}
+
+func (m holdService) PlaceHold(movieId int, userId int) (model.Hold, error) {
+	movie, err := m.movieRepository.GetMovieBy(movieId)
+	if err != nil {
+		fmt.Println("failed to find movie for hold:", err.Error())
+		return model.Hold{}, err
+	}
+	if movie.AvailableCopies > 0 {
+		return model.Hold{}, model.ErrCopiesAvailable
+	}
+
+	id, err := m.repository.AddHold(model.Hold{
+		MovieId:   movieId,
+		UserId:    userId,
+		Status:    model.StatusWaiting,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Println("failed to place hold:", err.Error())
+		return model.Hold{}, err
+	}
+	return m.repository.GetHold(id)
+}
+
+func (m holdService) GetUserHolds(userId int) ([]model.Hold, error) {
+	holds, err := m.repository.GetUserHolds(userId)
+	if err != nil {
+		fmt.Println("failed to find holds:", err.Error())
+		return []model.Hold{}, err
+	}
+	return holds, nil
+}
+
+// AllocateNext keeps handing shelf copies to the front of the queue until
+// either runs out.
+func (m holdService) AllocateNext(movieId int) error {
+	for {
+		now := time.Now()
+		allocated, err := m.repository.AllocateNext(movieId, now, now.Add(constants.HoldPickupWindow))
+		if err != nil {
+			fmt.Println("failed to allocate hold:", err.Error())
+			return err
+		}
+		if !allocated {
+			return nil
+		}
+		fmt.Println("allocated copy to waitlist for movie", movieId)
+	}
+}
+
+// ExpireAllocations is run periodically; every queue that loses an allocation
+// is offered to the next person in line.
+func (m holdService) ExpireAllocations() error {
+	movieIds, err := m.repository.ExpireAllocations(time.Now())
+	if err != nil {
+		fmt.Println("failed to expire holds:", err.Error())
+		return err
+	}
+	for _, movieId := range movieIds {
+		if err = m.AllocateNext(movieId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertToCart adds the held copy to the user's cart through the cart
+// service, so the line gets the same validation as any other cart addition.
+func (m holdService) ConvertToCart(holdId int, userId int, rentalDays int) (int, error) {
+	hold, err := m.repository.GetHold(holdId)
+	if err != nil {
+		fmt.Println("failed to find hold:", err.Error())
+		return 0, err
+	}
+	if hold.UserId != userId {
+		return 0, model.ErrHoldNotFound
+	}
+	if hold.Status != model.StatusAllocated {
+		return 0, model.ErrHoldNotAllocated
+	}
+	if hold.ExpiresAt != nil && hold.ExpiresAt.Before(time.Now()) {
+		return 0, model.ErrHoldExpired
+	}
+
+	id, err := m.cartService.AddToCart(cartModel.CartRequest{
+		UserId:     userId,
+		MovieId:    hold.MovieId,
+		RentalDays: rentalDays,
+		HoldId:     holdId,
+	})
+	if err != nil {
+		fmt.Println("failed to convert hold to cart:", err.Error())
+		return 0, err
+	}
+	return id, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	cartMocks "movie-rent/pkg/cart/mocks"
+	cartModel "movie-rent/pkg/cart/model"
+	"movie-rent/pkg/hold/mocks"
+	"movie-rent/pkg/hold/model"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	"testing"
+	"time"
+)
+
+type HoldServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockHoldRepository
+	mockMovieRepo  *movieMocks.MockMovieRepository
+	mockCart       *cartMocks.MockCartService
+
+	holdService HoldService
+}
+
+func TestHoldServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldServiceTestSuite))
+}
+
+func (suite *HoldServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockHoldRepository(suite.mockController)
+	suite.mockMovieRepo = movieMocks.NewMockMovieRepository(suite.mockController)
+
+	suite.mockCart = cartMocks.NewMockCartService(suite.mockController)
+
+	suite.holdService = NewHoldService(suite.mockRepository, suite.mockMovieRepo, suite.mockCart)
+}
+
+func (suite *HoldServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *HoldServiceTestSuite) Test_PlaceHold_ShouldReturnErrorWhenCopiesAvailable() {
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(movieModel.Movie{Id: 4563, AvailableCopies: 2}, nil).Times(1)
+
+	_, err := suite.holdService.PlaceHold(4563, 1001)
+
+	suite.ErrorIs(err, model.ErrCopiesAvailable)
+}
+
+func (suite *HoldServiceTestSuite) Test_PlaceHold_ShouldReturnErrorWhenAlreadyQueued() {
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(movieModel.Movie{Id: 4563}, nil).Times(1)
+	suite.mockRepository.EXPECT().AddHold(gomock.Any()).Return(0, model.ErrDuplicateHold).Times(1)
+
+	_, err := suite.holdService.PlaceHold(4563, 1001)
+
+	suite.ErrorIs(err, model.ErrDuplicateHold)
+}
+
+func (suite *HoldServiceTestSuite) Test_PlaceHold_ShouldJoinWaitlist() {
+	expected := model.Hold{Id: 7, MovieId: 4563, UserId: 1001, Status: model.StatusWaiting, Position: 3}
+	suite.mockMovieRepo.EXPECT().GetMovieBy(4563).Return(movieModel.Movie{Id: 4563}, nil).Times(1)
+	suite.mockRepository.EXPECT().AddHold(gomock.Any()).DoAndReturn(func(hold model.Hold) (int, error) {
+		suite.Equal(model.StatusWaiting, hold.Status)
+		suite.Equal(1001, hold.UserId)
+		return 7, nil
+	}).Times(1)
+	suite.mockRepository.EXPECT().GetHold(7).Return(expected, nil).Times(1)
+
+	hold, err := suite.holdService.PlaceHold(4563, 1001)
+
+	suite.Nil(err)
+	suite.Equal(expected, hold)
+}
+
+func (suite *HoldServiceTestSuite) Test_AllocateNext_ShouldAllocateUntilQueueOrShelfRunsOut() {
+	gomock.InOrder(
+		suite.mockRepository.EXPECT().AllocateNext(4563, gomock.Any(), gomock.Any()).Return(true, nil),
+		suite.mockRepository.EXPECT().AllocateNext(4563, gomock.Any(), gomock.Any()).Return(false, nil),
+	)
+
+	err := suite.holdService.AllocateNext(4563)
+
+	suite.Nil(err)
+}
+
+func (suite *HoldServiceTestSuite) Test_ExpireAllocations_ShouldMoveAffectedQueuesOn() {
+	suite.mockRepository.EXPECT().ExpireAllocations(gomock.Any()).Return([]int{4563}, nil).Times(1)
+	suite.mockRepository.EXPECT().AllocateNext(4563, gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+
+	err := suite.holdService.ExpireAllocations()
+
+	suite.Nil(err)
+}
+
+func (suite *HoldServiceTestSuite) Test_ExpireAllocations_ShouldReturnErrorWhenExpiryFailed() {
+	suite.mockRepository.EXPECT().ExpireAllocations(gomock.Any()).Return(nil, fmt.Errorf("error")).Times(1)
+
+	err := suite.holdService.ExpireAllocations()
+
+	suite.NotNil(err)
+}
+
+func (suite *HoldServiceTestSuite) Test_ConvertToCart_ShouldHideOtherUsersHolds() {
+	suite.mockRepository.EXPECT().GetHold(7).Return(model.Hold{Id: 7, UserId: 2002, Status: model.StatusAllocated}, nil).Times(1)
+
+	_, err := suite.holdService.ConvertToCart(7, 1001, 7)
+
+	suite.ErrorIs(err, model.ErrHoldNotFound)
+}
+
+func (suite *HoldServiceTestSuite) Test_ConvertToCart_ShouldReturnErrorWhenStillWaiting() {
+	suite.mockRepository.EXPECT().GetHold(7).Return(model.Hold{Id: 7, UserId: 1001, Status: model.StatusWaiting}, nil).Times(1)
+
+	_, err := suite.holdService.ConvertToCart(7, 1001, 7)
+
+	suite.ErrorIs(err, model.ErrHoldNotAllocated)
+}
+
+func (suite *HoldServiceTestSuite) Test_ConvertToCart_ShouldReturnErrorWhenPickupWindowPassed() {
+	expiresAt := time.Now().Add(-time.Minute)
+	suite.mockRepository.EXPECT().GetHold(7).Return(model.Hold{Id: 7, UserId: 1001, Status: model.StatusAllocated, ExpiresAt: &expiresAt}, nil).Times(1)
+
+	_, err := suite.holdService.ConvertToCart(7, 1001, 7)
+
+	suite.ErrorIs(err, model.ErrHoldExpired)
+}
+
+func (suite *HoldServiceTestSuite) Test_ConvertToCart_ShouldMoveHeldCopyToCart() {
+	expiresAt := time.Now().Add(time.Hour)
+	suite.mockRepository.EXPECT().GetHold(7).Return(model.Hold{Id: 7, MovieId: 4563, UserId: 1001, Status: model.StatusAllocated, ExpiresAt: &expiresAt}, nil).Times(1)
+	suite.mockCart.EXPECT().AddToCart(cartModel.CartRequest{UserId: 1001, MovieId: 4563, RentalDays: 7, HoldId: 7}).Return(11, nil).Times(1)
+
+	id, err := suite.holdService.ConvertToCart(7, 1001, 7)
+
+	suite.Nil(err)
+	suite.Equal(11, id)
+}
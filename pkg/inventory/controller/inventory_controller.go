@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/inventory/model"
+	"movie-rent/pkg/inventory/service"
+	movieModel "movie-rent/pkg/movie/model"
+	"net/http"
+	"strconv"
+)
+
+type InventoryController struct {
+	service service.InventoryService
+}
+
+func NewInventoryController(service service.InventoryService) InventoryController {
+	return InventoryController{service: service}
+}
+
+func (m *InventoryController) AddCopy(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.CopyRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	copy, err := m.service.AddCopy(movieId, request)
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateBarcode) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, copy)
+}
+
+func (m *InventoryController) GetCopies(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	copies, err := m.service.GetCopies(movieId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, copies)
+}
+
+func (m *InventoryController) RetireCopy(ctx *gin.Context) {
+	copyId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.RetireRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	copy, err := m.service.RetireCopy(copyId, request)
+	if errors.Is(err, model.ErrCopyNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrCopyInUse) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, copy)
+}
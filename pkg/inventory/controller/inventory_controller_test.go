@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/inventory/mocks"
+	"movie-rent/pkg/inventory/model"
+	movieModel "movie-rent/pkg/movie/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type InventoryControllerTestSuite struct {
+	suite.Suite
+	context              *gin.Context
+	recorder             *httptest.ResponseRecorder
+	mockController       *gomock.Controller
+	mockInventoryService *mocks.MockInventoryService
+	testController       InventoryController
+}
+
+func TestInventoryControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(InventoryControllerTestSuite))
+}
+
+func (suite *InventoryControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockInventoryService = mocks.NewMockInventoryService(suite.mockController)
+	suite.testController = NewInventoryController(suite.mockInventoryService)
+}
+
+func (suite *InventoryControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *InventoryControllerTestSuite) Test_AddCopy_ShouldReturnBadRequestWhenConditionUnknown() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/1/copies", strings.NewReader(`{"barcode":"B-1","condition":"mint"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	suite.testController.AddCopy(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_AddCopy_ShouldReturnNotFoundWhenMovieMissing() {
+	request := model.CopyRequest{Barcode: "B-1", Condition: "new"}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/1/copies", strings.NewReader(`{"barcode":"B-1","condition":"new"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockInventoryService.EXPECT().AddCopy(1, request).Return(model.Copy{}, movieModel.ErrMovieNotFound).Times(1)
+
+	suite.testController.AddCopy(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_AddCopy_ShouldReturnConflictWhenBarcodeExists() {
+	request := model.CopyRequest{Barcode: "B-1", Condition: "new"}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/1/copies", strings.NewReader(`{"barcode":"B-1","condition":"new"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockInventoryService.EXPECT().AddCopy(1, request).Return(model.Copy{}, model.ErrDuplicateBarcode).Times(1)
+
+	suite.testController.AddCopy(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_AddCopy_ShouldCreateCopy() {
+	request := model.CopyRequest{Barcode: "B-1", Condition: "new"}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/1/copies", strings.NewReader(`{"barcode":"B-1","condition":"new"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockInventoryService.EXPECT().AddCopy(1, request).Return(model.Copy{Id: 5, MovieId: 1}, nil).Times(1)
+
+	suite.testController.AddCopy(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_GetCopies_ShouldReturnCopies() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/1/copies", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockInventoryService.EXPECT().GetCopies(1).Return([]model.Copy{{Id: 5}}, nil).Times(1)
+
+	suite.testController.GetCopies(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_RetireCopy_ShouldReturnConflictWhenCopyInUse() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/copies/5/retire", strings.NewReader(`{"status":"lost"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "5"}}
+	suite.mockInventoryService.EXPECT().RetireCopy(5, model.RetireRequest{Status: "lost"}).Return(model.Copy{}, model.ErrCopyInUse).Times(1)
+
+	suite.testController.RetireCopy(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *InventoryControllerTestSuite) Test_RetireCopy_ShouldReturnBadRequestWhenStatusNotRetired() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/copies/5/retire", strings.NewReader(`{"status":"available"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "5"}}
+
+	suite.testController.RetireCopy(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
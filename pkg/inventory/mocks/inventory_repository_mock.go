@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/inventory/repository/inventory_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/inventory/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCopy mocks base method.
+func (m *MockInventoryRepository) AddCopy(copy model.Copy) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCopy", copy)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCopy indicates an expected call of AddCopy.
+func (mr *MockInventoryRepositoryMockRecorder) AddCopy(copy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCopy", reflect.TypeOf((*MockInventoryRepository)(nil).AddCopy), copy)
+}
+
+// GetCopies mocks base method.
+func (m *MockInventoryRepository) GetCopies(movieId int) ([]model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopies", movieId)
+	ret0, _ := ret[0].([]model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopies indicates an expected call of GetCopies.
+func (mr *MockInventoryRepositoryMockRecorder) GetCopies(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopies", reflect.TypeOf((*MockInventoryRepository)(nil).GetCopies), movieId)
+}
+
+// GetCopy mocks base method.
+func (m *MockInventoryRepository) GetCopy(copyId int) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopy", copyId)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopy indicates an expected call of GetCopy.
+func (mr *MockInventoryRepositoryMockRecorder) GetCopy(copyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopy", reflect.TypeOf((*MockInventoryRepository)(nil).GetCopy), copyId)
+}
+
+// RetireCopy mocks base method.
+func (m *MockInventoryRepository) RetireCopy(copyId int, status string) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireCopy", copyId, status)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetireCopy indicates an expected call of RetireCopy.
+func (mr *MockInventoryRepositoryMockRecorder) RetireCopy(copyId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireCopy", reflect.TypeOf((*MockInventoryRepository)(nil).RetireCopy), copyId, status)
+}
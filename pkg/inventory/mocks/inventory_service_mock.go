@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/inventory/service/inventory_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/inventory/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInventoryService is a mock of InventoryService interface.
+type MockInventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceMockRecorder
+}
+
+// MockInventoryServiceMockRecorder is the mock recorder for MockInventoryService.
+type MockInventoryServiceMockRecorder struct {
+	mock *MockInventoryService
+}
+
+// NewMockInventoryService creates a new mock instance.
+func NewMockInventoryService(ctrl *gomock.Controller) *MockInventoryService {
+	mock := &MockInventoryService{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryService) EXPECT() *MockInventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// AddCopy mocks base method.
+func (m *MockInventoryService) AddCopy(movieId int, request model.CopyRequest) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCopy", movieId, request)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCopy indicates an expected call of AddCopy.
+func (mr *MockInventoryServiceMockRecorder) AddCopy(movieId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCopy", reflect.TypeOf((*MockInventoryService)(nil).AddCopy), movieId, request)
+}
+
+// GetCopies mocks base method.
+func (m *MockInventoryService) GetCopies(movieId int) ([]model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopies", movieId)
+	ret0, _ := ret[0].([]model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopies indicates an expected call of GetCopies.
+func (mr *MockInventoryServiceMockRecorder) GetCopies(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopies", reflect.TypeOf((*MockInventoryService)(nil).GetCopies), movieId)
+}
+
+// RetireCopy mocks base method.
+func (m *MockInventoryService) RetireCopy(copyId int, request model.RetireRequest) (model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireCopy", copyId, request)
+	ret0, _ := ret[0].(model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetireCopy indicates an expected call of RetireCopy.
+func (mr *MockInventoryServiceMockRecorder) RetireCopy(copyId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireCopy", reflect.TypeOf((*MockInventoryService)(nil).RetireCopy), copyId, request)
+}
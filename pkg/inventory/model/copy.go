@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	StatusAvailable = "available"
+	StatusReserved  = "reserved"
+	StatusRented    = "rented"
+	StatusLost      = "lost"
+	StatusDamaged   = "damaged"
+)
+
+type Copy struct {
+	Id        int       `json:"id"`
+	MovieId   int       `json:"movieId"`
+	Barcode   string    `json:"barcode"`
+	Condition string    `json:"condition"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CopyRequest struct {
+	Barcode   string `json:"barcode"  binding:"required"`
+	Condition string `json:"condition"  binding:"required,oneof=new good fair poor"`
+}
+
+type RetireRequest struct {
+	Status string `json:"status"  binding:"required,oneof=lost damaged"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "errors"
+
+var (
+	ErrCopyNotFound     = errors.New("copy not found")
+	ErrDuplicateBarcode = errors.New("barcode is already registered")
+	ErrCopyInUse        = errors.New("copy is reserved or rented")
+	ErrOutOfStock       = errors.New("no copies available")
+)
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/inventory/model"
+)
+
+const (
+	InsertCopySQL        = `INSERT INTO movie_copies(movie_id, barcode, condition, status) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
+	SelectCopiesSQL      = `SELECT id, movie_id, barcode, condition, status, created_at, updated_at FROM movie_copies WHERE movie_id = $1 ORDER BY id`
+	SelectCopyByIdSQL    = `SELECT id, movie_id, barcode, condition, status, created_at, updated_at FROM movie_copies WHERE id = $1`
+	UpdateCopyRetiredSQL = `UPDATE movie_copies SET status = $1, updated_at = now() WHERE id = $2 AND status IN ($3, $4) RETURNING id, movie_id, barcode, condition, status, created_at, updated_at`
+)
+
+type InventoryRepository interface {
+	AddCopy(copy model.Copy) (model.Copy, error)
+	GetCopies(movieId int) ([]model.Copy, error)
+	GetCopy(copyId int) (model.Copy, error)
+	RetireCopy(copyId int, status string) (model.Copy, error)
+}
+
+type inventoryRepo struct {
+	db *sqlx.DB
+}
+
+func NewInventoryRepository(db *sqlx.DB) InventoryRepository {
+	return &inventoryRepo{db: db}
+}
+
+func (m inventoryRepo) AddCopy(copy model.Copy) (model.Copy, error) {
+	err := m.db.QueryRow(InsertCopySQL, copy.MovieId, copy.Barcode, copy.Condition, copy.Status).
+		Scan(&copy.Id, &copy.CreatedAt, &copy.UpdatedAt)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return model.Copy{}, model.ErrDuplicateBarcode
+	}
+	if err != nil {
+		return model.Copy{}, fmt.Errorf("failed to insert copy: %w", err)
+	}
+	fmt.Println("Successfully inserted copy. Id:", copy.Id)
+	return copy, nil
+}
+
+func (m inventoryRepo) GetCopies(movieId int) ([]model.Copy, error) {
+	rows, err := m.db.Query(SelectCopiesSQL, movieId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch copies: %w", err)
+	}
+	defer rows.Close()
+
+	copies := []model.Copy{}
+	for rows.Next() {
+		copy, err := scanCopy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan copy: %w", err)
+		}
+		copies = append(copies, copy)
+	}
+	return copies, rows.Err()
+}
+
+func (m inventoryRepo) GetCopy(copyId int) (model.Copy, error) {
+	copy, err := scanCopy(m.db.QueryRow(SelectCopyByIdSQL, copyId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Copy{}, model.ErrCopyNotFound
+	}
+	if err != nil {
+		return model.Copy{}, fmt.Errorf("failed to fetch copy: %w", err)
+	}
+	return copy, nil
+}
+
+// RetireCopy only retires copies that are on the shelf or already pulled as
+// damaged, so a copy that gets reserved concurrently is reported as in use
+// instead of being pulled from under a cart or rental.
+func (m inventoryRepo) RetireCopy(copyId int, status string) (model.Copy, error) {
+	copy, err := scanCopy(m.db.QueryRow(UpdateCopyRetiredSQL, status, copyId, model.StatusAvailable, model.StatusDamaged))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Copy{}, model.ErrCopyInUse
+	}
+	if err != nil {
+		return model.Copy{}, fmt.Errorf("failed to retire copy: %w", err)
+	}
+	return copy, nil
+}
+
+func scanCopy(row postgres.Scanner) (model.Copy, error) {
+	var c model.Copy
+	err := row.Scan(&c.Id, &c.MovieId, &c.Barcode, &c.Condition, &c.Status, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/inventory/model"
+	"testing"
+	"time"
+)
+
+type InventoryRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository InventoryRepository
+}
+
+func TestInventoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(InventoryRepositoryTestSuite))
+}
+
+func (suite *InventoryRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewInventoryRepository(suite.mockedDB)
+}
+
+func (suite *InventoryRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *InventoryRepositoryTestSuite) Test_AddCopy_ShouldReturnErrorWhenBarcodeExists() {
+	suite.mockDB.ExpectQuery(InsertCopySQL).WithArgs(1, "B-1", "new", model.StatusAvailable).
+		WillReturnError(&pq.Error{Code: postgres.UniqueViolation})
+
+	_, err := suite.testRepository.AddCopy(model.Copy{MovieId: 1, Barcode: "B-1", Condition: "new", Status: model.StatusAvailable})
+
+	suite.ErrorIs(err, model.ErrDuplicateBarcode)
+}
+
+func (suite *InventoryRepositoryTestSuite) Test_AddCopy_ShouldInsertCopy() {
+	now := time.Now()
+	suite.mockDB.ExpectQuery(InsertCopySQL).WithArgs(1, "B-1", "new", model.StatusAvailable).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(5, now, now))
+
+	copy, err := suite.testRepository.AddCopy(model.Copy{MovieId: 1, Barcode: "B-1", Condition: "new", Status: model.StatusAvailable})
+
+	suite.Nil(err)
+	suite.Equal(5, copy.Id)
+}
+
+func (suite *InventoryRepositoryTestSuite) Test_RetireCopy_ShouldReturnErrorWhenCopyLeftTheShelf() {
+	suite.mockDB.ExpectQuery(UpdateCopyRetiredSQL).WithArgs(model.StatusLost, 5, model.StatusAvailable, model.StatusDamaged).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.RetireCopy(5, model.StatusLost)
+
+	suite.ErrorIs(err, model.ErrCopyInUse)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+	"movie-rent/pkg/inventory/model"
+	"movie-rent/pkg/inventory/repository"
+	movieRepository "movie-rent/pkg/movie/repository"
+)
+
+// go:generate mockgen -source=pkg/inventory/service/inventory_service.go -destination=pkg/inventory/mocks/inventory_service_mock.go -package=mocks
+
+type InventoryService interface {
+	AddCopy(movieId int, request model.CopyRequest) (model.Copy, error)
+	GetCopies(movieId int) ([]model.Copy, error)
+	RetireCopy(copyId int, request model.RetireRequest) (model.Copy, error)
+}
+
+type inventoryService struct {
+	repository      repository.InventoryRepository
+	movieRepository movieRepository.MovieRepository
+}
+
+func NewInventoryService(repository repository.InventoryRepository, movieRepository movieRepository.MovieRepository) InventoryService {
+	return inventoryService{repository: repository, movieRepository: movieRepository}
+}
+
+func (m inventoryService) AddCopy(movieId int, request model.CopyRequest) (model.Copy, error) {
+	if _, err := m.movieRepository.GetMovieBy(movieId); err != nil {
+		fmt.Println("failed to find movie for copy:", err.Error())
+		return model.Copy{}, err
+	}
+
+	copy, err := m.repository.AddCopy(model.Copy{
+		MovieId:   movieId,
+		Barcode:   request.Barcode,
+		Condition: request.Condition,
+		Status:    model.StatusAvailable,
+	})
+	if err != nil {
+		fmt.Println("failed to add copy:", err.Error())
+		return model.Copy{}, err
+	}
+	return copy, nil
+}
+
+func (m inventoryService) GetCopies(movieId int) ([]model.Copy, error) {
+	copies, err := m.repository.GetCopies(movieId)
+	if err != nil {
+		fmt.Println("failed to find copies:", err.Error())
+		return []model.Copy{}, err
+	}
+	return copies, nil
+}
+
+func (m inventoryService) RetireCopy(copyId int, request model.RetireRequest) (model.Copy, error) {
+	copy, err := m.repository.GetCopy(copyId)
+	if err != nil {
+		fmt.Println("failed to find copy:", err.Error())
+		return model.Copy{}, err
+	}
+	if copy.Status == request.Status {
+		return copy, nil
+	}
+	if copy.Status != model.StatusAvailable && copy.Status != model.StatusDamaged {
+		return model.Copy{}, model.ErrCopyInUse
+	}
+
+	copy, err = m.repository.RetireCopy(copyId, request.Status)
+	if err != nil {
+		fmt.Println("failed to retire copy:", err.Error())
+		return model.Copy{}, err
+	}
+	return copy, nil
+}
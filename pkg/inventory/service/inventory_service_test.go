@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/inventory/mocks"
+	"movie-rent/pkg/inventory/model"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	"testing"
+)
+
+type InventoryServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockInventoryRepository
+	mockMovieRepo  *movieMocks.MockMovieRepository
+
+	inventoryService InventoryService
+}
+
+func TestInventoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(InventoryServiceTestSuite))
+}
+
+func (suite *InventoryServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockInventoryRepository(suite.mockController)
+	suite.mockMovieRepo = movieMocks.NewMockMovieRepository(suite.mockController)
+
+	suite.inventoryService = NewInventoryService(suite.mockRepository, suite.mockMovieRepo)
+}
+
+func (suite *InventoryServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *InventoryServiceTestSuite) Test_AddCopy_ShouldReturnErrorWhenMovieNotFound() {
+	suite.mockMovieRepo.EXPECT().GetMovieBy(1).Return(movieModel.Movie{}, movieModel.ErrMovieNotFound).Times(1)
+
+	_, err := suite.inventoryService.AddCopy(1, model.CopyRequest{Barcode: "B-1", Condition: "new"})
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *InventoryServiceTestSuite) Test_AddCopy_ShouldStoreAvailableCopy() {
+	expected := model.Copy{Id: 5, MovieId: 1, Barcode: "B-1", Condition: "new", Status: model.StatusAvailable}
+	suite.mockMovieRepo.EXPECT().GetMovieBy(1).Return(movieModel.Movie{Id: 1}, nil).Times(1)
+	suite.mockRepository.EXPECT().AddCopy(model.Copy{MovieId: 1, Barcode: "B-1", Condition: "new", Status: model.StatusAvailable}).
+		Return(expected, nil).Times(1)
+
+	copy, err := suite.inventoryService.AddCopy(1, model.CopyRequest{Barcode: "B-1", Condition: "new"})
+
+	suite.Nil(err)
+	suite.Equal(expected, copy)
+}
+
+func (suite *InventoryServiceTestSuite) Test_GetCopies_ShouldReturnErrorWhenRepositoryFailed() {
+	suite.mockRepository.EXPECT().GetCopies(1).Return(nil, fmt.Errorf("error")).Times(1)
+
+	copies, err := suite.inventoryService.GetCopies(1)
+
+	suite.NotNil(err)
+	suite.Empty(copies)
+}
+
+func (suite *InventoryServiceTestSuite) Test_RetireCopy_ShouldReturnErrorWhenCopyRented() {
+	suite.mockRepository.EXPECT().GetCopy(5).Return(model.Copy{Id: 5, Status: model.StatusRented}, nil).Times(1)
+
+	_, err := suite.inventoryService.RetireCopy(5, model.RetireRequest{Status: model.StatusLost})
+
+	suite.ErrorIs(err, model.ErrCopyInUse)
+}
+
+func (suite *InventoryServiceTestSuite) Test_RetireCopy_ShouldRetireAvailableCopy() {
+	retired := model.Copy{Id: 5, Status: model.StatusDamaged}
+	suite.mockRepository.EXPECT().GetCopy(5).Return(model.Copy{Id: 5, Status: model.StatusAvailable}, nil).Times(1)
+	suite.mockRepository.EXPECT().RetireCopy(5, model.StatusDamaged).Return(retired, nil).Times(1)
+
+	copy, err := suite.inventoryService.RetireCopy(5, model.RetireRequest{Status: model.StatusDamaged})
+
+	suite.Nil(err)
+	suite.Equal(retired, copy)
+}
+
+func (suite *InventoryServiceTestSuite) Test_RetireCopy_ShouldMarkDamagedCopyLost() {
+	lost := model.Copy{Id: 5, Status: model.StatusLost}
+	suite.mockRepository.EXPECT().GetCopy(5).Return(model.Copy{Id: 5, Status: model.StatusDamaged}, nil).Times(1)
+	suite.mockRepository.EXPECT().RetireCopy(5, model.StatusLost).Return(lost, nil).Times(1)
+
+	copy, err := suite.inventoryService.RetireCopy(5, model.RetireRequest{Status: model.StatusLost})
+
+	suite.Nil(err)
+	suite.Equal(lost, copy)
+}
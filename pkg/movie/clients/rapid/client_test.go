@@ -5,6 +5,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 	"io/ioutil"
+	personModel "movie-rent/pkg/person/model"
 	"net/http"
 	"testing"
 )
@@ -61,6 +62,26 @@ func (suite *RapidClientTestSuite) TestFetchAllMovies_Success() {
 	suite.Len(movies, 2)
 }
 
+func (suite *RapidClientTestSuite) TestFetchAllMovies_DecodesCredits() {
+	jsonResponse := `[{"id":1,"title":"Forrest Gump","credits":[` +
+		`{"name":"Tom Hanks","imdbCode":"nm0000158","role":"actor","character":"Forrest Gump","billingOrder":0}]}]`
+
+	mockClient := newTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+
+	movies, err := NewRapidClient(mockClient).FetchAllMovies()
+
+	suite.Nil(err)
+	suite.Equal([]personModel.Credit{
+		{Name: "Tom Hanks", ImdbCode: "nm0000158", Role: personModel.RoleActor, Character: "Forrest Gump"},
+	}, movies[0].Credits)
+}
+
 func (suite *RapidClientTestSuite) TestFetchAllMovies_HTTPError() {
 	mockClient := newTestClient(func(req *http.Request) *http.Response {
 		return &http.Response{
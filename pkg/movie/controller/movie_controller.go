@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/movie/model"
 	"movie-rent/pkg/movie/service"
 	"net/http"
 	"strconv"
@@ -19,21 +21,24 @@ func NewMovieController(service service.MovieService) MovieController {
 func (m *MovieController) AddMovie(ctx *gin.Context) {
 	err := m.service.AddMovie()
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, nil)
 }
 
+// GetMovies lists movies a page at a time. It takes an optional filter
+// expression, sort, limit, cursor and a sparse list of fields.
 func (m *MovieController) GetMovies(ctx *gin.Context) {
 	fmt.Println("Fetching all movies")
-	movies, err := m.service.GetMovies()
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err)
+	fields, page, ok := pageParams(ctx)
+	if !ok {
 		return
 	}
-	ctx.JSON(http.StatusOK, movies)
+
+	result, err := m.service.FindMovies(ctx.Query("filter"), page)
+	respondWithPage(ctx, result, fields, err)
 }
 
 func (m *MovieController) GetFilteredMovies(ctx *gin.Context) {
@@ -41,15 +46,117 @@ func (m *MovieController) GetFilteredMovies(ctx *gin.Context) {
 	searchType := ctx.Query("searchType")
 	searchText := ctx.Query("searchText")
 	if searchType == "" || searchText == "" {
-		ctx.JSON(http.StatusBadRequest, "searchType or searchText is empty")
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, "searchType or searchText is empty")
+		return
+	}
+	fields, page, ok := pageParams(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := m.service.GetFilteredMovies(searchType, searchText, page)
+	respondWithPage(ctx, result, fields, err)
+}
+
+func pageParams(ctx *gin.Context) ([]string, model.PageRequest, bool) {
+	fields, err := model.ParseFields(ctx.Query("fields"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, err.Error())
+		return nil, model.PageRequest{}, false
+	}
+	page := model.PageRequest{Sort: ctx.Query("sort"), Cursor: ctx.Query("cursor")}
+	if limit := ctx.Query("limit"); limit != "" {
+		if page.Limit, err = strconv.Atoi(limit); err != nil || page.Limit < 1 {
+			respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, "limit must be a positive number")
+			return nil, model.PageRequest{}, false
+		}
+	}
+	return fields, page, true
+}
+
+func respondWithPage(ctx *gin.Context, result model.MoviePage, fields []string, err error) {
+	if errors.Is(err, model.ErrInvalidFilter) {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidFilter, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrInvalidQuery) {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.PageResponse{
+		Items:  model.Project(result.Movies, fields),
+		Total:  result.Total,
+		Limit:  result.Limit,
+		Next:   pageLink(ctx, result.NextCursor),
+		Prev:   pageLink(ctx, result.PrevCursor),
+		Facets: result.Facets,
+	})
+}
+
+// pageLink is the current request with its cursor swapped for the given one.
+func pageLink(ctx *gin.Context, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	query := ctx.Request.URL.Query()
+	query.Set("cursor", cursor)
+	return ctx.Request.URL.Path + "?" + query.Encode()
+}
+
+func (m *MovieController) SearchMovies(ctx *gin.Context) {
+	limit, ok := limitParam(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := m.service.SearchMovies(ctx.Query("q"), limit)
+	if errors.Is(err, model.ErrInvalidQuery) {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, err.Error())
 		return
 	}
-	
-	movies, err := m.service.GetFilteredMovies(searchType, searchText)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
+		return
 	}
-	ctx.JSON(http.StatusOK, movies)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (m *MovieController) SuggestTitles(ctx *gin.Context) {
+	limit, ok := limitParam(ctx)
+	if !ok {
+		return
+	}
+
+	suggestions, err := m.service.SuggestTitles(ctx.Query("prefix"), limit)
+	if errors.Is(err, model.ErrInvalidQuery) {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// limitParam reads an optional positive limit; 0 means the caller did not
+// give one. It writes the 400 itself when the value is bad.
+func limitParam(ctx *gin.Context) (int, bool) {
+	text := ctx.Query("limit")
+	if text == "" {
+		return 0, true
+	}
+	limit, err := strconv.Atoi(text)
+	if err != nil || limit < 1 {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidQuery, "limit must be a positive number")
+		return 0, false
+	}
+	return limit, true
 }
 
 func (m *MovieController) GetMovieBy(ctx *gin.Context) {
@@ -57,14 +164,107 @@ func (m *MovieController) GetMovieBy(ctx *gin.Context) {
 	id := ctx.Param("id")
 	movieId, err := strconv.Atoi(id)
 	if id == "" || err != nil {
-		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidId, "Invalid id")
 		return
 	}
 
 	movie, serviceErr := m.service.GetMovieBy(movieId)
+	if errors.Is(serviceErr, model.ErrMovieNotFound) {
+		respondWithError(ctx, http.StatusNotFound, model.CodeMovieNotFound, serviceErr.Error())
+		return
+	}
 	if serviceErr != nil {
-		ctx.JSON(http.StatusInternalServerError, serviceErr)
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, serviceErr.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, movie)
+}
+
+func (m *MovieController) CreateMovie(ctx *gin.Context) {
+	var request model.MovieRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidRequest, bindErr.Error())
+		return
+	}
+
+	movie, err := m.service.CreateMovie(request)
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, movie)
+}
+
+func (m *MovieController) UpdateMovie(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidId, "Invalid id")
+		return
+	}
+	var request model.MovieRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidRequest, bindErr.Error())
+		return
+	}
+
+	movie, err := m.service.UpdateMovie(movieId, request)
+	m.respondWithMovie(ctx, movie, err)
+}
+
+func (m *MovieController) PatchMovie(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidId, "Invalid id")
+		return
+	}
+	var patch model.MoviePatch
+	bindErr := ctx.ShouldBindJSON(&patch)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidRequest, bindErr.Error())
+		return
+	}
+
+	movie, err := m.service.PatchMovie(movieId, patch)
+	m.respondWithMovie(ctx, movie, err)
+}
+
+func (m *MovieController) respondWithMovie(ctx *gin.Context, movie model.Movie, err error) {
+	if errors.Is(err, model.ErrMovieNotFound) {
+		respondWithError(ctx, http.StatusNotFound, model.CodeMovieNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
 		return
 	}
 	ctx.JSON(http.StatusOK, movie)
 }
+
+// respondWithError writes the body every failed movie request gets.
+func respondWithError(ctx *gin.Context, status int, code string, message string) {
+	ctx.JSON(status, model.ErrorResponse{Code: code, Message: message})
+}
+
+func (m *MovieController) DeleteMovie(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, model.CodeInvalidId, "Invalid id")
+		return
+	}
+
+	err = m.service.DeleteMovie(movieId)
+	if errors.Is(err, model.ErrMovieNotFound) {
+		respondWithError(ctx, http.StatusNotFound, model.CodeMovieNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, model.CodeInternalError, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -59,7 +60,7 @@ func (suite *MovieControllerTestSuite) Test_AddMovie_ShouldStoreAllMoviesToDB()
 
 func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnInternalServerErrorWhenServiceReturnError() {
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies", nil)
-	suite.mockMovieService.EXPECT().GetMovies().Return([]model.Movie{}, errors.New("error")).Times(1)
+	suite.mockMovieService.EXPECT().FindMovies("", model.PageRequest{}).Return(model.MoviePage{}, errors.New("error")).Times(1)
 
 	suite.testController.GetMovies(suite.context)
 
@@ -78,15 +79,72 @@ func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnAllMoviesSucce
 		},
 	}
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies", nil)
-	suite.mockMovieService.EXPECT().GetMovies().Return(movies, nil).Times(1)
+	suite.mockMovieService.EXPECT().FindMovies("", model.PageRequest{}).
+		Return(model.MoviePage{Movies: movies, Total: 1, Limit: 20}, nil).Times(1)
 
 	suite.testController.GetMovies(suite.context)
 
-	expectedMovies := `[{"id":1,"title":"Hero","releaseYear":1990,"genre":"Action","description":"Action movie","imdbCode":"1234"}]`
+	expectedMovies := `{"items":[{"id":1,"title":"Hero","releaseYear":1990,"genre":"Action","description":"Action movie","imdbCode":"1234","priceCents":0,"availableCopies":0,"averageRating":null,"ratingCount":0}],"total":1,"limit":20}`
 	suite.Equal(http.StatusOK, suite.recorder.Code)
 	suite.Equal(expectedMovies, suite.recorder.Body.String())
 }
 
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnSelectedFieldsAndLinks() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies?sort=-releaseYear&limit=1&fields=id,title&cursor=abc", nil)
+	page := model.PageRequest{Sort: "-releaseYear", Limit: 1, Cursor: "abc"}
+	suite.mockMovieService.EXPECT().FindMovies("", page).Return(model.MoviePage{
+		Movies:     []model.Movie{{Id: 1, Title: "Hero", Year: 2002}},
+		Total:      3,
+		Limit:      1,
+		NextCursor: "def",
+		PrevCursor: "xyz",
+	}, nil).Times(1)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"items":[{"id":1,"title":"Hero"}],"total":3,"limit":1,
+		"next":"/movies?cursor=def&fields=id%2Ctitle&limit=1&sort=-releaseYear",
+		"prev":"/movies?cursor=xyz&fields=id%2Ctitle&limit=1&sort=-releaseYear"}`, suite.recorder.Body.String())
+}
+
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnBadRequestForUnknownField() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies?fields=id,budget", nil)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"code":"invalid_query"`)
+}
+
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnBadRequestForInvalidLimit() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies?limit=ten", nil)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldFilterWhenFilterGiven() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, `/movies?filter=genre:horror+title~%22alien%22`, nil)
+	suite.mockMovieService.EXPECT().FindMovies(`genre:horror title~"alien"`, model.PageRequest{}).Return(model.MoviePage{}, nil).Times(1)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnBadRequestForInvalidFilter() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies?filter=budget>1", nil)
+	suite.mockMovieService.EXPECT().FindMovies("budget>1", model.PageRequest{}).
+		Return(model.MoviePage{}, fmt.Errorf("%w: unknown field \"budget\"", model.ErrInvalidFilter)).Times(1)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"code":"invalid_filter"`)
+}
+
 func (suite *MovieControllerTestSuite) Test_GetFilteredMovies_ShouldReturnBadRequestWhenSearchTypeIsEmpty() {
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/filter?searchType=&searchText=action", nil)
 
@@ -105,7 +163,8 @@ func (suite *MovieControllerTestSuite) Test_GetFilteredMovies_ShouldReturnBadReq
 
 func (suite *MovieControllerTestSuite) Test_GetFilteredMovies_ShouldReturnErrorServiceCallFailed() {
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/filter?searchType=genre&searchText=action", nil)
-	suite.mockMovieService.EXPECT().GetFilteredMovies("genre", "action").Return(nil, errors.New("error")).Times(1)
+	suite.mockMovieService.EXPECT().GetFilteredMovies("genre", "action", model.PageRequest{}).
+		Return(model.MoviePage{}, errors.New("error")).Times(1)
 
 	suite.testController.GetFilteredMovies(suite.context)
 
@@ -124,11 +183,12 @@ func (suite *MovieControllerTestSuite) Test_GetFilteredMovies_ShouldReturnFilter
 		},
 	}
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/filter?searchType=genre&searchText=action", nil)
-	suite.mockMovieService.EXPECT().GetFilteredMovies("genre", "action").Return(movies, nil).Times(1)
+	suite.mockMovieService.EXPECT().GetFilteredMovies("genre", "action", model.PageRequest{}).
+		Return(model.MoviePage{Movies: movies, Total: 1, Limit: 20}, nil).Times(1)
 
 	suite.testController.GetFilteredMovies(suite.context)
 
-	expectedMovies := `[{"id":1,"title":"Hero","releaseYear":1990,"genre":"Action","description":"Action movie","imdbCode":"1234"}]`
+	expectedMovies := `{"items":[{"id":1,"title":"Hero","releaseYear":1990,"genre":"Action","description":"Action movie","imdbCode":"1234","priceCents":0,"availableCopies":0,"averageRating":null,"ratingCount":0}],"total":1,"limit":20}`
 	suite.Equal(http.StatusOK, suite.recorder.Code)
 	suite.Equal(expectedMovies, suite.recorder.Body.String())
 }
@@ -164,22 +224,56 @@ func (suite *MovieControllerTestSuite) Test_GetMovieBy_ShouldReturnInternalServe
 			Value: strconv.Itoa(movieId),
 		},
 	}
-	suite.mockMovieService.EXPECT().GetMovieBy(movieId).Return(model.Movie{}, errors.New("error")).Times(1)
+	suite.mockMovieService.EXPECT().GetMovieBy(movieId).Return(model.MovieDetails{}, errors.New("error")).Times(1)
 
 	suite.testController.GetMovieBy(suite.context)
 
 	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
 }
 
+func (suite *MovieControllerTestSuite) Test_GetMovieBy_ShouldReturnNotFoundWhenMovieMissing() {
+	movieId := 1001
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/1001", strings.NewReader(""))
+	suite.context.Params = gin.Params{
+		gin.Param{
+			Key:   "id",
+			Value: strconv.Itoa(movieId),
+		},
+	}
+	suite.mockMovieService.EXPECT().GetMovieBy(movieId).Return(model.MovieDetails{}, model.ErrMovieNotFound).Times(1)
+
+	suite.testController.GetMovieBy(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.JSONEq(`{"code":"movie_not_found","message":"movie not found"}`, suite.recorder.Body.String())
+}
+
+func (suite *MovieControllerTestSuite) Test_DeleteMovie_ShouldReturnErrorBodyWhenMovieMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/movie/1001", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}}
+	suite.mockMovieService.EXPECT().DeleteMovie(1001).Return(model.ErrMovieNotFound).Times(1)
+
+	suite.testController.DeleteMovie(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.JSONEq(`{"code":"movie_not_found","message":"movie not found"}`, suite.recorder.Body.String())
+}
+
 func (suite *MovieControllerTestSuite) Test_GetMovieBy_ShouldSuccessfullyAddMovieToCart() {
 	movieId := 1001
-	expectedResponse := model.Movie{
-		Id:          1,
-		Title:       "Hero",
-		Year:        1990,
-		Genre:       "Action",
-		Description: "Action movie",
-		ImdbCode:    "1234",
+	expectedResponse := model.MovieDetails{
+		Movie: model.Movie{
+			Id:          1,
+			Title:       "Hero",
+			Year:        1990,
+			Genre:       "Action",
+			Description: "Action movie",
+			ImdbCode:    "1234",
+
+			AvailableCopies: 1,
+		},
+		TotalCopies: 2,
+		Related:     []model.Movie{},
 	}
 	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/1001", strings.NewReader(""))
 	suite.context.Params = gin.Params{
@@ -193,4 +287,101 @@ func (suite *MovieControllerTestSuite) Test_GetMovieBy_ShouldSuccessfullyAddMovi
 	suite.testController.GetMovieBy(suite.context)
 
 	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(1, strings.Count(suite.recorder.Body.String(), `"availableCopies"`))
+	suite.Contains(suite.recorder.Body.String(), `"totalCopies":2`)
+}
+
+func (suite *MovieControllerTestSuite) Test_CreateMovie_ShouldReturnBadRequestWhenTitleMissing() {
+	body := `{"releaseYear":2002,"genre":"Action","description":"Wuxia epic"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movies", strings.NewReader(body))
+
+	suite.testController.CreateMovie(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_CreateMovie_ShouldReturnCreatedMovie() {
+	request := model.MovieRequest{Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic"}
+	body := `{"title":"Hero","releaseYear":2002,"genre":"Action","description":"Wuxia epic"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movies", strings.NewReader(body))
+	suite.mockMovieService.EXPECT().CreateMovie(request).Return(model.Movie{Id: 1000000, Title: "Hero"}, nil).Times(1)
+
+	suite.testController.CreateMovie(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_PatchMovie_ShouldReturnNotFoundWhenMovieMissing() {
+	body := `{"description":"Wuxia epic"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPatch, "/movie/7", strings.NewReader(body))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockMovieService.EXPECT().PatchMovie(7, gomock.Any()).Return(model.Movie{}, model.ErrMovieNotFound).Times(1)
+
+	suite.testController.PatchMovie(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_DeleteMovie_ShouldReturnNoContent() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/movie/7", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockMovieService.EXPECT().DeleteMovie(7).Return(nil).Times(1)
+
+	suite.testController.DeleteMovie(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
+
+func (suite *MovieControllerTestSuite) Test_SearchMovies_ShouldReturnBadRequestForEmptyQuery() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/search?q=", nil)
+	suite.mockMovieService.EXPECT().SearchMovies("", 0).
+		Return(model.SearchResponse{}, fmt.Errorf("%w: q must be 1 to 200 characters", model.ErrInvalidQuery)).Times(1)
+
+	suite.testController.SearchMovies(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_SearchMovies_ShouldReturnResults() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/search?q=god+father&limit=5", nil)
+	response := model.SearchResponse{Query: "god father", Items: []model.SearchResult{{Movie: model.Movie{Id: 7}, Match: model.MatchFuzzy}}}
+	suite.mockMovieService.EXPECT().SearchMovies("god father", 5).Return(response, nil).Times(1)
+
+	suite.testController.SearchMovies(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"match":"fuzzy"`)
+}
+
+func (suite *MovieControllerTestSuite) Test_SuggestTitles_ShouldReturnSuggestions() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/suggest?prefix=god&limit=3", nil)
+	suggestions := []model.Suggestion{{Id: 7, Title: "The Godfather", Year: 1972}}
+	suite.mockMovieService.EXPECT().SuggestTitles("god", 3).Return(suggestions, nil).Times(1)
+
+	suite.testController.SuggestTitles(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`[{"id":7,"title":"The Godfather","releaseYear":1972}]`, suite.recorder.Body.String())
+}
+
+func (suite *MovieControllerTestSuite) Test_SuggestTitles_ShouldReturnBadRequestForInvalidLimit() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies/suggest?prefix=god&limit=x", nil)
+
+	suite.testController.SuggestTitles(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *MovieControllerTestSuite) Test_GetMovies_ShouldReturnFacets() {
+	facets := model.NewFacets()
+	facets.Genre = []model.FacetBucket{{Value: "Horror", Count: 42, Filter: "genre:Horror"}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movies?filter=decade:1980", nil)
+	suite.mockMovieService.EXPECT().FindMovies("decade:1980", model.PageRequest{}).
+		Return(model.MoviePage{Movies: []model.Movie{}, Total: 42, Limit: 20, Facets: &facets}, nil).Times(1)
+
+	suite.testController.GetMovies(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(),
+		`"facets":{"genre":[{"value":"Horror","count":42,"filter":"genre:Horror"}],"decade":[],"rating":[],"inStock":[]}`)
 }
@@ -0,0 +1,53 @@
+package filter
+
+import "strings"
+
+type Operator string
+
+const (
+	OpEquals       Operator = ":"
+	OpContains     Operator = "~"
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+)
+
+// Expression is a parsed filter: every condition must hold.
+type Expression struct {
+	Conditions []Condition
+}
+
+// Condition compares one whitelisted field with a value. Build it with
+// NewCondition, which validates it.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+func (e Expression) IsEmpty() bool {
+	return len(e.Conditions) == 0
+}
+
+// String renders the expression back in filter syntax, so that parsing the
+// result yields the same expression.
+func (e Expression) String() string {
+	parts := make([]string, 0, len(e.Conditions))
+	for _, c := range e.Conditions {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func (c Condition) String() string {
+	return c.Field + string(c.Operator) + quote(c.Value)
+}
+
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"\\") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
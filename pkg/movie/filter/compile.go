@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// genreTagged tests for a movie tagged with the genre that the placeholder
+// names by any alias; genre_alias normalizes it as the taxonomy does.
+const genreTagged = `EXISTS (SELECT 1 FROM movie_genres mg JOIN genre_aliases a ON a.genre_id = mg.genre_id ` +
+	`WHERE mg.movie_id = movies.id AND a.alias = genre_alias(%s))`
+
+// genreNamed tests for a movie tagged with a genre whose name matches the
+// ILIKE pattern in the placeholder, so secondary genres match too.
+const genreNamed = `EXISTS (SELECT 1 FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id ` +
+	`WHERE mg.movie_id = movies.id AND g.name ILIKE %s)`
+
+// personCredited tests for a credit on the movie whose person matches the
+// condition that fills it.
+const personCredited = `EXISTS (SELECT 1 FROM credits cr JOIN people p ON p.id = cr.person_id WHERE cr.movie_id = movies.id AND %s)`
+
+// Compile turns the expression into a SQL condition over the movies table,
+// joined with AND, and its arguments. Placeholders are numbered from
+// firstArg, so the condition can follow other arguments. Values only ever
+// travel as arguments; the SQL text is built from the whitelist alone.
+func Compile(expression Expression, firstArg int) (string, []any) {
+	clauses := make([]string, 0, len(expression.Conditions))
+	args := make([]any, 0, len(expression.Conditions))
+	for _, c := range expression.Conditions {
+		f := fields[c.Field]
+		placeholder := "$" + strconv.Itoa(firstArg+len(args))
+		switch {
+		case f.kind == numberKind:
+			number, _ := strconv.Atoi(c.Value)
+			clauses = append(clauses, f.column+" "+sqlOperator(c.Operator)+" "+placeholder)
+			args = append(args, number)
+		case f.kind == personKind && c.Operator == OpContains:
+			clauses = append(clauses, fmt.Sprintf(personCredited, f.column+" ILIKE "+placeholder))
+			args = append(args, "%"+escapeLike(c.Value)+"%")
+		case f.kind == personKind:
+			clauses = append(clauses, fmt.Sprintf(personCredited, "LOWER("+f.column+") = LOWER("+placeholder+")"))
+			args = append(args, c.Value)
+		case f.kind == genreKind && c.Operator == OpEquals:
+			clauses = append(clauses, "(LOWER("+f.column+") = LOWER("+placeholder+") OR "+fmt.Sprintf(genreTagged, placeholder)+")")
+			args = append(args, c.Value)
+		case f.kind == genreKind && c.Operator == OpContains:
+			clauses = append(clauses, "("+f.column+" ILIKE "+placeholder+" OR "+fmt.Sprintf(genreNamed, placeholder)+")")
+			args = append(args, "%"+escapeLike(c.Value)+"%")
+		case c.Operator == OpContains:
+			clauses = append(clauses, f.column+" ILIKE "+placeholder)
+			args = append(args, "%"+escapeLike(c.Value)+"%")
+		default:
+			clauses = append(clauses, "LOWER("+f.column+") = LOWER("+placeholder+")")
+			args = append(args, c.Value)
+		}
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+func sqlOperator(operator Operator) string {
+	if operator == OpEquals {
+		return "="
+	}
+	return string(operator)
+}
+
+// escapeLike makes %, _ and \ match literally; backslash is the default
+// LIKE escape character in PostgreSQL.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
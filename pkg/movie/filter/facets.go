@@ -0,0 +1,33 @@
+package filter
+
+import "movie-rent/pkg/movie/model"
+
+// ratingBands are the rating facet's buckets, as labelled by the facet query,
+// and the conditions that select each one.
+var ratingBands = map[string][]Condition{
+	"1-2": {{Field: "rating", Operator: OpLess, Value: "2"}},
+	"2-3": {{Field: "rating", Operator: OpGreaterEqual, Value: "2"}, {Field: "rating", Operator: OpLess, Value: "3"}},
+	"3-4": {{Field: "rating", Operator: OpGreaterEqual, Value: "3"}, {Field: "rating", Operator: OpLess, Value: "4"}},
+	"4-5": {{Field: "rating", Operator: OpGreaterEqual, Value: "4"}},
+}
+
+// BucketFilter is the filter text that selects one facet bucket. It is empty
+// for buckets the filter language cannot express, such as unrated titles.
+func BucketFilter(facet string, value string) string {
+	var conditions []Condition
+	switch facet {
+	case model.FacetGenre:
+		conditions = []Condition{{Field: "genre", Operator: OpEquals, Value: value}}
+	case model.FacetDecade:
+		conditions = []Condition{{Field: "decade", Operator: OpEquals, Value: value}}
+	case model.FacetRating:
+		conditions = ratingBands[value]
+	case model.FacetInStock:
+		if value == model.InStock {
+			conditions = []Condition{{Field: "available", Operator: OpGreater, Value: "0"}}
+		} else {
+			conditions = []Condition{{Field: "available", Operator: OpEquals, Value: "0"}}
+		}
+	}
+	return Expression{Conditions: conditions}.String()
+}
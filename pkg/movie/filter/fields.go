@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"fmt"
+	"movie-rent/pkg/movie/model"
+	"strconv"
+	"strings"
+)
+
+type kind int
+
+const (
+	textKind kind = iota
+	numberKind
+	// genreKind is text that also matches the canonical genres a movie is
+	// tagged with, by any of their aliases.
+	genreKind
+	// personKind matches the name of anyone credited on the movie.
+	personKind
+)
+
+// field maps a filter name onto a column. Only fields listed in fields can
+// appear in a query, and only with their own operators.
+type field struct {
+	column    string
+	kind      kind
+	operators []Operator
+}
+
+var comparisons = []Operator{OpEquals, OpGreater, OpGreaterEqual, OpLess, OpLessEqual}
+
+var fields = map[string]field{
+	"title":       {column: "title", kind: textKind, operators: []Operator{OpEquals, OpContains}},
+	"genre":       {column: "genre", kind: genreKind, operators: []Operator{OpEquals, OpContains}},
+	"description": {column: "description", kind: textKind, operators: []Operator{OpContains}},
+	"imdb":        {column: "imdb_code", kind: textKind, operators: []Operator{OpEquals}},
+	"year":        {column: "release_year", kind: numberKind, operators: comparisons},
+	"price":       {column: "price_cents", kind: numberKind, operators: comparisons},
+	"decade":      {column: "(release_year / 10 * 10)", kind: numberKind, operators: []Operator{OpEquals}},
+	"rating":      {column: "average_rating", kind: numberKind, operators: comparisons},
+	"available":   {column: availableCopiesColumn, kind: numberKind, operators: comparisons},
+	"person":      {column: "p.name", kind: personKind, operators: []Operator{OpEquals, OpContains}},
+}
+
+// availableCopiesColumn counts the copies on the shelf, so available>0 keeps
+// titles in stock.
+const availableCopiesColumn = `(SELECT COUNT(*) FROM movie_copies c WHERE c.movie_id = movies.id AND c.status = 'available')`
+
+const maxValueLength = 100
+
+// NewCondition checks the field, operator and value against the whitelist.
+// Field names are case-insensitive.
+func NewCondition(name string, operator Operator, value string) (Condition, error) {
+	name = strings.ToLower(name)
+	f, ok := fields[name]
+	if !ok {
+		return Condition{}, fmt.Errorf("%w: unknown field %q", model.ErrInvalidFilter, name)
+	}
+	if !f.allows(operator) {
+		return Condition{}, fmt.Errorf("%w: operator %q is not supported for %s", model.ErrInvalidFilter, operator, name)
+	}
+	if value == "" {
+		return Condition{}, fmt.Errorf("%w: missing value for %s", model.ErrInvalidFilter, name)
+	}
+	if len(value) > maxValueLength {
+		return Condition{}, fmt.Errorf("%w: value for %s is longer than %d characters", model.ErrInvalidFilter, name, maxValueLength)
+	}
+	if f.kind == numberKind {
+		if _, err := strconv.Atoi(value); err != nil {
+			return Condition{}, fmt.Errorf("%w: %s must be a whole number", model.ErrInvalidFilter, name)
+		}
+	}
+	return Condition{Field: name, Operator: operator, Value: value}, nil
+}
+
+func (f field) allows(operator Operator) bool {
+	for _, op := range f.operators {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package filter
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/movie/model"
+	"testing"
+)
+
+type FilterTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (suite *FilterTestSuite) Test_Parse_ShouldReadConditions() {
+	expression, err := Parse(`genre:horror year>=1990 year<2000 title~"alien"`)
+
+	suite.Nil(err)
+	suite.Equal([]Condition{
+		{Field: "genre", Operator: OpEquals, Value: "horror"},
+		{Field: "year", Operator: OpGreaterEqual, Value: "1990"},
+		{Field: "year", Operator: OpLess, Value: "2000"},
+		{Field: "title", Operator: OpContains, Value: "alien"},
+	}, expression.Conditions)
+}
+
+func (suite *FilterTestSuite) Test_Parse_ShouldUnescapeQuotedValues() {
+	expression, err := Parse(`Title~"the \"thing\" \\ returns"`)
+
+	suite.Nil(err)
+	suite.Equal(Condition{Field: "title", Operator: OpContains, Value: `the "thing" \ returns`}, expression.Conditions[0])
+}
+
+func (suite *FilterTestSuite) Test_Parse_ShouldAcceptEmptyInput() {
+	expression, err := Parse("  ")
+
+	suite.Nil(err)
+	suite.True(expression.IsEmpty())
+}
+
+func (suite *FilterTestSuite) Test_Parse_ShouldRejectInvalidExpressions() {
+	for _, input := range []string{
+		`budget>100`,
+		`description:alien`,
+		`year~199`,
+		`year>=nineteen`,
+		`genre:`,
+		`genre`,
+		`:horror`,
+		`title~"alien`,
+		`title~"alien"year>1`,
+		`title~al"ien`,
+		`genre:horror; DROP TABLE movies`,
+	} {
+		_, err := Parse(input)
+
+		suite.ErrorIs(err, model.ErrInvalidFilter, input)
+	}
+}
+
+func (suite *FilterTestSuite) Test_Parse_ShouldReportPosition() {
+	_, err := Parse(`genre:horror budget>100`)
+
+	suite.EqualError(err, `invalid filter: unknown field "budget" at position 14`)
+}
+
+func (suite *FilterTestSuite) Test_Compile_ShouldUsePlaceholdersOnly() {
+	expression, err := Parse(`genre:Horror year>=1990 title~"50%_off"`)
+	suite.Require().Nil(err)
+
+	where, args := Compile(expression, 3)
+
+	suite.Equal(`(LOWER(genre) = LOWER($3) OR `+fmt.Sprintf(genreTagged, "$3")+`) AND release_year >= $4 AND title ILIKE $5`, where)
+	suite.Equal([]any{"Horror", 1990, `%50\%\_off%`}, args)
+}
+
+func (suite *FilterTestSuite) Test_Compile_ShouldMatchSecondaryGenresBySubstring() {
+	expression, err := Parse(`genre~"fi"`)
+	suite.Require().Nil(err)
+
+	where, args := Compile(expression, 1)
+
+	suite.Equal(`(genre ILIKE $1 OR `+fmt.Sprintf(genreNamed, "$1")+`)`, where)
+	suite.Equal([]any{"%fi%"}, args)
+}
+
+func (suite *FilterTestSuite) Test_Compile_ShouldSupportFacetFields() {
+	expression, err := Parse(`decade:1980 rating>=4 available>0`)
+	suite.Require().Nil(err)
+
+	where, args := Compile(expression, 1)
+
+	suite.Equal(`(release_year / 10 * 10) = $1 AND average_rating >= $2 AND `+availableCopiesColumn+` > $3`, where)
+	suite.Equal([]any{1980, 4, 0}, args)
+}
+
+func (suite *FilterTestSuite) Test_Compile_ShouldMatchCreditedPeople() {
+	expression, err := Parse(`person:"Tom Hanks" person~zemeckis`)
+	suite.Require().Nil(err)
+
+	where, args := Compile(expression, 1)
+
+	suite.Equal(fmt.Sprintf(personCredited, "LOWER(p.name) = LOWER($1)")+" AND "+fmt.Sprintf(personCredited, "p.name ILIKE $2"), where)
+	suite.Equal([]any{"Tom Hanks", "%zemeckis%"}, args)
+}
+
+func (suite *FilterTestSuite) Test_BucketFilter_ShouldParseBackIntoConditions() {
+	suite.Equal(`genre:"Science Fiction"`, BucketFilter(model.FacetGenre, "Science Fiction"))
+	suite.Equal(`rating>=3 rating<4`, BucketFilter(model.FacetRating, "3-4"))
+	suite.Equal(`available:0`, BucketFilter(model.FacetInStock, model.OutOfStock))
+	suite.Empty(BucketFilter(model.FacetRating, model.RatingUnrated))
+
+	_, err := Parse(BucketFilter(model.FacetDecade, "1990"))
+	suite.Nil(err)
+}
+
+func (suite *FilterTestSuite) Test_String_ShouldRoundTrip() {
+	expression, err := Parse(`title~"a \"b\"" year<=2000 imdb:tt0078748`)
+	suite.Require().Nil(err)
+
+	reparsed, err := Parse(expression.String())
+
+	suite.Nil(err)
+	suite.Equal(expression, reparsed)
+}
+
+func (suite *FilterTestSuite) Test_ParseSort_ShouldAppendIdAndRejectUnknownKeys() {
+	sort, err := ParseSort("title,-releaseYear")
+
+	suite.Nil(err)
+	suite.Equal([]SortKey{{Field: "title"}, {Field: "releaseYear", Descending: true}, {Field: "id"}}, sort)
+
+	_, err = ParseSort("title,budget")
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+	_, err = ParseSort("title,-title")
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+}
+
+func (suite *FilterTestSuite) Test_CompileListing_ShouldFlipOrderWhenReadingBackwards() {
+	sort, _ := ParseSort("-priceCents")
+	cursor := CursorAt(model.Movie{Id: 4, PriceCents: 299}, sort, true)
+
+	where, orderBy, args := CompileListing(Query{Sort: sort, Limit: 10, Cursor: &cursor}, 1)
+
+	suite.Equal(`((price_cents > $1) OR (price_cents = $1 AND id < $2))`, where)
+	suite.Equal(`price_cents ASC, id DESC`, orderBy)
+	suite.Equal([]any{299, 4}, args)
+}
+
+func (suite *FilterTestSuite) Test_DecodeCursor_ShouldRejectTamperedCursor() {
+	sort, _ := ParseSort("releaseYear")
+
+	for _, token := range []string{"not base64!", "e30", CursorAt(model.Movie{}, []SortKey{{Field: "id"}}, false).Encode()} {
+		_, err := DecodeCursor(token, sort)
+
+		suite.ErrorIs(err, model.ErrInvalidQuery, token)
+	}
+}
@@ -0,0 +1,56 @@
+package filter
+
+import (
+	"errors"
+	"movie-rent/pkg/movie/model"
+	"strings"
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`genre:horror year>=1990 year<2000 title~"alien"`,
+		`title~"the \"thing\""`,
+		`price<=299 imdb:tt0078748`,
+		`available>0`,
+		`title~"alien`,
+		`year>=>=1`,
+		`genre:horror'; DROP TABLE movies; --`,
+		"title~\"\x00\"",
+		``,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		expression, err := Parse(input)
+		if err != nil {
+			if !errors.Is(err, model.ErrInvalidFilter) {
+				t.Fatalf("error %v does not wrap ErrInvalidFilter", err)
+			}
+			return
+		}
+
+		where, args := Compile(expression, 1)
+		if len(args) != len(expression.Conditions) {
+			t.Fatalf("got %d args for %d conditions", len(args), len(expression.Conditions))
+		}
+		for _, c := range expression.Conditions {
+			if _, ok := fields[c.Field]; !ok {
+				t.Fatalf("field %q is not whitelisted", c.Field)
+			}
+		}
+		// availableCopiesColumn is the only fixed SQL that quotes a literal.
+		if strings.ContainsAny(strings.ReplaceAll(where, availableCopiesColumn, ""), `'";`) {
+			t.Fatalf("compiled SQL %q contains user input", where)
+		}
+
+		reparsed, err := Parse(expression.String())
+		if err != nil {
+			t.Fatalf("reparsing %q: %v", expression.String(), err)
+		}
+		if reparsed.String() != expression.String() {
+			t.Fatalf("round trip changed %q to %q", expression.String(), reparsed.String())
+		}
+	})
+}
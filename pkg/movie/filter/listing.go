@@ -0,0 +1,211 @@
+package filter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"movie-rent/pkg/movie/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Query is one page of a listing: the filter, the order, the page size and
+// where the page starts.
+type Query struct {
+	Expression Expression
+	Sort       []SortKey
+	Limit      int
+	Cursor     *Cursor
+}
+
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// Cursor marks the edge of a page by the sort values of a row. With Before
+// set it reads backwards, for the previous page.
+type Cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+	Before bool   `json:"b,omitempty"`
+}
+
+var sortFields = map[string]field{
+	"id":          {column: "id", kind: numberKind},
+	"title":       {column: "title", kind: textKind},
+	"releaseYear": {column: "release_year", kind: numberKind},
+	"priceCents":  {column: "price_cents", kind: numberKind},
+}
+
+// NewQuery validates the sort, limit and cursor of a listing request. A
+// limit of 0 means the default. Every error wraps model.ErrInvalidQuery.
+func NewQuery(expression Expression, sortText string, limit int, cursorText string) (Query, error) {
+	sort, err := ParseSort(sortText)
+	if err != nil {
+		return Query{}, err
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		return Query{}, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalidQuery, MaxLimit)
+	}
+	query := Query{Expression: expression, Sort: sort, Limit: limit}
+	if cursorText != "" {
+		cursor, err := DecodeCursor(cursorText, sort)
+		if err != nil {
+			return Query{}, err
+		}
+		query.Cursor = &cursor
+	}
+	return query, nil
+}
+
+// ParseSort reads keys such as "title,-releaseYear"; a leading "-" sorts
+// descending. Rows are always ordered by id last, so the order is total.
+func ParseSort(input string) ([]SortKey, error) {
+	keys := []SortKey{}
+	seen := map[string]bool{}
+	if input != "" {
+		for _, part := range strings.Split(input, ",") {
+			key := SortKey{Field: strings.TrimSpace(part)}
+			if name, found := strings.CutPrefix(key.Field, "-"); found {
+				key = SortKey{Field: name, Descending: true}
+			}
+			if _, ok := sortFields[key.Field]; !ok {
+				return nil, fmt.Errorf("%w: cannot sort by %q", model.ErrInvalidQuery, key.Field)
+			}
+			if seen[key.Field] {
+				return nil, fmt.Errorf("%w: %s is sorted twice", model.ErrInvalidQuery, key.Field)
+			}
+			seen[key.Field] = true
+			keys = append(keys, key)
+		}
+	}
+	if !seen["id"] {
+		keys = append(keys, SortKey{Field: "id"})
+	}
+	return keys, nil
+}
+
+func sortString(keys []SortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.Descending {
+			parts = append(parts, "-"+key.Field)
+		} else {
+			parts = append(parts, key.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// CursorAt returns the cursor just past the movie, in the direction given.
+func CursorAt(movie model.Movie, sort []SortKey, before bool) Cursor {
+	values := make([]any, 0, len(sort))
+	for _, key := range sort {
+		switch key.Field {
+		case "title":
+			values = append(values, movie.Title)
+		case "releaseYear":
+			values = append(values, movie.Year)
+		case "priceCents":
+			values = append(values, movie.PriceCents)
+		default:
+			values = append(values, movie.Id)
+		}
+	}
+	return Cursor{Sort: sortString(sort), Values: values, Before: before}
+}
+
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor rejects cursors that were issued for a different order.
+func DecodeCursor(token string, sort []SortKey) (Cursor, error) {
+	invalid := fmt.Errorf("%w: cursor is invalid", model.ErrInvalidQuery)
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(payload)))
+	decoder.UseNumber()
+	var cursor Cursor
+	if err = decoder.Decode(&cursor); err != nil {
+		return Cursor{}, invalid
+	}
+	if cursor.Sort != sortString(sort) || len(cursor.Values) != len(sort) {
+		return Cursor{}, fmt.Errorf("%w: cursor does not match the sort order", model.ErrInvalidQuery)
+	}
+
+	for i, key := range sort {
+		switch value := cursor.Values[i].(type) {
+		case json.Number:
+			number, err := strconv.Atoi(value.String())
+			if err != nil || sortFields[key.Field].kind != numberKind {
+				return Cursor{}, invalid
+			}
+			cursor.Values[i] = number
+		case string:
+			if sortFields[key.Field].kind != textKind {
+				return Cursor{}, invalid
+			}
+		default:
+			return Cursor{}, invalid
+		}
+	}
+	return cursor, nil
+}
+
+// CompileListing extends Compile with the keyset condition for the cursor
+// and the ORDER BY clause. Reading backwards flips every direction; the
+// caller reverses the rows again.
+func CompileListing(query Query, firstArg int) (where string, orderBy string, args []any) {
+	where, args = Compile(query.Expression, firstArg)
+	clauses := []string{}
+	if where != "" {
+		clauses = append(clauses, where)
+	}
+
+	before := query.Cursor != nil && query.Cursor.Before
+	if query.Cursor != nil {
+		placeholders := make([]string, len(query.Sort))
+		for i, value := range query.Cursor.Values {
+			placeholders[i] = "$" + strconv.Itoa(firstArg+len(args))
+			args = append(args, value)
+		}
+
+		alternatives := make([]string, 0, len(query.Sort))
+		for i, key := range query.Sort {
+			terms := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				terms = append(terms, sortFields[query.Sort[j].Field].column+" = "+placeholders[j])
+			}
+			operator := ">"
+			if key.Descending != before {
+				operator = "<"
+			}
+			terms = append(terms, sortFields[key.Field].column+" "+operator+" "+placeholders[i])
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		clauses = append(clauses, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	order := make([]string, 0, len(query.Sort))
+	for _, key := range query.Sort {
+		direction := "ASC"
+		if key.Descending != before {
+			direction = "DESC"
+		}
+		order = append(order, sortFields[key.Field].column+" "+direction)
+	}
+	return strings.Join(clauses, " AND "), strings.Join(order, ", "), args
+}
@@ -0,0 +1,157 @@
+package filter
+
+import (
+	"fmt"
+	"movie-rent/pkg/movie/model"
+	"strings"
+)
+
+const (
+	maxInputLength = 500
+	maxConditions  = 20
+)
+
+// Parse reads a filter such as
+//
+//	genre:horror year>=1990 year<2000 title~"alien"
+//
+// Conditions are separated by spaces and must all hold. Values with spaces
+// or quotes are written in double quotes, escaping " and \ with a backslash.
+// Every error wraps model.ErrInvalidFilter.
+func Parse(input string) (Expression, error) {
+	if len(input) > maxInputLength {
+		return Expression{}, fmt.Errorf("%w: longer than %d characters", model.ErrInvalidFilter, maxInputLength)
+	}
+
+	p := parser{input: input}
+	expression := Expression{Conditions: []Condition{}}
+	for {
+		p.skipSpace()
+		if p.done() {
+			return expression, nil
+		}
+		if len(expression.Conditions) == maxConditions {
+			return Expression{}, fmt.Errorf("%w: more than %d conditions", model.ErrInvalidFilter, maxConditions)
+		}
+		condition, err := p.condition()
+		if err != nil {
+			return Expression{}, err
+		}
+		expression.Conditions = append(expression.Conditions, condition)
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) condition() (Condition, error) {
+	start := p.pos
+	name := p.identifier()
+	if name == "" {
+		return Condition{}, p.errorf("expected a field name")
+	}
+	operator, ok := p.operator()
+	if !ok {
+		return Condition{}, p.errorf("expected an operator after %q", name)
+	}
+	value, err := p.value()
+	if err != nil {
+		return Condition{}, err
+	}
+
+	condition, err := NewCondition(name, operator, value)
+	if err != nil {
+		return Condition{}, fmt.Errorf("%w at position %d", err, start+1)
+	}
+	return condition, nil
+}
+
+func (p *parser) identifier() string {
+	start := p.pos
+	for !p.done() && isIdentifierChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// operator tries the two-character operators first, so that ">=" is not read
+// as ">" followed by a value starting with "=".
+func (p *parser) operator() (Operator, bool) {
+	for _, op := range []Operator{OpGreaterEqual, OpLessEqual, OpEquals, OpContains, OpGreater, OpLess} {
+		if strings.HasPrefix(p.input[p.pos:], string(op)) {
+			p.pos += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) value() (string, error) {
+	if p.done() || isSpace(p.input[p.pos]) {
+		return "", p.errorf("expected a value")
+	}
+	if p.input[p.pos] == '"' {
+		return p.quoted()
+	}
+
+	start := p.pos
+	for !p.done() && !isSpace(p.input[p.pos]) {
+		if p.input[p.pos] == '"' || p.input[p.pos] == '\\' {
+			return "", p.errorf("unexpected %q; quote values that contain it", p.input[p.pos])
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var value strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			if !p.done() && !isSpace(p.input[p.pos]) {
+				return "", p.errorf("expected a space after the closing quote")
+			}
+			return value.String(), nil
+		case c == '\\':
+			p.pos++
+			if p.done() || (p.input[p.pos] != '"' && p.input[p.pos] != '\\') {
+				return "", p.errorf("only \\\" and \\\\ can be escaped")
+			}
+			value.WriteByte(p.input[p.pos])
+		default:
+			value.WriteByte(c)
+		}
+		p.pos++
+	}
+	p.pos = start
+	return "", p.errorf("unterminated quote")
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", model.ErrInvalidFilter, fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentifierChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -5,8 +5,10 @@
 package mocks
 
 import (
+	filter "movie-rent/pkg/movie/filter"
 	model "movie-rent/pkg/movie/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,34 +36,93 @@ func (m *MockMovieRepository) EXPECT() *MockMovieRepositoryMockRecorder {
 	return m.recorder
 }
 
-// FetchMoviesBySearchText mocks base method.
-func (m *MockMovieRepository) FetchMoviesBySearchText(searchType, searchText string) ([]model.Movie, error) {
+// CountMovies mocks base method.
+func (m *MockMovieRepository) CountMovies(expression filter.Expression) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchMoviesBySearchText", searchType, searchText)
-	ret0, _ := ret[0].([]model.Movie)
+	ret := m.ctrl.Call(m, "CountMovies", expression)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchMoviesBySearchText indicates an expected call of FetchMoviesBySearchText.
-func (mr *MockMovieRepositoryMockRecorder) FetchMoviesBySearchText(searchType, searchText interface{}) *gomock.Call {
+// CountMovies indicates an expected call of CountMovies.
+func (mr *MockMovieRepositoryMockRecorder) CountMovies(expression interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMoviesBySearchText", reflect.TypeOf((*MockMovieRepository)(nil).FetchMoviesBySearchText), searchType, searchText)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMovies", reflect.TypeOf((*MockMovieRepository)(nil).CountMovies), expression)
 }
 
-// FetchMoviesByYear mocks base method.
-func (m *MockMovieRepository) FetchMoviesByYear(year int) ([]model.Movie, error) {
+// CreateMovie mocks base method.
+func (m *MockMovieRepository) CreateMovie(movie model.Movie) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchMoviesByYear", year)
-	ret0, _ := ret[0].([]model.Movie)
+	ret := m.ctrl.Call(m, "CreateMovie", movie)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovie indicates an expected call of CreateMovie.
+func (mr *MockMovieRepositoryMockRecorder) CreateMovie(movie interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockMovieRepository)(nil).CreateMovie), movie)
+}
+
+// DeleteMovie mocks base method.
+func (m *MockMovieRepository) DeleteMovie(movieId int, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovie", movieId, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovie indicates an expected call of DeleteMovie.
+func (mr *MockMovieRepositoryMockRecorder) DeleteMovie(movieId, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieRepository)(nil).DeleteMovie), movieId, deletedAt)
+}
+
+// FuzzySearchMovies mocks base method.
+func (m *MockMovieRepository) FuzzySearchMovies(text string, limit int) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuzzySearchMovies", text, limit)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FuzzySearchMovies indicates an expected call of FuzzySearchMovies.
+func (mr *MockMovieRepositoryMockRecorder) FuzzySearchMovies(text, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuzzySearchMovies", reflect.TypeOf((*MockMovieRepository)(nil).FuzzySearchMovies), text, limit)
+}
+
+// GetAvailability mocks base method.
+func (m *MockMovieRepository) GetAvailability(movieId int) (model.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", movieId)
+	ret0, _ := ret[0].(model.Availability)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchMoviesByYear indicates an expected call of FetchMoviesByYear.
-func (mr *MockMovieRepositoryMockRecorder) FetchMoviesByYear(year interface{}) *gomock.Call {
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockMovieRepositoryMockRecorder) GetAvailability(movieId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMoviesByYear", reflect.TypeOf((*MockMovieRepository)(nil).FetchMoviesByYear), year)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockMovieRepository)(nil).GetAvailability), movieId)
+}
+
+// GetFacets mocks base method.
+func (m *MockMovieRepository) GetFacets(expression filter.Expression) (model.Facets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacets", expression)
+	ret0, _ := ret[0].(model.Facets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacets indicates an expected call of GetFacets.
+func (mr *MockMovieRepositoryMockRecorder) GetFacets(expression interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacets", reflect.TypeOf((*MockMovieRepository)(nil).GetFacets), expression)
 }
 
 // GetMovieBy mocks base method.
@@ -94,6 +155,51 @@ func (mr *MockMovieRepositoryMockRecorder) GetMovies() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieRepository)(nil).GetMovies))
 }
 
+// GetPopularity mocks base method.
+func (m *MockMovieRepository) GetPopularity(since time.Time) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularity", since)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularity indicates an expected call of GetPopularity.
+func (mr *MockMovieRepositoryMockRecorder) GetPopularity(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularity", reflect.TypeOf((*MockMovieRepository)(nil).GetPopularity), since)
+}
+
+// GetRelatedMovies mocks base method.
+func (m *MockMovieRepository) GetRelatedMovies(movie model.Movie, limit int) ([]model.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelatedMovies", movie, limit)
+	ret0, _ := ret[0].([]model.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelatedMovies indicates an expected call of GetRelatedMovies.
+func (mr *MockMovieRepositoryMockRecorder) GetRelatedMovies(movie, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedMovies", reflect.TypeOf((*MockMovieRepository)(nil).GetRelatedMovies), movie, limit)
+}
+
+// ListMovies mocks base method.
+func (m *MockMovieRepository) ListMovies(query filter.Query) ([]model.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovies", query)
+	ret0, _ := ret[0].([]model.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovies indicates an expected call of ListMovies.
+func (mr *MockMovieRepositoryMockRecorder) ListMovies(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovies", reflect.TypeOf((*MockMovieRepository)(nil).ListMovies), query)
+}
+
 // Save mocks base method.
 func (m *MockMovieRepository) Save(movie model.Movie) error {
 	m.ctrl.T.Helper()
@@ -121,3 +227,32 @@ func (mr *MockMovieRepositoryMockRecorder) SaveAll(movies interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockMovieRepository)(nil).SaveAll), movies)
 }
+
+// SearchMovies mocks base method.
+func (m *MockMovieRepository) SearchMovies(text string, limit int) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMovies", text, limit)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMovies indicates an expected call of SearchMovies.
+func (mr *MockMovieRepositoryMockRecorder) SearchMovies(text, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockMovieRepository)(nil).SearchMovies), text, limit)
+}
+
+// UpdateMovie mocks base method.
+func (m *MockMovieRepository) UpdateMovie(movie model.Movie) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovie", movie)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovie indicates an expected call of UpdateMovie.
+func (mr *MockMovieRepositoryMockRecorder) UpdateMovie(movie interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieRepository)(nil).UpdateMovie), movie)
+}
@@ -48,26 +48,70 @@ func (mr *MockMovieServiceMockRecorder) AddMovie() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockMovieService)(nil).AddMovie))
 }
 
+// CreateMovie mocks base method.
+func (m *MockMovieService) CreateMovie(request model.MovieRequest) (model.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovie", request)
+	ret0, _ := ret[0].(model.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovie indicates an expected call of CreateMovie.
+func (mr *MockMovieServiceMockRecorder) CreateMovie(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockMovieService)(nil).CreateMovie), request)
+}
+
+// DeleteMovie mocks base method.
+func (m *MockMovieService) DeleteMovie(movieId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovie", movieId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovie indicates an expected call of DeleteMovie.
+func (mr *MockMovieServiceMockRecorder) DeleteMovie(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), movieId)
+}
+
+// FindMovies mocks base method.
+func (m *MockMovieService) FindMovies(filterText string, page model.PageRequest) (model.MoviePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovies", filterText, page)
+	ret0, _ := ret[0].(model.MoviePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMovies indicates an expected call of FindMovies.
+func (mr *MockMovieServiceMockRecorder) FindMovies(filterText, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovies", reflect.TypeOf((*MockMovieService)(nil).FindMovies), filterText, page)
+}
+
 // GetFilteredMovies mocks base method.
-func (m *MockMovieService) GetFilteredMovies(searchType, searchText string) ([]model.Movie, error) {
+func (m *MockMovieService) GetFilteredMovies(searchType, searchText string, page model.PageRequest) (model.MoviePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilteredMovies", searchType, searchText)
-	ret0, _ := ret[0].([]model.Movie)
+	ret := m.ctrl.Call(m, "GetFilteredMovies", searchType, searchText, page)
+	ret0, _ := ret[0].(model.MoviePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilteredMovies indicates an expected call of GetFilteredMovies.
-func (mr *MockMovieServiceMockRecorder) GetFilteredMovies(searchType, searchText interface{}) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) GetFilteredMovies(searchType, searchText, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredMovies", reflect.TypeOf((*MockMovieService)(nil).GetFilteredMovies), searchType, searchText)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredMovies", reflect.TypeOf((*MockMovieService)(nil).GetFilteredMovies), searchType, searchText, page)
 }
 
 // GetMovieBy mocks base method.
-func (m *MockMovieService) GetMovieBy(movieId int) (model.Movie, error) {
+func (m *MockMovieService) GetMovieBy(movieId int) (model.MovieDetails, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMovieBy", movieId)
-	ret0, _ := ret[0].(model.Movie)
+	ret0, _ := ret[0].(model.MovieDetails)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -78,17 +122,62 @@ func (mr *MockMovieServiceMockRecorder) GetMovieBy(movieId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieBy", reflect.TypeOf((*MockMovieService)(nil).GetMovieBy), movieId)
 }
 
-// GetMovies mocks base method.
-func (m *MockMovieService) GetMovies() ([]model.Movie, error) {
+// PatchMovie mocks base method.
+func (m *MockMovieService) PatchMovie(movieId int, patch model.MoviePatch) (model.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovies")
-	ret0, _ := ret[0].([]model.Movie)
+	ret := m.ctrl.Call(m, "PatchMovie", movieId, patch)
+	ret0, _ := ret[0].(model.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchMovie indicates an expected call of PatchMovie.
+func (mr *MockMovieServiceMockRecorder) PatchMovie(movieId, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchMovie", reflect.TypeOf((*MockMovieService)(nil).PatchMovie), movieId, patch)
+}
+
+// SearchMovies mocks base method.
+func (m *MockMovieService) SearchMovies(text string, limit int) (model.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMovies", text, limit)
+	ret0, _ := ret[0].(model.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMovies indicates an expected call of SearchMovies.
+func (mr *MockMovieServiceMockRecorder) SearchMovies(text, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockMovieService)(nil).SearchMovies), text, limit)
+}
+
+// SuggestTitles mocks base method.
+func (m *MockMovieService) SuggestTitles(prefix string, limit int) ([]model.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestTitles", prefix, limit)
+	ret0, _ := ret[0].([]model.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestTitles indicates an expected call of SuggestTitles.
+func (mr *MockMovieServiceMockRecorder) SuggestTitles(prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestTitles", reflect.TypeOf((*MockMovieService)(nil).SuggestTitles), prefix, limit)
+}
+
+// UpdateMovie mocks base method.
+func (m *MockMovieService) UpdateMovie(movieId int, request model.MovieRequest) (model.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovie", movieId, request)
+	ret0, _ := ret[0].(model.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMovies indicates an expected call of GetMovies.
-func (mr *MockMovieServiceMockRecorder) GetMovies() *gomock.Call {
+// UpdateMovie indicates an expected call of UpdateMovie.
+func (mr *MockMovieServiceMockRecorder) UpdateMovie(movieId, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieService)(nil).GetMovies))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieService)(nil).UpdateMovie), movieId, request)
 }
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/movie/suggest/index.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/movie/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIndex is a mock of Index interface.
+type MockIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexMockRecorder
+}
+
+// MockIndexMockRecorder is the mock recorder for MockIndex.
+type MockIndexMockRecorder struct {
+	mock *MockIndex
+}
+
+// NewMockIndex creates a new mock instance.
+func NewMockIndex(ctrl *gomock.Controller) *MockIndex {
+	mock := &MockIndex{ctrl: ctrl}
+	mock.recorder = &MockIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndex) EXPECT() *MockIndexMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *MockIndex) Invalidate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate")
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockIndexMockRecorder) Invalidate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockIndex)(nil).Invalidate))
+}
+
+// Refresh mocks base method.
+func (m *MockIndex) Refresh() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockIndexMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockIndex)(nil).Refresh))
+}
+
+// Suggest mocks base method.
+func (m *MockIndex) Suggest(prefix string, limit int) []model.Suggestion {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", prefix, limit)
+	ret0, _ := ret[0].([]model.Suggestion)
+	return ret0
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockIndexMockRecorder) Suggest(prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockIndex)(nil).Suggest), prefix, limit)
+}
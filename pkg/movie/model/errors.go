@@ -0,0 +1,25 @@
+package model
+
+import "errors"
+
+var (
+	ErrMovieNotFound = errors.New("movie not found")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidQuery  = errors.New("invalid query")
+)
+
+const (
+	CodeInvalidId      = "invalid_id"
+	CodeInvalidRequest = "invalid_request"
+	CodeMovieNotFound  = "movie_not_found"
+	CodeInvalidFilter  = "invalid_filter"
+	CodeInvalidQuery   = "invalid_query"
+	CodeInternalError  = "internal_error"
+)
+
+// ErrorResponse is the body of a failed request: a stable code for clients
+// to switch on, and a message for people.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,34 @@
+package model
+
+const (
+	FacetGenre   = "genre"
+	FacetDecade  = "decade"
+	FacetRating  = "rating"
+	FacetInStock = "inStock"
+
+	RatingUnrated = "unrated"
+	InStock       = "inStock"
+	OutOfStock    = "outOfStock"
+)
+
+// FacetBucket is one value of a facet and the number of matching movies that
+// have it. Filter narrows the current filter to the bucket when appended to
+// it; it is empty when the bucket cannot be selected.
+type FacetBucket struct {
+	Value  string `json:"value"`
+	Count  int    `json:"count"`
+	Filter string `json:"filter,omitempty"`
+}
+
+// Facets break the movies matching a filter down by genre, release decade,
+// average rating band and whether a copy is on the shelf.
+type Facets struct {
+	Genre   []FacetBucket `json:"genre"`
+	Decade  []FacetBucket `json:"decade"`
+	Rating  []FacetBucket `json:"rating"`
+	InStock []FacetBucket `json:"inStock"`
+}
+
+func NewFacets() Facets {
+	return Facets{Genre: []FacetBucket{}, Decade: []FacetBucket{}, Rating: []FacetBucket{}, InStock: []FacetBucket{}}
+}
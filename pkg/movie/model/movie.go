@@ -1,5 +1,7 @@
 package model
 
+import personModel "movie-rent/pkg/person/model"
+
 type Movies struct {
 	Movies Movie `json:"movies"`
 }
@@ -11,4 +13,119 @@ type Movie struct {
 	Genre       string `json:"genre"`
 	Description string `json:"description"`
 	ImdbCode    string `json:"imdbCode"`
+
+	PriceCents      int `json:"priceCents"`
+	AvailableCopies int `json:"availableCopies"`
+
+	// AverageRating and RatingCount summarise the movie's reviews. The
+	// average is nil until the movie has been rated.
+	AverageRating *float64 `json:"averageRating"`
+	RatingCount   int      `json:"ratingCount"`
+
+	// Credits come with the Rapid feed and are stored apart; movies read
+	// from the catalog leave them empty.
+	Credits []personModel.Credit `json:"credits,omitempty"`
+}
+
+// MovieDetails is a single title with the context shown on its page.
+// TotalCopies sits next to the movie's own availableCopies.
+type MovieDetails struct {
+	Movie
+	TotalCopies int     `json:"totalCopies"`
+	Related     []Movie `json:"related"`
+}
+
+// Availability counts the copies in circulation; lost and damaged copies are
+// left out of the total.
+type Availability struct {
+	AvailableCopies int `json:"availableCopies"`
+	TotalCopies     int `json:"totalCopies"`
+}
+
+const (
+	MatchFullText = "fulltext"
+	MatchFuzzy    = "fuzzy"
+)
+
+// SearchResult is a movie found by a text search. TitleHighlight and Snippet
+// are HTML: text is escaped and the matched words are wrapped in <mark>.
+type SearchResult struct {
+	Movie
+	Rank           float64 `json:"rank"`
+	Match          string  `json:"match"`
+	TitleHighlight string  `json:"titleHighlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type SearchResponse struct {
+	Query string         `json:"query"`
+	Items []SearchResult `json:"items"`
+}
+
+type Suggestion struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+	Year  int    `json:"releaseYear"`
+}
+
+// MovieRequest is the full set of editable fields, used to add a title by
+// hand and to replace one. Lengths follow the movies table.
+type MovieRequest struct {
+	Title       string `json:"title" binding:"required,max=50"`
+	Year        int    `json:"releaseYear" binding:"required,min=1888,max=2100"`
+	Genre       string `json:"genre" binding:"required"`
+	Description string `json:"description" binding:"required,max=200"`
+	ImdbCode    string `json:"imdbCode"`
+	PriceCents  *int   `json:"priceCents" binding:"omitempty,min=0"`
+}
+
+// MoviePatch changes only the fields that are present.
+type MoviePatch struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=50"`
+	Year        *int    `json:"releaseYear" binding:"omitempty,min=1888,max=2100"`
+	Genre       *string `json:"genre" binding:"omitempty,min=1"`
+	Description *string `json:"description" binding:"omitempty,min=1,max=200"`
+	ImdbCode    *string `json:"imdbCode"`
+	PriceCents  *int    `json:"priceCents" binding:"omitempty,min=0"`
+}
+
+// DefaultPriceCents matches the default of movies.price_cents.
+const DefaultPriceCents = 299
+
+func (r MovieRequest) Movie(movieId int) Movie {
+	priceCents := DefaultPriceCents
+	if r.PriceCents != nil {
+		priceCents = *r.PriceCents
+	}
+	return Movie{
+		Id:          movieId,
+		Title:       r.Title,
+		Year:        r.Year,
+		Genre:       r.Genre,
+		Description: r.Description,
+		ImdbCode:    r.ImdbCode,
+		PriceCents:  priceCents,
+	}
+}
+
+func (p MoviePatch) Apply(movie Movie) Movie {
+	if p.Title != nil {
+		movie.Title = *p.Title
+	}
+	if p.Year != nil {
+		movie.Year = *p.Year
+	}
+	if p.Genre != nil {
+		movie.Genre = *p.Genre
+	}
+	if p.Description != nil {
+		movie.Description = *p.Description
+	}
+	if p.ImdbCode != nil {
+		movie.ImdbCode = *p.ImdbCode
+	}
+	if p.PriceCents != nil {
+		movie.PriceCents = *p.PriceCents
+	}
+	return movie
 }
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PageRequest holds the raw listing parameters: sort=title,-releaseYear,
+// limit and the cursor from a previous page.
+type PageRequest struct {
+	Sort   string
+	Limit  int
+	Cursor string
+}
+
+// MoviePage is one page of a listing. Total and Facets cover every match of
+// the filter, not just this page.
+type MoviePage struct {
+	Movies     []Movie
+	Total      int
+	Limit      int
+	NextCursor string
+	PrevCursor string
+	Facets     *Facets
+}
+
+type PageResponse struct {
+	Items  any     `json:"items"`
+	Total  int     `json:"total"`
+	Limit  int     `json:"limit"`
+	Next   string  `json:"next,omitempty"`
+	Prev   string  `json:"prev,omitempty"`
+	Facets *Facets `json:"facets,omitempty"`
+}
+
+var movieFields = map[string]func(Movie) any{
+	"id":              func(m Movie) any { return m.Id },
+	"title":           func(m Movie) any { return m.Title },
+	"releaseYear":     func(m Movie) any { return m.Year },
+	"genre":           func(m Movie) any { return m.Genre },
+	"description":     func(m Movie) any { return m.Description },
+	"imdbCode":        func(m Movie) any { return m.ImdbCode },
+	"priceCents":      func(m Movie) any { return m.PriceCents },
+	"availableCopies": func(m Movie) any { return m.AvailableCopies },
+	"averageRating":   func(m Movie) any { return m.AverageRating },
+	"ratingCount":     func(m Movie) any { return m.RatingCount },
+}
+
+// ParseFields reads a sparse field list such as "id,title". An empty list
+// selects every field.
+func ParseFields(input string) ([]string, error) {
+	if input == "" {
+		return nil, nil
+	}
+	fields := strings.Split(input, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		if _, ok := movieFields[fields[i]]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, fields[i])
+		}
+	}
+	return fields, nil
+}
+
+// Project keeps only the given fields of each movie, or returns the movies
+// unchanged when no fields are given.
+func Project(movies []Movie, fields []string) any {
+	if len(fields) == 0 {
+		return movies
+	}
+	projected := make([]map[string]any, 0, len(movies))
+	for _, movie := range movies {
+		item := make(map[string]any, len(fields))
+		for _, field := range fields {
+			item[field] = movieFields[field](movie)
+		}
+		projected = append(projected, item)
+	}
+	return projected
+}
@@ -1,20 +1,68 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
-	"log"
-	model2 "movie-rent/pkg/cart/model"
+	"html"
+	"movie-rent/pkg/movie/filter"
 	"movie-rent/pkg/movie/model"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
-	InsertMovieSQL       = `INSERT INTO movies(id, title, description, genre, release_year, imdb_code) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	SelectMovies         = `SELECT * FROM movies`
-	SelectMoviesByYear   = `SELECT id, title, release_year, genre, description, imdb_code FROM movies where release_year = $1`
-	InsertCartDetailsSQL = `INSERT INTO movie_carts(user_id, movie_id, movie_name, release_year) VALUES ($1, $2, $3, $4) RETURNING id`
-	SelectCartListSQL    = `SELECT * FROM movie_carts where user_id = $1`
-	SelectMovieByIdSQL   = `SELECT * FROM movies where id = $1`
+	MovieColumns = `id, title, release_year, genre, description, imdb_code, price_cents, ` +
+		`(SELECT COUNT(*) FROM movie_copies c WHERE c.movie_id = movies.id AND c.status = 'available') AS available_copies, average_rating, rating_count`
+	InsertMovieSQL        = `INSERT INTO movies(id, title, description, genre, release_year, imdb_code) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	CreateMovieSQL        = `INSERT INTO movies(title, description, genre, release_year, imdb_code, price_cents) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	UpdateMovieSQL        = `UPDATE movies SET title = $1, description = $2, genre = $3, release_year = $4, imdb_code = $5, price_cents = $6 WHERE id = $7 AND deleted_at IS NULL`
+	SoftDeleteMovieSQL    = `UPDATE movies SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+	SelectMovies          = `SELECT ` + MovieColumns + ` FROM movies WHERE deleted_at IS NULL`
+	CountMoviesSQL        = `SELECT COUNT(*) FROM movies WHERE deleted_at IS NULL`
+	SelectMovieByIdSQL    = `SELECT ` + MovieColumns + ` FROM movies WHERE deleted_at IS NULL AND id = $1`
+	SelectAvailabilitySQL = `SELECT COUNT(*) FILTER (WHERE status = 'available'), COUNT(*) FILTER (WHERE status NOT IN ('lost', 'damaged')) ` +
+		`FROM movie_copies WHERE movie_id = $1`
+	SelectRelatedMoviesSQL = `SELECT ` + MovieColumns + ` FROM movies WHERE deleted_at IS NULL AND genre = $1 AND id <> $2 ` +
+		`ORDER BY ABS(release_year - $3), id LIMIT $4`
+	SearchMoviesSQL = `SELECT ` + MovieColumns + `, ts_rank_cd(search_vector, query) AS rank, ts_headline('english', title, query, $3), ` +
+		`ts_headline('english', description, query, $4) FROM movies, websearch_to_tsquery('english', $1) query ` +
+		`WHERE deleted_at IS NULL AND search_vector @@ query ORDER BY rank DESC, id LIMIT $2`
+	SelectFacetsSQL     = `SELECT ` + facetSelect + ` FROM movies WHERE deleted_at IS NULL`
+	SelectGenreFacetSQL = `) m GROUP BY GROUPING SETS ((decade), (rating_band), (in_stock)) UNION ALL ` + genreFacetSelect
+	GroupGenreFacetSQL  = `) tagged LEFT JOIN movie_genres mg ON mg.movie_id = tagged.id LEFT JOIN genres g ON g.id = mg.genre_id ` +
+		`GROUP BY 2 ORDER BY 1, 3 DESC, 2`
+	SelectPopularitySQL  = `SELECT movie_id, COUNT(*) FROM rentals WHERE rented_at >= $1 GROUP BY movie_id`
+	FuzzySearchMoviesSQL = `SELECT ` + MovieColumns + `, similarity(title, $1) AS rank, title, ` +
+		`ts_headline('english', description, websearch_to_tsquery('english', $1), $3) FROM movies ` +
+		`WHERE deleted_at IS NULL AND title % $1 ORDER BY rank DESC, id LIMIT $2`
+)
+
+// The facet query runs the filter twice. facetSelect counts decade, rating
+// band and stock with GROUPING SETS over a subquery deriving them per movie.
+// genreFacetSelect counts genres apart, since a movie can have several: its
+// canonical genres if tagged, otherwise its genre text. Each subquery ends
+// with the filter and is closed by the constant that follows it.
+const (
+	facetSelect = `CASE WHEN GROUPING(decade) = 0 THEN 'decade' WHEN GROUPING(rating_band) = 0 THEN 'rating' ELSE 'inStock' END, ` +
+		`CASE WHEN GROUPING(decade) = 0 THEN COALESCE(decade::text, '') WHEN GROUPING(rating_band) = 0 THEN rating_band ` +
+		`WHEN in_stock THEN 'inStock' ELSE 'outOfStock' END, ` +
+		`COUNT(*) FROM (SELECT release_year / 10 * 10 AS decade, ` +
+		`CASE WHEN average_rating IS NULL THEN 'unrated' WHEN average_rating < 2 THEN '1-2' WHEN average_rating < 3 THEN '2-3' ` +
+		`WHEN average_rating < 4 THEN '3-4' ELSE '4-5' END AS rating_band, ` +
+		`EXISTS (SELECT 1 FROM movie_copies c WHERE c.movie_id = movies.id AND c.status = 'available') AS in_stock`
+	genreFacetSelect = `SELECT 'genre', COALESCE(g.name, tagged.genre, ''), COUNT(*) FROM (SELECT id, genre FROM movies WHERE deleted_at IS NULL`
+)
+
+// ts_headline marks matches with these control characters rather than tags,
+// so the text can be HTML-escaped before the real tags go in.
+const (
+	markStart              = "\x02"
+	markStop               = "\x03"
+	titleHeadlineOptions   = "StartSel=" + markStart + ", StopSel=" + markStop + ", HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=" + markStart + ", StopSel=" + markStop + ", MaxWords=25, MinWords=10, MaxFragments=2"
 )
 
 type MovieRepository interface {
@@ -22,8 +70,17 @@ type MovieRepository interface {
 	SaveAll(movies []model.Movie) error
 	GetMovies() ([]model.Movie, error)
 	GetMovieBy(movieId int) (model.Movie, error)
-	FetchMoviesByYear(year int) ([]model.Movie, error)
-	FetchMoviesBySearchText(searchType string, searchText string) ([]model.Movie, error)
+	ListMovies(query filter.Query) ([]model.Movie, error)
+	CountMovies(expression filter.Expression) (int, error)
+	GetFacets(expression filter.Expression) (model.Facets, error)
+	CreateMovie(movie model.Movie) (int, error)
+	UpdateMovie(movie model.Movie) error
+	DeleteMovie(movieId int, deletedAt time.Time) error
+	GetAvailability(movieId int) (model.Availability, error)
+	GetRelatedMovies(movie model.Movie, limit int) ([]model.Movie, error)
+	SearchMovies(text string, limit int) ([]model.SearchResult, error)
+	FuzzySearchMovies(text string, limit int) ([]model.SearchResult, error)
+	GetPopularity(since time.Time) (map[int]int, error)
 }
 
 type movieRepo struct {
@@ -58,81 +115,253 @@ func (m movieRepo) SaveAll(movies []model.Movie) error {
 func (m movieRepo) GetMovies() ([]model.Movie, error) {
 	rows, err := m.db.Query(SelectMovies)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to fetch movies: %w", err)
 	}
 	defer rows.Close()
 
 	var movies []model.Movie
 	for rows.Next() {
 		var movie model.Movie
-		err := rows.Scan(&movie.Id, &movie.Title, &movie.Year, &movie.Genre, &movie.Description, &movie.ImdbCode)
+		err := rows.Scan(movieDest(&movie)...)
 		if err != nil {
-			log.Println("Error scanning row:", err)
+			return nil, fmt.Errorf("failed to scan movie: %w", err)
 		}
 		movies = append(movies, movie)
 	}
 
 	fmt.Println("movies fetched. Total movies:", len(movies))
-	return movies, nil
+	return movies, rows.Err()
 }
 
-func (m movieRepo) FetchMoviesByYear(year int) ([]model.Movie, error) {
-	rows, err := m.db.Query(SelectMoviesByYear, year)
+// ListMovies returns up to query.Limit+1 movies in the query's order, so the
+// caller can tell whether another page follows. Pages read backwards come
+// back in reverse order.
+func (m movieRepo) ListMovies(query filter.Query) ([]model.Movie, error) {
+	sqlQuery := SelectMovies
+	where, orderBy, args := filter.CompileListing(query, 1)
+	if where != "" {
+		sqlQuery += " AND " + where
+	}
+	sqlQuery += " ORDER BY " + orderBy + " LIMIT $" + strconv.Itoa(len(args)+1)
+	args = append(args, query.Limit+1)
+
+	rows, err := m.db.Query(sqlQuery, args...)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to list movies: %w", err)
 	}
 	defer rows.Close()
 
-	var movies []model.Movie
+	movies := []model.Movie{}
 	for rows.Next() {
 		var movie model.Movie
-		err := rows.Scan(&movie.Id, &movie.Title, &movie.Year, &movie.Genre, &movie.Description, &movie.ImdbCode)
+		err := rows.Scan(movieDest(&movie)...)
 		if err != nil {
-			log.Println("Error scanning row:", err)
+			return nil, fmt.Errorf("failed to scan movie: %w", err)
 		}
 		movies = append(movies, movie)
 	}
+	return movies, rows.Err()
+}
 
-	fmt.Println("movies fetched. Total movies:", len(movies))
-	return movies, nil
+func (m movieRepo) CountMovies(expression filter.Expression) (int, error) {
+	query := CountMoviesSQL
+	where, args := filter.Compile(expression, 1)
+	if where != "" {
+		query += " AND " + where
+	}
+	var total int
+	if err := m.db.QueryRow(query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count movies: %w", err)
+	}
+	return total, nil
 }
 
-func (m movieRepo) FetchMoviesBySearchText(searchType string, searchText string) ([]model.Movie, error) {
-	query := fmt.Sprintf("SELECT id, title, release_year, genre, description, imdb_code FROM movies WHERE %s ILIKE $1", searchType)
-	rows, err := m.db.Query(query, "%"+searchText+"%")
+// GetFacets counts the movies matching the expression by genre, decade,
+// rating band and stock. The filter's placeholders appear twice in the
+// query but bind once.
+func (m movieRepo) GetFacets(expression filter.Expression) (model.Facets, error) {
+	where, args := filter.Compile(expression, 1)
+	if where != "" {
+		where = " AND " + where
+	}
+	query := SelectFacetsSQL + where + SelectGenreFacetSQL + where + GroupGenreFacetSQL
+	rows, err := m.db.Query(query, args...)
 	if err != nil {
-		log.Fatal(err)
+		return model.Facets{}, fmt.Errorf("failed to count facets: %w", err)
 	}
 	defer rows.Close()
 
-	var movies []model.Movie
+	facets := model.NewFacets()
 	for rows.Next() {
-		var movie model.Movie
-		err := rows.Scan(&movie.Id, &movie.Title, &movie.Year, &movie.Genre, &movie.Description, &movie.ImdbCode)
+		var facet string
+		var bucket model.FacetBucket
+		if err := rows.Scan(&facet, &bucket.Value, &bucket.Count); err != nil {
+			return model.Facets{}, fmt.Errorf("failed to scan facet: %w", err)
+		}
+		if bucket.Value == "" {
+			continue
+		}
+		bucket.Filter = filter.BucketFilter(facet, bucket.Value)
+		switch facet {
+		case model.FacetGenre:
+			facets.Genre = append(facets.Genre, bucket)
+		case model.FacetDecade:
+			facets.Decade = append(facets.Decade, bucket)
+		case model.FacetRating:
+			facets.Rating = append(facets.Rating, bucket)
+		case model.FacetInStock:
+			facets.InStock = append(facets.InStock, bucket)
+		}
+	}
+	return facets, rows.Err()
+}
+
+func (m movieRepo) GetMovieBy(movieId int) (model.Movie, error) {
+	var movie model.Movie
+	err := m.db.QueryRow(SelectMovieByIdSQL, movieId).Scan(movieDest(&movie)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Movie{}, model.ErrMovieNotFound
+	}
+	if err != nil {
+		return model.Movie{}, fmt.Errorf("failed to fetch movie: %w", err)
+	}
+	return movie, nil
+}
+
+// CreateMovie adds a title entered by hand. Its id comes from movies_id_seq,
+// which starts above the ids used by the Rapid feed.
+func (m movieRepo) CreateMovie(movie model.Movie) (int, error) {
+	var id int
+	err := m.db.QueryRow(CreateMovieSQL, movie.Title, movie.Description, movie.Genre, movie.Year, movie.ImdbCode,
+		movie.PriceCents).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create movie: %w", err)
+	}
+	fmt.Println("movie created. Id:", id)
+	return id, nil
+}
+
+func (m movieRepo) UpdateMovie(movie model.Movie) error {
+	res, err := m.db.Exec(UpdateMovieSQL, movie.Title, movie.Description, movie.Genre, movie.Year, movie.ImdbCode,
+		movie.PriceCents, movie.Id)
+	if err != nil {
+		return fmt.Errorf("failed to update movie: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrMovieNotFound
+	}
+	return nil
+}
+
+// DeleteMovie hides the title from the catalog but keeps the row, so rentals
+// and orders that reference it stay intact.
+func (m movieRepo) DeleteMovie(movieId int, deletedAt time.Time) error {
+	res, err := m.db.Exec(SoftDeleteMovieSQL, deletedAt, movieId)
+	if err != nil {
+		return fmt.Errorf("failed to delete movie: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrMovieNotFound
+	}
+	return nil
+}
+
+func (m movieRepo) GetAvailability(movieId int) (model.Availability, error) {
+	var availability model.Availability
+	err := m.db.QueryRow(SelectAvailabilitySQL, movieId).Scan(&availability.AvailableCopies, &availability.TotalCopies)
+	if err != nil {
+		return model.Availability{}, fmt.Errorf("failed to fetch availability: %w", err)
+	}
+	return availability, nil
+}
+
+// GetRelatedMovies returns other titles in the same genre, closest in release
+// year first.
+func (m movieRepo) GetRelatedMovies(movie model.Movie, limit int) ([]model.Movie, error) {
+	rows, err := m.db.Query(SelectRelatedMoviesSQL, movie.Genre, movie.Id, movie.Year, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch related movies: %w", err)
+	}
+	defer rows.Close()
+
+	movies := []model.Movie{}
+	for rows.Next() {
+		var related model.Movie
+		err := rows.Scan(movieDest(&related)...)
 		if err != nil {
-			log.Println("Error scanning row:", err)
+			return nil, fmt.Errorf("failed to scan related movie: %w", err)
 		}
-		movies = append(movies, movie)
+		movies = append(movies, related)
 	}
+	return movies, rows.Err()
+}
 
-	fmt.Println("movies fetched. Total movies:", len(movies))
-	return movies, nil
+// SearchMovies ranks movies whose title, genre or description match the
+// words of the text. The text uses web search syntax: quoted phrases, "or"
+// and a leading "-" to exclude a word.
+func (m movieRepo) SearchMovies(text string, limit int) ([]model.SearchResult, error) {
+	rows, err := m.db.Query(SearchMoviesSQL, text, limit, titleHeadlineOptions, snippetHeadlineOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search movies: %w", err)
+	}
+	return scanSearchResults(rows, model.MatchFullText)
 }
 
-func (m movieRepo) GetMovieBy(movieId int) (model.Movie, error) {
-	rows, err := m.db.Query(SelectMovieByIdSQL, movieId)
+// FuzzySearchMovies ranks movies by trigram similarity of their title to the
+// text, which tolerates typos and split or joined words.
+func (m movieRepo) FuzzySearchMovies(text string, limit int) ([]model.SearchResult, error) {
+	rows, err := m.db.Query(FuzzySearchMoviesSQL, text, limit, snippetHeadlineOptions)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to search movies: %w", err)
 	}
+	return scanSearchResults(rows, model.MatchFuzzy)
+}
+
+// movieDest lists the scan destinations for MovieColumns, followed by any
+// extra columns the query selects.
+func movieDest(movie *model.Movie, extra ...any) []any {
+	dest := []any{&movie.Id, &movie.Title, &movie.Year, &movie.Genre, &movie.Description, &movie.ImdbCode, &movie.PriceCents,
+		&movie.AvailableCopies, &movie.AverageRating, &movie.RatingCount}
+	return append(dest, extra...)
+}
+
+func scanSearchResults(rows *sql.Rows, match string) ([]model.SearchResult, error) {
 	defer rows.Close()
 
-	var movie model.Movie
+	results := []model.SearchResult{}
 	for rows.Next() {
-		var c model2.CartResponse
-		err := rows.Scan(&c.Id, &c.UserId, &c.MovieId, &c.MovieName, &c.ReleaseYear)
+		r := model.SearchResult{Match: match}
+		err := rows.Scan(movieDest(&r.Movie, &r.Rank, &r.TitleHighlight, &r.Snippet)...)
 		if err != nil {
-			fmt.Println("Error scanning row:", err)
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
 		}
+		r.TitleHighlight = highlight(r.TitleHighlight)
+		r.Snippet = highlight(r.Snippet)
+		results = append(results, r)
 	}
-	return movie, nil
+	return results, rows.Err()
+}
+
+func highlight(headline string) string {
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(html.EscapeString(headline))
+}
+
+// GetPopularity counts rentals per movie since the given time. Movies never
+// rented are absent.
+func (m movieRepo) GetPopularity(since time.Time) (map[int]int, error) {
+	rows, err := m.db.Query(SelectPopularitySQL, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch popularity: %w", err)
+	}
+	defer rows.Close()
+
+	popularity := map[int]int{}
+	for rows.Next() {
+		var movieId, rentals int
+		if err := rows.Scan(&movieId, &rentals); err != nil {
+			return nil, fmt.Errorf("failed to scan popularity: %w", err)
+		}
+		popularity[movieId] = rentals
+	}
+	return popularity, rows.Err()
 }
@@ -2,16 +2,19 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/movie/filter"
+	"movie-rent/pkg/movie/model"
 	"testing"
+	"time"
 )
 
 type MovieRepositoryTestSuite struct {
 	suite.Suite
-	db             *sqlx.DB
 	mockedDB       *sqlx.DB
 	mockDB         sqlmock.Sqlmock
 	testRepository MovieRepository
@@ -22,21 +25,240 @@ func TestMovieRepositoryTestSuite(t *testing.T) {
 }
 
 func (suite *MovieRepositoryTestSuite) SetupTest() {
-	testDb, err := sqlx.Open("godror", fmt.Sprintf("host=localhost port=5432 user=postgres password=Sanjit dbname=movie_db sslmode=disable"))
-	if err != nil {
-		panic(fmt.Sprintf("failed to connect database: %v", err))
-	}
 	var sqlDB *sql.DB
+	var err error
 	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
 		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
 	}
-	suite.mockedDB = sqlx.NewDb(sqlDB, "godror")
-	suite.db = testDb
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
 
-	suite.testRepository = NewMovieRepository(suite.db)
+	suite.testRepository = NewMovieRepository(suite.mockedDB)
 }
 
 func (suite *MovieRepositoryTestSuite) TearDownTest() {
-	suite.db.Close()
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *MovieRepositoryTestSuite) Test_CreateMovie_ShouldReturnGeneratedId() {
+	movie := model.Movie{Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: 399}
+	suite.mockDB.ExpectQuery(CreateMovieSQL).WithArgs("Hero", "Wuxia epic", "Action", 2002, "", 399).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1000000))
+
+	id, err := suite.testRepository.CreateMovie(movie)
+
+	suite.Nil(err)
+	suite.Equal(1000000, id)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_UpdateMovie_ShouldReturnNotFoundForDeletedMovie() {
+	movie := model.Movie{Id: 7, Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: 399}
+	suite.mockDB.ExpectExec(UpdateMovieSQL).WithArgs("Hero", "Wuxia epic", "Action", 2002, "", 399, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.UpdateMovie(movie)
+
+	suite.ErrorIs(err, model.ErrMovieNotFound)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_DeleteMovie_ShouldMarkRowDeleted() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(SoftDeleteMovieSQL).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.testRepository.DeleteMovie(7, now)
+
+	suite.Nil(err)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_DeleteMovie_ShouldReturnNotFoundWhenAlreadyDeleted() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(SoftDeleteMovieSQL).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.DeleteMovie(7, now)
+
+	suite.ErrorIs(err, model.ErrMovieNotFound)
+}
+
+var movieRowColumns = []string{"id", "title", "release_year", "genre", "description", "imdb_code", "price_cents", "available_copies", "average_rating", "rating_count"}
+
+func (suite *MovieRepositoryTestSuite) Test_GetMovieBy_ShouldReturnMovie() {
+	suite.mockDB.ExpectQuery(SelectMovieByIdSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows(movieRowColumns).
+		AddRow(7, "Hero", 2002, "Action", "Wuxia epic", "tt0299977", 399, 2, 4.5, 2))
+
+	movie, err := suite.testRepository.GetMovieBy(7)
+
+	average := 4.5
+	suite.Nil(err)
+	suite.Equal(model.Movie{Id: 7, Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic",
+		ImdbCode: "tt0299977", PriceCents: 399, AvailableCopies: 2, AverageRating: &average, RatingCount: 2}, movie)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetMovieBy_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectMovieByIdSQL).WithArgs(7).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetMovieBy(7)
+
+	suite.ErrorIs(err, model.ErrMovieNotFound)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetMovieBy_ShouldWrapDatabaseError() {
+	dbErr := errors.New("connection reset")
+	suite.mockDB.ExpectQuery(SelectMovieByIdSQL).WithArgs(7).WillReturnError(dbErr)
+
+	_, err := suite.testRepository.GetMovieBy(7)
+
+	suite.ErrorIs(err, dbErr)
+	suite.NotErrorIs(err, model.ErrMovieNotFound)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetAvailability_ShouldCountCopiesInCirculation() {
+	suite.mockDB.ExpectQuery(SelectAvailabilitySQL).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"available", "total"}).AddRow(1, 3))
+
+	availability, err := suite.testRepository.GetAvailability(7)
+
+	suite.Nil(err)
+	suite.Equal(model.Availability{AvailableCopies: 1, TotalCopies: 3}, availability)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetRelatedMovies_ShouldMatchGenreAndExcludeMovie() {
+	movie := model.Movie{Id: 7, Genre: "Action", Year: 2002}
+	suite.mockDB.ExpectQuery(SelectRelatedMoviesSQL).WithArgs("Action", 7, 2002, 5).WillReturnRows(sqlmock.NewRows(movieRowColumns).
+		AddRow(8, "House of Flying Daggers", 2004, "Action", "Wuxia romance", "tt0385004", 299, 0, nil, 0))
+
+	related, err := suite.testRepository.GetRelatedMovies(movie, 5)
+
+	suite.Nil(err)
+	suite.Len(related, 1)
+	suite.Equal(8, related[0].Id)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_ListMovies_ShouldBindValuesAsArguments() {
+	expression, err := filter.Parse(`genre:horror title~"it's"`)
+	suite.Require().Nil(err)
+	query, err := filter.NewQuery(expression, "", 20, "")
+	suite.Require().Nil(err)
+	suite.mockDB.ExpectQuery(SelectMovies+` AND (LOWER(genre) = LOWER($1) OR EXISTS (SELECT 1 FROM movie_genres mg JOIN genre_aliases a ON a.genre_id = mg.genre_id `+
+		`WHERE mg.movie_id = movies.id AND a.alias = genre_alias($1))) AND title ILIKE $2 ORDER BY id ASC LIMIT $3`).
+		WithArgs("horror", "%it's%", 21).
+		WillReturnRows(sqlmock.NewRows(movieRowColumns))
+
+	movies, err := suite.testRepository.ListMovies(query)
+
+	suite.Nil(err)
+	suite.Empty(movies)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_ListMovies_ShouldSeekPastCursor() {
+	sort, _ := filter.ParseSort("title,-releaseYear")
+	cursor := filter.CursorAt(model.Movie{Id: 9, Title: "Alien", Year: 1979}, sort, false)
+	query, err := filter.NewQuery(filter.Expression{}, "title,-releaseYear", 2, cursor.Encode())
+	suite.Require().Nil(err)
+	suite.mockDB.ExpectQuery(SelectMovies+` AND ((title > $1) OR (title = $1 AND release_year < $2) OR `+
+		`(title = $1 AND release_year = $2 AND id > $3)) ORDER BY title ASC, release_year DESC, id ASC LIMIT $4`).
+		WithArgs("Alien", 1979, 9, 3).
+		WillReturnRows(sqlmock.NewRows(movieRowColumns).AddRow(10, "Aliens", 1986, "Action", "", "", 299, 1, nil, 0))
+
+	movies, err := suite.testRepository.ListMovies(query)
+
+	suite.Nil(err)
+	suite.Len(movies, 1)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_CountMovies_ShouldApplyFilter() {
+	expression, err := filter.Parse(`year>=1990`)
+	suite.Require().Nil(err)
+	suite.mockDB.ExpectQuery(CountMoviesSQL + ` AND release_year >= $1`).WithArgs(1990).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	total, err := suite.testRepository.CountMovies(expression)
+
+	suite.Nil(err)
+	suite.Equal(42, total)
+}
+
+var searchRowColumns = append(append([]string{}, movieRowColumns...), "rank", "ts_headline", "ts_headline")
+
+func (suite *MovieRepositoryTestSuite) Test_SearchMovies_ShouldEscapeAndHighlightSnippets() {
+	suite.mockDB.ExpectQuery(SearchMoviesSQL).WithArgs("alien", 20, titleHeadlineOptions, snippetHeadlineOptions).
+		WillReturnRows(sqlmock.NewRows(searchRowColumns).AddRow(1, "Alien", 1979, "Horror", "<b>Crew</b> meets an alien", "", 299, 1, nil, 0,
+			0.8, "\x02Alien\x03", "<b>Crew</b> meets an \x02alien\x03"))
+
+	results, err := suite.testRepository.SearchMovies("alien", 20)
+
+	suite.Nil(err)
+	suite.Len(results, 1)
+	suite.Equal(model.MatchFullText, results[0].Match)
+	suite.Equal("<mark>Alien</mark>", results[0].TitleHighlight)
+	suite.Equal("&lt;b&gt;Crew&lt;/b&gt; meets an <mark>alien</mark>", results[0].Snippet)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_FuzzySearchMovies_ShouldRankBySimilarity() {
+	suite.mockDB.ExpectQuery(FuzzySearchMoviesSQL).WithArgs("god father", 20, snippetHeadlineOptions).
+		WillReturnRows(sqlmock.NewRows(searchRowColumns).AddRow(7, "The Godfather", 1972, "Crime", "A mafia saga", "", 299, 0, nil, 0,
+			0.61, "The Godfather", "A mafia saga"))
+
+	results, err := suite.testRepository.FuzzySearchMovies("god father", 20)
+
+	suite.Nil(err)
+	suite.Equal(model.MatchFuzzy, results[0].Match)
+	suite.Equal(0.61, results[0].Rank)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetMovies_ShouldReturnErrorInsteadOfExiting() {
+	suite.mockDB.ExpectQuery(SelectMovies).WillReturnError(fmt.Errorf("connection reset"))
+
+	_, err := suite.testRepository.GetMovies()
+
+	suite.NotNil(err)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetPopularity_ShouldCountRentalsPerMovie() {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectQuery(SelectPopularitySQL).WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"movie_id", "count"}).AddRow(7, 12).AddRow(9, 3))
+
+	popularity, err := suite.testRepository.GetPopularity(since)
+
+	suite.Nil(err)
+	suite.Equal(map[int]int{7: 12, 9: 3}, popularity)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetFacets_ShouldCountBucketsForCombinedFilters() {
+	expression, err := filter.Parse(`genre:horror year>=1980 available>0`)
+	suite.Require().Nil(err)
+	where := ` AND (LOWER(genre) = LOWER($1) OR EXISTS (SELECT 1 FROM movie_genres mg JOIN genre_aliases a ` +
+		`ON a.genre_id = mg.genre_id WHERE mg.movie_id = movies.id AND a.alias = genre_alias($1))) AND release_year >= $2 AND ` +
+		`(SELECT COUNT(*) FROM movie_copies c WHERE c.movie_id = movies.id AND c.status = 'available') > $3`
+	suite.mockDB.ExpectQuery(SelectFacetsSQL+where+SelectGenreFacetSQL+where+GroupGenreFacetSQL).
+		WithArgs("horror", 1980, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "value", "count"}).
+			AddRow("decade", "1980", 4).
+			AddRow("decade", "1990", 2).
+			AddRow("genre", "Horror", 6).
+			AddRow("genre", "Comedy", 1).
+			AddRow("inStock", "inStock", 6).
+			AddRow("rating", "unrated", 5).
+			AddRow("rating", "4-5", 1))
+
+	facets, err := suite.testRepository.GetFacets(expression)
+
+	suite.Nil(err)
+	suite.Equal(model.Facets{
+		Genre:   []model.FacetBucket{{Value: "Horror", Count: 6, Filter: "genre:Horror"}, {Value: "Comedy", Count: 1, Filter: "genre:Comedy"}},
+		Decade:  []model.FacetBucket{{Value: "1980", Count: 4, Filter: "decade:1980"}, {Value: "1990", Count: 2, Filter: "decade:1990"}},
+		Rating:  []model.FacetBucket{{Value: "unrated", Count: 5}, {Value: "4-5", Count: 1, Filter: "rating>=4"}},
+		InStock: []model.FacetBucket{{Value: "inStock", Count: 6, Filter: "available>0"}},
+	}, facets)
+}
+
+func (suite *MovieRepositoryTestSuite) Test_GetFacets_ShouldSkipMoviesWithoutGenre() {
+	suite.mockDB.ExpectQuery(SelectFacetsSQL + SelectGenreFacetSQL + GroupGenreFacetSQL).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "value", "count"}).AddRow("genre", "", 2))
+
+	facets, err := suite.testRepository.GetFacets(filter.Expression{})
+
+	suite.Nil(err)
+	suite.Equal(model.NewFacets(), facets)
 }
@@ -2,82 +2,335 @@ package service
 
 import (
 	"fmt"
+	"movie-rent/constants"
+	genreModel "movie-rent/pkg/genre/model"
+	genreService "movie-rent/pkg/genre/service"
 	"movie-rent/pkg/movie/clients/rapid"
+	"movie-rent/pkg/movie/filter"
 	"movie-rent/pkg/movie/model"
 	"movie-rent/pkg/movie/repository"
-	"strconv"
+	"movie-rent/pkg/movie/suggest"
+	personService "movie-rent/pkg/person/service"
+	"slices"
 	"strings"
+	"time"
 )
 
 // go:generate mockgen -source=pkg/movie/service/movie_service.go -destination=pkg/movie/mocks/movie_service_mock.go -package=mocks
 
 type MovieService interface {
 	AddMovie() error
-	GetMovies() ([]model.Movie, error)
-	GetMovieBy(movieId int) (model.Movie, error)
-	GetFilteredMovies(searchType string, searchText string) ([]model.Movie, error)
+	GetMovieBy(movieId int) (model.MovieDetails, error)
+	FindMovies(filterText string, page model.PageRequest) (model.MoviePage, error)
+	GetFilteredMovies(searchType string, searchText string, page model.PageRequest) (model.MoviePage, error)
+	SearchMovies(text string, limit int) (model.SearchResponse, error)
+	SuggestTitles(prefix string, limit int) ([]model.Suggestion, error)
+	CreateMovie(request model.MovieRequest) (model.Movie, error)
+	UpdateMovie(movieId int, request model.MovieRequest) (model.Movie, error)
+	PatchMovie(movieId int, patch model.MoviePatch) (model.Movie, error)
+	DeleteMovie(movieId int) error
 }
 
+const (
+	relatedTitlesLimit = 5
+	maxSearchLength    = 200
+
+	defaultSuggestions = 10
+	maxSuggestions     = 20
+	maxPrefixLength    = 50
+)
+
 type movieService struct {
 	repository repository.MovieRepository
 	client     rapid.RapidClient
+	titles     suggest.Index
+	genres     genreService.GenreService
+	people     personService.PersonService
 }
 
-func NewMovieService(repository repository.MovieRepository, client rapid.RapidClient) MovieService {
-	return movieService{repository: repository, client: client}
+func NewMovieService(repository repository.MovieRepository, client rapid.RapidClient, titles suggest.Index,
+	genres genreService.GenreService, people personService.PersonService) MovieService {
+	return movieService{repository: repository, client: client, titles: titles, genres: genres, people: people}
 }
 
+// AddMovie imports the whole Rapid catalog, mapping the feed's genre text
+// onto canonical genres. Credits are replaced only for movies the feed sends
+// credits for.
 func (m movieService) AddMovie() error {
 	movies, err := m.client.FetchAllMovies()
 	if err != nil {
 		return fmt.Errorf("failed to fetch movies from rapid api: %w", err)
 	}
+	movies = slices.DeleteFunc(movies, func(movie model.Movie) bool {
+		if movie.Id < constants.ManualMovieIdStart {
+			return false
+		}
+		fmt.Println("skipping rapid movie in the manual id range:", movie.Id)
+		return true
+	})
+	taxonomy, err := m.genres.GetTaxonomy()
+	if err != nil {
+		return fmt.Errorf("failed to load genres: %w", err)
+	}
+	tags := make([][]genreModel.Genre, len(movies))
+	for i := range movies {
+		tags[i] = canonicalizeGenre(&movies[i], taxonomy)
+	}
 
 	err = m.repository.SaveAll(movies)
 	if err != nil {
 		return fmt.Errorf("failed to save movies to rapid api: %s", err.Error())
 	}
+	for i, movie := range movies {
+		m.tagMovie(movie.Id, tags[i])
+		if len(movie.Credits) == 0 {
+			continue
+		}
+		if err := m.people.ImportCredits(movie.Id, movie.Credits); err != nil {
+			fmt.Println("failed to import credits:", err.Error())
+		}
+	}
 
 	fmt.Println("movie inserted successfully")
+	m.titles.Invalidate()
 	return nil
 }
 
-func (m movieService) GetMovies() ([]model.Movie, error) {
-	movies, err := m.repository.GetMovies()
-	if err != nil {
-		fmt.Println("failed to find movies: %w", err.Error())
-		return []model.Movie{}, err
+// canonicalizeGenre replaces the movie's genre text with its primary
+// canonical genre and returns every genre the text named. Text that names
+// no known genre is kept as it is.
+func canonicalizeGenre(movie *model.Movie, taxonomy genreModel.Taxonomy) []genreModel.Genre {
+	genres := taxonomy.Match(movie.Genre)
+	if len(genres) == 0 {
+		fmt.Println("no canonical genre for movie", movie.Id, "genre:", movie.Genre)
+		return genres
 	}
+	movie.Genre = genres[0].Name
+	return genres
+}
 
-	return movies, err
+// tagMovie is best effort: the movie is saved either way and keeps its
+// primary genre text.
+func (m movieService) tagMovie(movieId int, genres []genreModel.Genre) {
+	if err := m.genres.TagMovie(movieId, genres); err != nil {
+		fmt.Println("failed to tag movie genres:", err.Error())
+	}
 }
 
-func (m movieService) GetMovieBy(movieId int) (model.Movie, error) {
+// GetMovieBy looks up one title and adds its availability and related
+// titles. Those extras are best effort: if they fail the movie is still
+// returned.
+func (m movieService) GetMovieBy(movieId int) (model.MovieDetails, error) {
 	movie, err := m.repository.GetMovieBy(movieId)
 	if err != nil {
-		fmt.Println("failed to find movies: %w", err.Error())
-		return model.Movie{}, err
+		fmt.Println("failed to find movie:", err.Error())
+		return model.MovieDetails{}, err
+	}
+
+	details := model.MovieDetails{Movie: movie, Related: []model.Movie{}}
+	if availability, err := m.repository.GetAvailability(movieId); err != nil {
+		fmt.Println("failed to find availability:", err.Error())
+	} else {
+		details.AvailableCopies = availability.AvailableCopies
+		details.TotalCopies = availability.TotalCopies
+	}
+	if related, err := m.repository.GetRelatedMovies(movie, relatedTitlesLimit); err != nil {
+		fmt.Println("failed to find related movies:", err.Error())
+	} else {
+		details.Related = related
 	}
+	return details, nil
+}
 
-	return movie, err
+// FindMovies returns a page of the movies matching a filter expression such
+// as `genre:horror year>=1990`; an empty filter matches every movie. A
+// malformed filter gives ErrInvalidFilter, a bad sort, limit or cursor
+// ErrInvalidQuery.
+func (m movieService) FindMovies(filterText string, page model.PageRequest) (model.MoviePage, error) {
+	expression, err := filter.Parse(filterText)
+	if err != nil {
+		return model.MoviePage{}, err
+	}
+	return m.listMovies(expression, page)
 }
 
-func (m movieService) GetFilteredMovies(searchType string, searchText string) ([]model.Movie, error) {
-	var movies []model.Movie
-	var err error
+// legacySearchType is the filter field and operator an older searchType
+// value stands for.
+type legacySearchType struct {
+	field    string
+	operator filter.Operator
+}
 
-	if strings.Contains(searchType, "year") {
-		fmt.Println("search movie by year")
-		year, _ := strconv.Atoi(searchText)
-		movies, err = m.repository.FetchMoviesByYear(year)
+// legacySearchTypes covers the column names the older query accepted and the
+// fields that must match exactly; anything else is a substring match.
+var legacySearchTypes = map[string]legacySearchType{
+	"year":         {field: "year", operator: filter.OpEquals},
+	"release_year": {field: "year", operator: filter.OpEquals},
+	"imdb":         {field: "imdb", operator: filter.OpEquals},
+	"imdb_code":    {field: "imdb", operator: filter.OpEquals},
+}
+
+// GetFilteredMovies supports the older searchType/searchText query: a
+// substring match on one field, or an exact year or IMDb code.
+func (m movieService) GetFilteredMovies(searchType string, searchText string, page model.PageRequest) (model.MoviePage, error) {
+	legacy, found := legacySearchTypes[strings.ToLower(searchType)]
+	if !found {
+		legacy = legacySearchType{field: searchType, operator: filter.OpContains}
+	}
+	condition, err := filter.NewCondition(legacy.field, legacy.operator, searchText)
+	if err != nil {
+		return model.MoviePage{}, err
+	}
+	return m.listMovies(filter.Expression{Conditions: []filter.Condition{condition}}, page)
+}
+
+func (m movieService) listMovies(expression filter.Expression, page model.PageRequest) (model.MoviePage, error) {
+	query, err := filter.NewQuery(expression, page.Sort, page.Limit, page.Cursor)
+	if err != nil {
+		return model.MoviePage{}, err
+	}
+	movies, err := m.repository.ListMovies(query)
+	if err != nil {
+		fmt.Println("failed to find movies:", err.Error())
+		return model.MoviePage{}, err
+	}
+	total, err := m.repository.CountMovies(expression)
+	if err != nil {
+		fmt.Println("failed to count movies:", err.Error())
+		return model.MoviePage{}, err
+	}
+
+	result := model.MoviePage{Total: total, Limit: query.Limit}
+	if facets, err := m.repository.GetFacets(expression); err != nil {
+		fmt.Println("failed to count facets:", err.Error())
 	} else {
-		movies, err = m.repository.FetchMoviesBySearchText(searchType, searchText)
+		result.Facets = &facets
+	}
+	before := query.Cursor != nil && query.Cursor.Before
+	more := len(movies) > query.Limit
+	if more {
+		movies = movies[:query.Limit]
+	}
+	if before {
+		slices.Reverse(movies)
+	}
+	result.Movies = movies
+	if len(movies) == 0 {
+		return result, nil
+	}
+
+	// Going forwards there is a previous page whenever we started from a
+	// cursor; going backwards there is always a next page.
+	if (!before && more) || before {
+		result.NextCursor = filter.CursorAt(movies[len(movies)-1], query.Sort, false).Encode()
 	}
+	if (before && more) || (!before && query.Cursor != nil) {
+		result.PrevCursor = filter.CursorAt(movies[0], query.Sort, true).Encode()
+	}
+	return result, nil
+}
+
+// SearchMovies runs a ranked full-text search, and falls back to fuzzy title
+// matching when nothing matches, to forgive typos such as "Termnator".
+func (m movieService) SearchMovies(text string, limit int) (model.SearchResponse, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxSearchLength {
+		return model.SearchResponse{}, fmt.Errorf("%w: q must be 1 to %d characters", model.ErrInvalidQuery, maxSearchLength)
+	}
+	if limit == 0 {
+		limit = filter.DefaultLimit
+	}
+	if limit < 1 || limit > filter.MaxLimit {
+		return model.SearchResponse{}, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalidQuery, filter.MaxLimit)
+	}
+
+	results, err := m.repository.SearchMovies(text, limit)
+	if err == nil && len(results) == 0 {
+		results, err = m.repository.FuzzySearchMovies(text, limit)
+	}
+	if err != nil {
+		fmt.Println("failed to search movies:", err.Error())
+		return model.SearchResponse{}, err
+	}
+	return model.SearchResponse{Query: text, Items: results}, nil
+}
+
+// SuggestTitles completes a partly typed title from the in-memory index, so
+// it never touches the database.
+func (m movieService) SuggestTitles(prefix string, limit int) ([]model.Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || len(prefix) > maxPrefixLength {
+		return nil, fmt.Errorf("%w: prefix must be 1 to %d characters", model.ErrInvalidQuery, maxPrefixLength)
+	}
+	if limit == 0 {
+		limit = defaultSuggestions
+	}
+	if limit < 1 || limit > maxSuggestions {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalidQuery, maxSuggestions)
+	}
+	return m.titles.Suggest(prefix, limit), nil
+}
 
+func (m movieService) CreateMovie(request model.MovieRequest) (model.Movie, error) {
+	movie := request.Movie(0)
+	taxonomy, err := m.genres.GetTaxonomy()
 	if err != nil {
-		fmt.Println("failed to find movies: %w", err.Error())
-		return []model.Movie{}, err
+		return model.Movie{}, fmt.Errorf("failed to load genres: %w", err)
 	}
+	genres := canonicalizeGenre(&movie, taxonomy)
+	movieId, err := m.repository.CreateMovie(movie)
+	if err != nil {
+		fmt.Println("failed to create movie:", err.Error())
+		return model.Movie{}, err
+	}
+	movie.Id = movieId
+	m.tagMovie(movieId, genres)
+	m.titles.Invalidate()
+	return movie, nil
+}
 
-	return movies, err
+// UpdateMovie replaces every editable field. The price is kept when the
+// request leaves it out.
+func (m movieService) UpdateMovie(movieId int, request model.MovieRequest) (model.Movie, error) {
+	current, err := m.repository.GetMovieBy(movieId)
+	if err != nil {
+		return model.Movie{}, err
+	}
+	movie := request.Movie(movieId)
+	if request.PriceCents == nil {
+		movie.PriceCents = current.PriceCents
+	}
+	movie.AvailableCopies = current.AvailableCopies
+	return m.saveMovie(movie)
+}
+
+func (m movieService) PatchMovie(movieId int, patch model.MoviePatch) (model.Movie, error) {
+	current, err := m.repository.GetMovieBy(movieId)
+	if err != nil {
+		return model.Movie{}, err
+	}
+	return m.saveMovie(patch.Apply(current))
+}
+
+func (m movieService) saveMovie(movie model.Movie) (model.Movie, error) {
+	taxonomy, err := m.genres.GetTaxonomy()
+	if err != nil {
+		return model.Movie{}, fmt.Errorf("failed to load genres: %w", err)
+	}
+	genres := canonicalizeGenre(&movie, taxonomy)
+	if err := m.repository.UpdateMovie(movie); err != nil {
+		fmt.Println("failed to update movie:", err.Error())
+		return model.Movie{}, err
+	}
+	m.tagMovie(movie.Id, genres)
+	m.titles.Invalidate()
+	return movie, nil
+}
+
+func (m movieService) DeleteMovie(movieId int) error {
+	if err := m.repository.DeleteMovie(movieId, time.Now()); err != nil {
+		return err
+	}
+	m.titles.Invalidate()
+	return nil
 }
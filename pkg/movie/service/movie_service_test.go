@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	genreMocks "movie-rent/pkg/genre/mocks"
+	genreModel "movie-rent/pkg/genre/model"
+	"movie-rent/pkg/movie/filter"
 	"movie-rent/pkg/movie/mocks"
 	"movie-rent/pkg/movie/model"
+	personMocks "movie-rent/pkg/person/mocks"
+	personModel "movie-rent/pkg/person/model"
 	"testing"
 )
 
@@ -14,6 +19,9 @@ type MovieServiceTestSuite struct {
 	mockRapidClient *mocks.MockRapidClient
 	mockController  *gomock.Controller
 	mockRepository  *mocks.MockMovieRepository
+	mockTitleIndex  *mocks.MockIndex
+	mockGenres      *genreMocks.MockGenreService
+	mockPeople      *personMocks.MockPersonService
 
 	movieService MovieService
 }
@@ -26,10 +34,24 @@ func (suite *MovieServiceTestSuite) SetupTest() {
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockRepository = mocks.NewMockMovieRepository(suite.mockController)
 	suite.mockRapidClient = mocks.NewMockRapidClient(suite.mockController)
+	suite.mockTitleIndex = mocks.NewMockIndex(suite.mockController)
+	suite.mockGenres = genreMocks.NewMockGenreService(suite.mockController)
+	suite.mockPeople = personMocks.NewMockPersonService(suite.mockController)
 
-	suite.movieService = NewMovieService(suite.mockRepository, suite.mockRapidClient)
+	suite.movieService = NewMovieService(suite.mockRepository, suite.mockRapidClient, suite.mockTitleIndex, suite.mockGenres,
+		suite.mockPeople)
 }
 
+var (
+	actionGenre         = genreModel.Genre{Id: 1, Slug: "action", Name: "Action"}
+	scienceFictionGenre = genreModel.Genre{Id: 17, Slug: "science-fiction", Name: "Science Fiction"}
+	taxonomy            = genreModel.NewTaxonomy(map[string]genreModel.Genre{
+		"action":         actionGenre,
+		"sciencefiction": scienceFictionGenre,
+		"scifi":          scienceFictionGenre,
+	})
+)
+
 func (suite *MovieServiceTestSuite) TearDownTest() {
 	suite.mockController.Finish()
 }
@@ -54,6 +76,7 @@ func (suite *MovieServiceTestSuite) Test_ReturnErrorWhileStoringMovieToDB() {
 		},
 	}
 	suite.mockRapidClient.EXPECT().FetchAllMovies().Return(movies, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
 
 	suite.mockRepository.EXPECT().SaveAll(movies).Return(fmt.Errorf("error"))
 
@@ -74,21 +97,83 @@ func (suite *MovieServiceTestSuite) Test_ShouldStoreMovieToDBSuccessfully() {
 		},
 	}
 	suite.mockRapidClient.EXPECT().FetchAllMovies().Return(movies, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
 
 	suite.mockRepository.EXPECT().SaveAll(movies).Return(nil)
+	suite.mockGenres.EXPECT().TagMovie(1, []genreModel.Genre{actionGenre}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	err := suite.movieService.AddMovie()
+
+	suite.Nil(err)
+}
+
+func (suite *MovieServiceTestSuite) Test_AddMovie_ShouldMapFeedGenresOntoCanonicalGenres() {
+	movies := []model.Movie{{Id: 1, Title: "Alien", Genre: "Sci-Fi / Horror, Action"}, {Id: 2, Title: "Slacker", Genre: "Mumblecore"}}
+	suite.mockRapidClient.EXPECT().FetchAllMovies().Return(movies, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().SaveAll(gomock.Any()).DoAndReturn(func(saved []model.Movie) error {
+		suite.Equal("Science Fiction", saved[0].Genre)
+		suite.Equal("Mumblecore", saved[1].Genre)
+		return nil
+	}).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(1, []genreModel.Genre{scienceFictionGenre, actionGenre}).Return(nil).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(2, []genreModel.Genre{}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	err := suite.movieService.AddMovie()
+
+	suite.Nil(err)
+}
+
+func (suite *MovieServiceTestSuite) Test_AddMovie_ShouldImportCreditsWhenFeedHasThem() {
+	credits := []personModel.Credit{{Name: "Sigourney Weaver", Role: personModel.RoleActor, Character: "Ripley"}}
+	movies := []model.Movie{{Id: 1, Title: "Alien", Genre: "Sci-Fi", Credits: credits}, {Id: 2, Title: "Aliens", Genre: "Sci-Fi"}}
+	suite.mockRapidClient.EXPECT().FetchAllMovies().Return(movies, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().SaveAll(gomock.Any()).Return(nil).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	suite.mockPeople.EXPECT().ImportCredits(1, credits).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	err := suite.movieService.AddMovie()
+
+	suite.Nil(err)
+}
+
+func (suite *MovieServiceTestSuite) Test_AddMovie_ShouldSkipFeedIdsInManualRange() {
+	movies := []model.Movie{{Id: 1, Title: "Alien", Genre: "Action"}, {Id: 1000000, Title: "Hero", Genre: "Action"}}
+	suite.mockRapidClient.EXPECT().FetchAllMovies().Return(movies, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().SaveAll(gomock.Any()).DoAndReturn(func(saved []model.Movie) error {
+		suite.Len(saved, 1)
+		suite.Equal(1, saved[0].Id)
+		return nil
+	}).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(1, []genreModel.Genre{actionGenre}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
 
 	err := suite.movieService.AddMovie()
 
 	suite.Nil(err)
 }
 
+func (suite *MovieServiceTestSuite) Test_AddMovie_ShouldNotSaveWhenTaxonomyFails() {
+	suite.mockRapidClient.EXPECT().FetchAllMovies().Return([]model.Movie{{Id: 1, Genre: "Action"}}, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(genreModel.Taxonomy{}, fmt.Errorf("error")).Times(1)
+
+	err := suite.movieService.AddMovie()
+
+	suite.NotNil(err)
+}
+
 func (suite *MovieServiceTestSuite) Test_ReturnErrorFetchToFailedMovies() {
-	suite.mockRepository.EXPECT().GetMovies().Return([]model.Movie{}, fmt.Errorf("error"))
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return(nil, fmt.Errorf("error"))
 
-	movies, err := suite.movieService.GetMovies()
+	page, err := suite.movieService.FindMovies("", model.PageRequest{})
 
 	suite.NotNil(err)
-	suite.Equal(0, len(movies))
+	suite.Equal(0, len(page.Movies))
 }
 
 func (suite *MovieServiceTestSuite) Test_ShouldReturnMovies() {
@@ -102,23 +187,80 @@ func (suite *MovieServiceTestSuite) Test_ShouldReturnMovies() {
 			ImdbCode:    "1234",
 		},
 	}
-	suite.mockRepository.EXPECT().GetMovies().Return(expectedMovies, nil).Times(1)
+	expectedQuery := filter.Query{
+		Expression: filter.Expression{Conditions: []filter.Condition{}},
+		Sort:       []filter.SortKey{{Field: "id"}},
+		Limit:      filter.DefaultLimit,
+	}
+	suite.mockRepository.EXPECT().ListMovies(expectedQuery).Return(expectedMovies, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(expectedQuery.Expression).Return(1, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(expectedQuery.Expression).Return(model.NewFacets(), nil).Times(1)
 
-	movies, err := suite.movieService.GetMovies()
+	page, err := suite.movieService.FindMovies("", model.PageRequest{})
 
 	suite.Nil(err)
-	suite.Equal(expectedMovies, movies)
+	facets := model.NewFacets()
+	suite.Equal(model.MoviePage{Movies: expectedMovies, Total: 1, Limit: filter.DefaultLimit, Facets: &facets}, page)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldLinkNextPageWhenMoreRowsExist() {
+	movies := []model.Movie{{Id: 1, Title: "Alien"}, {Id: 2, Title: "Aliens"}, {Id: 3, Title: "Alien 3"}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return(movies, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(gomock.Any()).Return(7, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(gomock.Any()).Return(model.NewFacets(), nil).Times(1)
+
+	page, err := suite.movieService.FindMovies("", model.PageRequest{Sort: "title", Limit: 2})
+
+	suite.Nil(err)
+	suite.Equal(movies[:2], page.Movies)
+	suite.Equal(7, page.Total)
+	suite.Empty(page.PrevCursor)
+	sort, _ := filter.ParseSort("title")
+	next, err := filter.DecodeCursor(page.NextCursor, sort)
+	suite.Nil(err)
+	suite.Equal([]any{"Aliens", 2}, next.Values)
+	suite.False(next.Before)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldRestoreOrderOfPreviousPage() {
+	sort, _ := filter.ParseSort("-releaseYear")
+	cursor := filter.CursorAt(model.Movie{Id: 5, Year: 1990}, sort, true)
+	// Read backwards, the repository returns the rows nearest the cursor first.
+	movies := []model.Movie{{Id: 4, Year: 1991}, {Id: 3, Year: 1992}, {Id: 2, Year: 1993}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return(movies, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(gomock.Any()).Return(9, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(gomock.Any()).Return(model.NewFacets(), nil).Times(1)
+
+	page, err := suite.movieService.FindMovies("", model.PageRequest{Sort: "-releaseYear", Limit: 2, Cursor: cursor.Encode()})
+
+	suite.Nil(err)
+	suite.Equal([]model.Movie{{Id: 3, Year: 1992}, {Id: 4, Year: 1991}}, page.Movies)
+	suite.NotEmpty(page.NextCursor)
+	suite.NotEmpty(page.PrevCursor)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldRejectCursorFromAnotherSort() {
+	sort, _ := filter.ParseSort("title")
+	cursor := filter.CursorAt(model.Movie{Id: 5, Title: "Alien"}, sort, false)
+
+	_, err := suite.movieService.FindMovies("", model.PageRequest{Sort: "-title", Cursor: cursor.Encode()})
+
+	suite.ErrorIs(err, model.ErrInvalidQuery)
 }
 
 func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ReturnErrorFetchToFailedMovies() {
-	searchType := "searchType"
+	searchType := "title"
 	searchText := "searchText"
-	suite.mockRepository.EXPECT().FetchMoviesBySearchText(searchType, searchText).Return([]model.Movie{}, fmt.Errorf("error"))
+	expression := filter.Expression{Conditions: []filter.Condition{{Field: "title", Operator: filter.OpContains, Value: "searchText"}}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).DoAndReturn(func(query filter.Query) ([]model.Movie, error) {
+		suite.Equal(expression, query.Expression)
+		return nil, fmt.Errorf("error")
+	})
 
-	movies, err := suite.movieService.GetFilteredMovies(searchType, searchText)
+	page, err := suite.movieService.GetFilteredMovies(searchType, searchText, model.PageRequest{})
 
 	suite.NotNil(err)
-	suite.Equal(0, len(movies))
+	suite.Equal(0, len(page.Movies))
 }
 
 func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldReturnFilterMoviesByYear() {
@@ -134,12 +276,15 @@ func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldReturnFilterMov
 			ImdbCode:    "1234",
 		},
 	}
-	suite.mockRepository.EXPECT().FetchMoviesByYear(1990).Return(expectedMovies, nil).Times(1)
+	expression := filter.Expression{Conditions: []filter.Condition{{Field: "year", Operator: filter.OpEquals, Value: "1990"}}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return(expectedMovies, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(expression).Return(1, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(expression).Return(model.NewFacets(), nil).Times(1)
 
-	movies, err := suite.movieService.GetFilteredMovies(searchType, searchText)
+	page, err := suite.movieService.GetFilteredMovies(searchType, searchText, model.PageRequest{})
 
 	suite.Nil(err)
-	suite.Equal(expectedMovies, movies)
+	suite.Equal(expectedMovies, page.Movies)
 }
 
 func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldReturnFilterMoviesBySearchText() {
@@ -155,12 +300,90 @@ func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldReturnFilterMov
 			ImdbCode:    "1234",
 		},
 	}
-	suite.mockRepository.EXPECT().FetchMoviesBySearchText(searchType, searchText).Return(expectedMovies, nil).Times(1)
+	expression := filter.Expression{Conditions: []filter.Condition{{Field: "genre", Operator: filter.OpContains, Value: "Action"}}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return(expectedMovies, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(expression).Return(1, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(expression).Return(model.NewFacets(), nil).Times(1)
 
-	movies, err := suite.movieService.GetFilteredMovies(searchType, searchText)
+	page, err := suite.movieService.GetFilteredMovies(searchType, searchText, model.PageRequest{})
 
 	suite.Nil(err)
-	suite.Equal(expectedMovies, movies)
+	suite.Equal(expectedMovies, page.Movies)
+}
+
+func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldMapLegacyColumnNames() {
+	for searchType, expected := range map[string]filter.Condition{
+		"release_year": {Field: "year", Operator: filter.OpEquals, Value: "1990"},
+		"imdb_code":    {Field: "imdb", Operator: filter.OpEquals, Value: "1990"},
+		"imdb":         {Field: "imdb", Operator: filter.OpEquals, Value: "1990"},
+	} {
+		expression := filter.Expression{Conditions: []filter.Condition{expected}}
+		suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return([]model.Movie{}, nil).Times(1)
+		suite.mockRepository.EXPECT().CountMovies(expression).Return(0, nil).Times(1)
+		suite.mockRepository.EXPECT().GetFacets(expression).Return(model.NewFacets(), nil).Times(1)
+
+		_, err := suite.movieService.GetFilteredMovies(searchType, "1990", model.PageRequest{})
+
+		suite.Nil(err, searchType)
+	}
+}
+
+func (suite *MovieServiceTestSuite) Test_GetFilteredMovies_ShouldRejectUnknownSearchType() {
+	page, err := suite.movieService.GetFilteredMovies("title ILIKE '%' OR 1=1 --", "x", model.PageRequest{})
+
+	suite.ErrorIs(err, model.ErrInvalidFilter)
+	suite.Equal(0, len(page.Movies))
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldPassParsedExpressionToRepository() {
+	expression := filter.Expression{Conditions: []filter.Condition{
+		{Field: "genre", Operator: filter.OpEquals, Value: "horror"},
+		{Field: "year", Operator: filter.OpGreaterEqual, Value: "1990"},
+	}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return([]model.Movie{}, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(expression).Return(0, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(expression).Return(model.NewFacets(), nil).Times(1)
+
+	_, err := suite.movieService.FindMovies("genre:horror year>=1990", model.PageRequest{})
+
+	suite.Nil(err)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldCountFacetsForCombinedFilters() {
+	expression := filter.Expression{Conditions: []filter.Condition{
+		{Field: "genre", Operator: filter.OpEquals, Value: "horror"},
+		{Field: "decade", Operator: filter.OpEquals, Value: "1980"},
+		{Field: "available", Operator: filter.OpGreater, Value: "0"},
+	}}
+	facets := model.NewFacets()
+	facets.Genre = []model.FacetBucket{{Value: "Horror", Count: 3, Filter: "genre:Horror"}}
+	facets.Decade = []model.FacetBucket{{Value: "1980", Count: 3, Filter: "decade:1980"}}
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return([]model.Movie{{Id: 1}, {Id: 2}, {Id: 3}}, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(expression).Return(3, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(expression).Return(facets, nil).Times(1)
+
+	page, err := suite.movieService.FindMovies("genre:horror decade:1980 available>0", model.PageRequest{})
+
+	suite.Nil(err)
+	suite.Equal(&facets, page.Facets)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldReturnPageWhenFacetsFail() {
+	suite.mockRepository.EXPECT().ListMovies(gomock.Any()).Return([]model.Movie{{Id: 1}}, nil).Times(1)
+	suite.mockRepository.EXPECT().CountMovies(gomock.Any()).Return(1, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFacets(gomock.Any()).Return(model.Facets{}, fmt.Errorf("error")).Times(1)
+
+	page, err := suite.movieService.FindMovies("", model.PageRequest{})
+
+	suite.Nil(err)
+	suite.Equal(1, page.Total)
+	suite.Nil(page.Facets)
+}
+
+func (suite *MovieServiceTestSuite) Test_FindMovies_ShouldRejectMalformedFilter() {
+	_, err := suite.movieService.FindMovies("year>=nineteen", model.PageRequest{})
+
+	suite.ErrorIs(err, model.ErrInvalidFilter)
 }
 
 func (suite *MovieServiceTestSuite) Test_GetMovieBy_ShouldReturnErrorWhenGetMovieByFailed() {
@@ -174,18 +397,141 @@ func (suite *MovieServiceTestSuite) Test_GetMovieBy_ShouldReturnErrorWhenGetMovi
 
 func (suite *MovieServiceTestSuite) Test_GetMovieBy_ShouldSuccessfullyMovieById() {
 	movieId := 1001
-	expectedMovies := model.Movie{
-		Id:          1,
+	movie := model.Movie{
+		Id:          1001,
 		Title:       "Hero",
 		Year:        1990,
 		Genre:       "Action",
 		Description: "Action movie",
 		ImdbCode:    "1234",
 	}
-	suite.mockRepository.EXPECT().GetMovieBy(movieId).Return(expectedMovies, nil).Times(1)
+	related := []model.Movie{{Id: 1002, Title: "Hard Boiled", Year: 1992, Genre: "Action"}}
+	suite.mockRepository.EXPECT().GetMovieBy(movieId).Return(movie, nil).Times(1)
+	suite.mockRepository.EXPECT().GetAvailability(movieId).Return(model.Availability{AvailableCopies: 1, TotalCopies: 3}, nil).Times(1)
+	suite.mockRepository.EXPECT().GetRelatedMovies(movie, 5).Return(related, nil).Times(1)
 
 	actualResponse, err := suite.movieService.GetMovieBy(movieId)
 
 	suite.Nil(err)
-	suite.Equal(expectedMovies, actualResponse)
+	movie.AvailableCopies = 1
+	suite.Equal(model.MovieDetails{Movie: movie, TotalCopies: 3, Related: related}, actualResponse)
+}
+
+func (suite *MovieServiceTestSuite) Test_GetMovieBy_ShouldReturnMovieWhenEnrichmentFails() {
+	movie := model.Movie{Id: 1001, Title: "Hero", Genre: "Action", AvailableCopies: 2}
+	suite.mockRepository.EXPECT().GetMovieBy(1001).Return(movie, nil).Times(1)
+	suite.mockRepository.EXPECT().GetAvailability(1001).Return(model.Availability{}, fmt.Errorf("error")).Times(1)
+	suite.mockRepository.EXPECT().GetRelatedMovies(movie, 5).Return(nil, fmt.Errorf("error")).Times(1)
+
+	actualResponse, err := suite.movieService.GetMovieBy(1001)
+
+	suite.Nil(err)
+	suite.Equal(2, actualResponse.AvailableCopies)
+	suite.Equal([]model.Movie{}, actualResponse.Related)
+}
+
+func (suite *MovieServiceTestSuite) Test_CreateMovie_ShouldUseDefaultPrice() {
+	request := model.MovieRequest{Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic"}
+	expected := model.Movie{Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: model.DefaultPriceCents}
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().CreateMovie(expected).Return(1000000, nil).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(1000000, []genreModel.Genre{actionGenre}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	movie, err := suite.movieService.CreateMovie(request)
+
+	suite.Nil(err)
+	suite.Equal(1000000, movie.Id)
+}
+
+func (suite *MovieServiceTestSuite) Test_UpdateMovie_ShouldKeepPriceWhenOmitted() {
+	current := model.Movie{Id: 7, Title: "Hreo", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: 499}
+	request := model.MovieRequest{Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic"}
+	expected := model.Movie{Id: 7, Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: 499}
+	suite.mockRepository.EXPECT().GetMovieBy(7).Return(current, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateMovie(expected).Return(nil).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(7, []genreModel.Genre{actionGenre}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	movie, err := suite.movieService.UpdateMovie(7, request)
+
+	suite.Nil(err)
+	suite.Equal(expected, movie)
+}
+
+func (suite *MovieServiceTestSuite) Test_PatchMovie_ShouldChangeOnlyGivenFields() {
+	description := "A nameless assassin's tale"
+	current := model.Movie{Id: 7, Title: "Hero", Year: 2002, Genre: "Action", Description: "Wuxia epic", PriceCents: 499}
+	expected := current
+	expected.Description = description
+	suite.mockRepository.EXPECT().GetMovieBy(7).Return(current, nil).Times(1)
+	suite.mockGenres.EXPECT().GetTaxonomy().Return(taxonomy, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateMovie(expected).Return(nil).Times(1)
+	suite.mockGenres.EXPECT().TagMovie(7, []genreModel.Genre{actionGenre}).Return(nil).Times(1)
+	suite.mockTitleIndex.EXPECT().Invalidate().Times(1)
+
+	movie, err := suite.movieService.PatchMovie(7, model.MoviePatch{Description: &description})
+
+	suite.Nil(err)
+	suite.Equal(expected, movie)
+}
+
+func (suite *MovieServiceTestSuite) Test_PatchMovie_ShouldReturnNotFoundForUnknownMovie() {
+	suite.mockRepository.EXPECT().GetMovieBy(7).Return(model.Movie{}, model.ErrMovieNotFound).Times(1)
+
+	_, err := suite.movieService.PatchMovie(7, model.MoviePatch{})
+
+	suite.ErrorIs(err, model.ErrMovieNotFound)
+}
+
+func (suite *MovieServiceTestSuite) Test_SearchMovies_ShouldReturnFullTextMatches() {
+	results := []model.SearchResult{{Movie: model.Movie{Id: 1, Title: "Alien"}, Match: model.MatchFullText}}
+	suite.mockRepository.EXPECT().SearchMovies("alien", 20).Return(results, nil).Times(1)
+
+	response, err := suite.movieService.SearchMovies("  alien ", 0)
+
+	suite.Nil(err)
+	suite.Equal(model.SearchResponse{Query: "alien", Items: results}, response)
+}
+
+func (suite *MovieServiceTestSuite) Test_SearchMovies_ShouldFallBackToFuzzyMatching() {
+	results := []model.SearchResult{{Movie: model.Movie{Id: 2, Title: "The Terminator"}, Match: model.MatchFuzzy}}
+	suite.mockRepository.EXPECT().SearchMovies("Termnator", 5).Return([]model.SearchResult{}, nil).Times(1)
+	suite.mockRepository.EXPECT().FuzzySearchMovies("Termnator", 5).Return(results, nil).Times(1)
+
+	response, err := suite.movieService.SearchMovies("Termnator", 5)
+
+	suite.Nil(err)
+	suite.Equal(results, response.Items)
+}
+
+func (suite *MovieServiceTestSuite) Test_SearchMovies_ShouldRejectEmptyQuery() {
+	_, err := suite.movieService.SearchMovies(" ", 0)
+
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+}
+
+func (suite *MovieServiceTestSuite) Test_SuggestTitles_ShouldUseDefaultLimit() {
+	suggestions := []model.Suggestion{{Id: 7, Title: "The Godfather", Year: 1972}}
+	suite.mockTitleIndex.EXPECT().Suggest("god", 10).Return(suggestions).Times(1)
+
+	actual, err := suite.movieService.SuggestTitles(" god ", 0)
+
+	suite.Nil(err)
+	suite.Equal(suggestions, actual)
+}
+
+func (suite *MovieServiceTestSuite) Test_SuggestTitles_ShouldRejectLimitAboveMaximum() {
+	_, err := suite.movieService.SuggestTitles("god", 21)
+
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+}
+
+func (suite *MovieServiceTestSuite) Test_DeleteMovie_ShouldNotRefreshSuggestionsWhenDeleteFails() {
+	suite.mockRepository.EXPECT().DeleteMovie(7, gomock.Any()).Return(model.ErrMovieNotFound).Times(1)
+
+	err := suite.movieService.DeleteMovie(7)
+
+	suite.ErrorIs(err, model.ErrMovieNotFound)
 }
@@ -0,0 +1,160 @@
+package suggest
+
+import (
+	"fmt"
+	"movie-rent/pkg/movie/model"
+	"movie-rent/pkg/movie/repository"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/movie/suggest/index.go -destination=pkg/movie/mocks/suggest_index_mock.go -package=mocks
+
+// Index answers title prefix queries from memory. It is built from the
+// catalog and rebuilt in the background after the catalog changes.
+type Index interface {
+	Suggest(prefix string, limit int) []model.Suggestion
+	Refresh() error
+	Invalidate()
+}
+
+// popularityWindow is how far back rentals count towards a title's
+// popularity.
+const popularityWindow = 90 * 24 * time.Hour
+
+type index struct {
+	repository repository.MovieRepository
+	snapshot   atomic.Pointer[snapshot]
+
+	mu         sync.Mutex
+	refreshing bool
+	stale      bool
+}
+
+// snapshot is immutable once built, so queries read it without locking.
+type snapshot struct {
+	titles  []title
+	entries []entry
+}
+
+type title struct {
+	suggestion model.Suggestion
+	popularity int
+}
+
+// entry is one word position of a title: "the godfather" has entries for
+// "the godfather" and "godfather", so "godf" finds it.
+type entry struct {
+	key   string
+	title int
+	start bool
+}
+
+func NewIndex(repository repository.MovieRepository) Index {
+	return &index{repository: repository}
+}
+
+// Refresh rebuilds the index from the catalog and swaps it in.
+func (i *index) Refresh() error {
+	movies, err := i.repository.GetMovies()
+	if err != nil {
+		return err
+	}
+	popularity, err := i.repository.GetPopularity(time.Now().Add(-popularityWindow))
+	if err != nil {
+		return err
+	}
+
+	s := &snapshot{titles: make([]title, 0, len(movies))}
+	for _, movie := range movies {
+		words := strings.Fields(normalize(movie.Title))
+		if len(words) == 0 {
+			continue
+		}
+		s.titles = append(s.titles, title{
+			suggestion: model.Suggestion{Id: movie.Id, Title: movie.Title, Year: movie.Year},
+			popularity: popularity[movie.Id],
+		})
+		for w := range words {
+			s.entries = append(s.entries, entry{key: strings.Join(words[w:], " "), title: len(s.titles) - 1, start: w == 0})
+		}
+	}
+	sort.Slice(s.entries, func(a, b int) bool { return s.entries[a].key < s.entries[b].key })
+
+	i.snapshot.Store(s)
+	fmt.Println("title suggestions rebuilt. Total titles:", len(s.titles))
+	return nil
+}
+
+// Invalidate schedules a rebuild without waiting for it. Changes made while
+// a rebuild runs cause one more rebuild, not one each.
+func (i *index) Invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.refreshing {
+		i.stale = true
+		return
+	}
+	i.refreshing = true
+	go i.refreshUntilCurrent()
+}
+
+func (i *index) refreshUntilCurrent() {
+	for {
+		if err := i.Refresh(); err != nil {
+			fmt.Println("failed to refresh title suggestions:", err.Error())
+		}
+		i.mu.Lock()
+		if !i.stale {
+			i.refreshing = false
+			i.mu.Unlock()
+			return
+		}
+		i.stale = false
+		i.mu.Unlock()
+	}
+}
+
+// Suggest returns up to limit titles with a word starting with prefix, most
+// rented first. Titles that start with the prefix win ties.
+func (i *index) Suggest(prefix string, limit int) []model.Suggestion {
+	s := i.snapshot.Load()
+	prefix = normalize(prefix)
+	if s == nil || prefix == "" {
+		return []model.Suggestion{}
+	}
+
+	matches := map[int]bool{}
+	first := sort.Search(len(s.entries), func(e int) bool { return s.entries[e].key >= prefix })
+	for e := first; e < len(s.entries) && strings.HasPrefix(s.entries[e].key, prefix); e++ {
+		matches[s.entries[e].title] = matches[s.entries[e].title] || s.entries[e].start
+	}
+
+	found := make([]int, 0, len(matches))
+	for t := range matches {
+		found = append(found, t)
+	}
+	sort.Slice(found, func(a, b int) bool {
+		ta, tb := s.titles[found[a]], s.titles[found[b]]
+		if ta.popularity != tb.popularity {
+			return ta.popularity > tb.popularity
+		}
+		if matches[found[a]] != matches[found[b]] {
+			return matches[found[a]]
+		}
+		return ta.suggestion.Title < tb.suggestion.Title
+	})
+
+	suggestions := make([]model.Suggestion, 0, min(limit, len(found)))
+	for _, t := range found[:min(limit, len(found))] {
+		suggestions = append(suggestions, s.titles[t].suggestion)
+	}
+	return suggestions
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
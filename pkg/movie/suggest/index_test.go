@@ -0,0 +1,92 @@
+package suggest
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/movie/mocks"
+	"movie-rent/pkg/movie/model"
+	"testing"
+)
+
+type IndexTestSuite struct {
+	suite.Suite
+	mockRepository *mocks.MockMovieRepository
+	controller     *gomock.Controller
+
+	index Index
+}
+
+func TestIndexTestSuite(t *testing.T) {
+	suite.Run(t, new(IndexTestSuite))
+}
+
+func (suite *IndexTestSuite) SetupTest() {
+	suite.controller = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockMovieRepository(suite.controller)
+	suite.index = NewIndex(suite.mockRepository)
+}
+
+func (suite *IndexTestSuite) TearDownTest() {
+	suite.controller.Finish()
+}
+
+func (suite *IndexTestSuite) load(popularity map[int]int) {
+	movies := []model.Movie{
+		{Id: 1, Title: "The Godfather", Year: 1972},
+		{Id: 2, Title: "The Godfather Part II", Year: 1974},
+		{Id: 3, Title: "Godzilla", Year: 1954},
+		{Id: 4, Title: "Good Will Hunting", Year: 1997},
+		{Id: 5, Title: "Alien", Year: 1979},
+	}
+	suite.mockRepository.EXPECT().GetMovies().Return(movies, nil).Times(1)
+	suite.mockRepository.EXPECT().GetPopularity(gomock.Any()).Return(popularity, nil).Times(1)
+	suite.Require().Nil(suite.index.Refresh())
+}
+
+func ids(suggestions []model.Suggestion) []int {
+	result := make([]int, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		result = append(result, suggestion.Id)
+	}
+	return result
+}
+
+func (suite *IndexTestSuite) Test_Suggest_ShouldReturnNothingBeforeFirstRefresh() {
+	suite.Empty(suite.index.Suggest("god", 10))
+}
+
+func (suite *IndexTestSuite) Test_Suggest_ShouldMatchAnyWordStart() {
+	suite.load(map[int]int{})
+
+	suite.Equal([]int{3, 1, 2}, ids(suite.index.Suggest("GOD", 10)))
+}
+
+func (suite *IndexTestSuite) Test_Suggest_ShouldRankByPopularity() {
+	suite.load(map[int]int{2: 40, 1: 15, 3: 1})
+
+	suite.Equal([]int{2, 1, 3}, ids(suite.index.Suggest("god", 10)))
+}
+
+func (suite *IndexTestSuite) Test_Suggest_ShouldMatchAcrossWords() {
+	suite.load(map[int]int{})
+
+	suite.Equal([]int{1, 2}, ids(suite.index.Suggest("godfather  ", 10)))
+	suite.Equal([]int{2}, ids(suite.index.Suggest("godfather part", 10)))
+}
+
+func (suite *IndexTestSuite) Test_Suggest_ShouldApplyLimit() {
+	suite.load(map[int]int{1: 5})
+
+	suite.Equal([]int{1, 3}, ids(suite.index.Suggest("g", 2)))
+}
+
+func (suite *IndexTestSuite) Test_Refresh_ShouldKeepPreviousIndexWhenCatalogFails() {
+	suite.load(map[int]int{})
+	suite.mockRepository.EXPECT().GetMovies().Return(nil, fmt.Errorf("connection reset")).Times(1)
+
+	err := suite.index.Refresh()
+
+	suite.NotNil(err)
+	suite.Equal([]int{5}, ids(suite.index.Suggest("ali", 10)))
+}
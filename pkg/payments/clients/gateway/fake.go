@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DeclinedToken is a payment token the fake gateway always declines.
+const DeclinedToken = "tok_declined"
+
+type fakeGateway struct {
+	mu           sync.Mutex
+	transactions map[string]*Result
+	references   map[string]string
+	replies      map[string]reply
+	nextId       int
+}
+
+type reply struct {
+	result Result
+	err    error
+}
+
+// NewFakeGateway returns an in-memory gateway for tests and local
+// development. It approves every token except DeclinedToken.
+func NewFakeGateway() PaymentGateway {
+	return &fakeGateway{transactions: map[string]*Result{}, references: map[string]string{}, replies: map[string]reply{}}
+}
+
+func (f *fakeGateway) Authorize(request AuthorizeRequest, idempotencyKey string) (Result, error) {
+	return f.once("authorize:"+idempotencyKey, func() (Result, error) {
+		if request.PaymentToken == DeclinedToken {
+			return Result{}, ErrDeclined
+		}
+		f.nextId++
+		tx := &Result{Id: fmt.Sprintf("fake_%d", f.nextId), Status: StatusAuthorized, AmountCents: request.AmountCents}
+		f.transactions[tx.Id] = tx
+		f.references[request.Reference] = tx.Id
+		return *tx, nil
+	})
+}
+
+func (f *fakeGateway) Lookup(reference string) (Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tx, ok := f.transactions[f.references[reference]]
+	if !ok {
+		return Result{}, ErrUnknownTransaction
+	}
+	return *tx, nil
+}
+
+func (f *fakeGateway) Capture(transactionId string, idempotencyKey string) (Result, error) {
+	return f.once("capture:"+idempotencyKey, func() (Result, error) {
+		return f.transition(transactionId, StatusAuthorized, StatusCaptured)
+	})
+}
+
+func (f *fakeGateway) Void(transactionId string, idempotencyKey string) (Result, error) {
+	return f.once("void:"+idempotencyKey, func() (Result, error) {
+		return f.transition(transactionId, StatusAuthorized, StatusVoided)
+	})
+}
+
+func (f *fakeGateway) Refund(transactionId string, amountCents int, idempotencyKey string) (Result, error) {
+	return f.once("refund:"+idempotencyKey, func() (Result, error) {
+		tx, ok := f.transactions[transactionId]
+		if !ok {
+			return Result{}, ErrUnknownTransaction
+		}
+		if tx.Status != StatusCaptured || amountCents > tx.AmountCents {
+			return Result{}, ErrInvalidState
+		}
+		tx.Status = StatusRefunded
+		return *tx, nil
+	})
+}
+
+// once replays the stored reply for a key that was already used, failures
+// included, the way a real processor does.
+func (f *fakeGateway) once(key string, call func() (Result, error)) (Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r, ok := f.replies[key]; ok {
+		return r.result, r.err
+	}
+	result, err := call()
+	f.replies[key] = reply{result: result, err: err}
+	return result, err
+}
+
+func (f *fakeGateway) transition(transactionId string, from string, to string) (Result, error) {
+	tx, ok := f.transactions[transactionId]
+	if !ok {
+		return Result{}, ErrUnknownTransaction
+	}
+	if tx.Status != from {
+		return Result{}, ErrInvalidState
+	}
+	tx.Status = to
+	return *tx, nil
+}
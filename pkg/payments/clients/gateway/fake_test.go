@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type FakeGatewayTestSuite struct {
+	suite.Suite
+	gateway PaymentGateway
+}
+
+func TestFakeGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(FakeGatewayTestSuite))
+}
+
+func (suite *FakeGatewayTestSuite) SetupTest() {
+	suite.gateway = NewFakeGateway()
+}
+
+func (suite *FakeGatewayTestSuite) Test_Authorize_ShouldReplaySameKey() {
+	request := AuthorizeRequest{AmountCents: 500, Currency: "USD", PaymentToken: "tok_visa"}
+
+	first, err := suite.gateway.Authorize(request, "key-1")
+	suite.Nil(err)
+	second, err := suite.gateway.Authorize(request, "key-1")
+	suite.Nil(err)
+	other, err := suite.gateway.Authorize(request, "key-2")
+	suite.Nil(err)
+
+	suite.Equal(first, second)
+	suite.NotEqual(first.Id, other.Id)
+}
+
+func (suite *FakeGatewayTestSuite) Test_Authorize_ShouldDeclineToken() {
+	_, err := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500, PaymentToken: DeclinedToken}, "key-1")
+
+	suite.ErrorIs(err, ErrDeclined)
+}
+
+func (suite *FakeGatewayTestSuite) Test_Capture_ShouldOnlyCaptureAuthorizedTransaction() {
+	auth, _ := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500, PaymentToken: "tok_visa"}, "key-1")
+	_, err := suite.gateway.Void(auth.Id, "key-1:void")
+	suite.Nil(err)
+
+	_, err = suite.gateway.Capture(auth.Id, "key-1:capture")
+
+	suite.ErrorIs(err, ErrInvalidState)
+}
+
+func (suite *FakeGatewayTestSuite) Test_Refund_ShouldRefundCapturedTransaction() {
+	auth, _ := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500, PaymentToken: "tok_visa"}, "key-1")
+	_, err := suite.gateway.Capture(auth.Id, "key-1:capture")
+	suite.Nil(err)
+
+	result, err := suite.gateway.Refund(auth.Id, 500, "key-1:refund")
+
+	suite.Nil(err)
+	suite.Equal(StatusRefunded, result.Status)
+}
+
+func (suite *FakeGatewayTestSuite) Test_Capture_ShouldReturnErrorForUnknownTransaction() {
+	_, err := suite.gateway.Capture("fake_99", "key-1:capture")
+
+	suite.ErrorIs(err, ErrUnknownTransaction)
+}
+
+func (suite *FakeGatewayTestSuite) Test_Lookup_ShouldFindAuthorizationByReference() {
+	auth, _ := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500, PaymentToken: "tok_visa", Reference: "key-1"}, "key-1")
+
+	found, err := suite.gateway.Lookup("key-1")
+	suite.Nil(err)
+	_, err = suite.gateway.Lookup("key-2")
+
+	suite.Equal(auth, found)
+	suite.ErrorIs(err, ErrUnknownTransaction)
+}
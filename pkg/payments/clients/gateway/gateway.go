@@ -0,0 +1,41 @@
+package gateway
+
+import "errors"
+
+const (
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusVoided     = "voided"
+	StatusRefunded   = "refunded"
+)
+
+var (
+	ErrDeclined           = errors.New("payment declined")
+	ErrUnknownTransaction = errors.New("unknown transaction")
+	ErrInvalidState       = errors.New("transaction is not in a valid state for this operation")
+)
+
+type AuthorizeRequest struct {
+	AmountCents  int    `json:"amountCents"`
+	Currency     string `json:"currency"`
+	PaymentToken string `json:"paymentToken"`
+	Reference    string `json:"reference"`
+}
+
+type Result struct {
+	Id          string `json:"id"`
+	Status      string `json:"status"`
+	AmountCents int    `json:"amountCents"`
+}
+
+// PaymentGateway talks to the card processor. Every call carries an
+// idempotency key, and a repeated key returns the original result instead of
+// moving money again. Lookup finds the authorization made for a reference,
+// for attempts that never got an answer.
+type PaymentGateway interface {
+	Authorize(request AuthorizeRequest, idempotencyKey string) (Result, error)
+	Lookup(reference string) (Result, error)
+	Capture(transactionId string, idempotencyKey string) (Result, error)
+	Void(transactionId string, idempotencyKey string) (Result, error)
+	Refund(transactionId string, amountCents int, idempotencyKey string) (Result, error)
+}
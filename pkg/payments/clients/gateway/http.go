@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type httpGateway struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewHTTPGateway returns a gateway backed by a processor's REST API at
+// baseURL.
+func NewHTTPGateway(http *http.Client, baseURL string, apiKey string) PaymentGateway {
+	return httpGateway{http: http, baseURL: baseURL, apiKey: apiKey}
+}
+
+func (g httpGateway) Authorize(request AuthorizeRequest, idempotencyKey string) (Result, error) {
+	return g.post("/authorizations", request, idempotencyKey)
+}
+
+func (g httpGateway) Lookup(reference string) (Result, error) {
+	req, err := http.NewRequest(http.MethodGet, g.baseURL+"/authorizations?reference="+url.QueryEscape(reference), nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to build gateway request: %w", err)
+	}
+	return g.do(req)
+}
+
+func (g httpGateway) Capture(transactionId string, idempotencyKey string) (Result, error) {
+	return g.post("/authorizations/"+url.PathEscape(transactionId)+"/capture", nil, idempotencyKey)
+}
+
+func (g httpGateway) Void(transactionId string, idempotencyKey string) (Result, error) {
+	return g.post("/authorizations/"+url.PathEscape(transactionId)+"/void", nil, idempotencyKey)
+}
+
+func (g httpGateway) Refund(transactionId string, amountCents int, idempotencyKey string) (Result, error) {
+	body := map[string]int{"amountCents": amountCents}
+	return g.post("/charges/"+url.PathEscape(transactionId)+"/refunds", body, idempotencyKey)
+}
+
+func (g httpGateway) post(path string, body any, idempotencyKey string) (Result, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to encode gateway request: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, g.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to build gateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	return g.do(req)
+}
+
+func (g httpGateway) do(req *http.Request) (Result, error) {
+	req.Header.Set("Authorization", "Bearer "+g.apiKey)
+
+	resp, err := g.http.Do(req)
+	if err != nil {
+		fmt.Println("failed to reach payment gateway:", err.Error())
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusPaymentRequired:
+		return Result{}, ErrDeclined
+	case http.StatusNotFound:
+		return Result{}, ErrUnknownTransaction
+	case http.StatusConflict:
+		return Result{}, ErrInvalidState
+	default:
+		fmt.Println("Payment gateway error:", resp.Status)
+		return Result{}, fmt.Errorf("payment gateway error: %s", resp.Status)
+	}
+
+	var result Result
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		fmt.Println("Failed to parse gateway response:", err)
+		return Result{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type HTTPGatewayTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	handler http.HandlerFunc
+	gateway PaymentGateway
+}
+
+func TestHTTPGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPGatewayTestSuite))
+}
+
+func (suite *HTTPGatewayTestSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.handler(w, r)
+	}))
+	suite.gateway = NewHTTPGateway(suite.server.Client(), suite.server.URL, "secret")
+}
+
+func (suite *HTTPGatewayTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Authorize_ShouldSendKeyAndDecodeResult() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("/authorizations", r.URL.Path)
+		suite.Equal("Bearer secret", r.Header.Get("Authorization"))
+		suite.Equal("key-1", r.Header.Get("Idempotency-Key"))
+		var request AuthorizeRequest
+		suite.Nil(json.NewDecoder(r.Body).Decode(&request))
+		suite.Equal(500, request.AmountCents)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"ch_1","status":"authorized","amountCents":500}`))
+	}
+
+	result, err := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500, Currency: "USD", PaymentToken: "tok_visa"}, "key-1")
+
+	suite.Nil(err)
+	suite.Equal(Result{Id: "ch_1", Status: StatusAuthorized, AmountCents: 500}, result)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Authorize_ShouldReturnDeclined() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	}
+
+	_, err := suite.gateway.Authorize(AuthorizeRequest{AmountCents: 500}, "key-1")
+
+	suite.ErrorIs(err, ErrDeclined)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Capture_ShouldPostToTransaction() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("/authorizations/ch_1/capture", r.URL.Path)
+		suite.Equal("key-1:capture", r.Header.Get("Idempotency-Key"))
+		_, _ = w.Write([]byte(`{"id":"ch_1","status":"captured","amountCents":500}`))
+	}
+
+	result, err := suite.gateway.Capture("ch_1", "key-1:capture")
+
+	suite.Nil(err)
+	suite.Equal(StatusCaptured, result.Status)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Void_ShouldReturnInvalidStateOnConflict() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}
+
+	_, err := suite.gateway.Void("ch_1", "key-1:void")
+
+	suite.ErrorIs(err, ErrInvalidState)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Refund_ShouldReturnErrorOnServerFailure() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	_, err := suite.gateway.Refund("ch_1", 500, "key-1:refund")
+
+	suite.NotNil(err)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Lookup_ShouldQueryByReference() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		suite.Equal("/authorizations", r.URL.Path)
+		suite.Equal("key-1", r.URL.Query().Get("reference"))
+		suite.Equal("Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"id":"ch_1","status":"authorized","amountCents":500}`))
+	}
+
+	result, err := suite.gateway.Lookup("key-1")
+
+	suite.Nil(err)
+	suite.Equal("ch_1", result.Id)
+}
+
+func (suite *HTTPGatewayTestSuite) Test_Lookup_ShouldReturnUnknownTransactionWhenNotFound() {
+	suite.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	_, err := suite.gateway.Lookup("key-1")
+
+	suite.ErrorIs(err, ErrUnknownTransaction)
+}
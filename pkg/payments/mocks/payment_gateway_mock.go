@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/payments/clients/gateway/gateway.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gateway "movie-rent/pkg/payments/clients/gateway"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentGateway is a mock of PaymentGateway interface.
+type MockPaymentGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentGatewayMockRecorder
+}
+
+// MockPaymentGatewayMockRecorder is the mock recorder for MockPaymentGateway.
+type MockPaymentGatewayMockRecorder struct {
+	mock *MockPaymentGateway
+}
+
+// NewMockPaymentGateway creates a new mock instance.
+func NewMockPaymentGateway(ctrl *gomock.Controller) *MockPaymentGateway {
+	mock := &MockPaymentGateway{ctrl: ctrl}
+	mock.recorder = &MockPaymentGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentGateway) EXPECT() *MockPaymentGatewayMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockPaymentGateway) Authorize(request gateway.AuthorizeRequest, idempotencyKey string) (gateway.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", request, idempotencyKey)
+	ret0, _ := ret[0].(gateway.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockPaymentGatewayMockRecorder) Authorize(request, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockPaymentGateway)(nil).Authorize), request, idempotencyKey)
+}
+
+// Capture mocks base method.
+func (m *MockPaymentGateway) Capture(transactionId, idempotencyKey string) (gateway.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", transactionId, idempotencyKey)
+	ret0, _ := ret[0].(gateway.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockPaymentGatewayMockRecorder) Capture(transactionId, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockPaymentGateway)(nil).Capture), transactionId, idempotencyKey)
+}
+
+// Lookup mocks base method.
+func (m *MockPaymentGateway) Lookup(reference string) (gateway.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", reference)
+	ret0, _ := ret[0].(gateway.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockPaymentGatewayMockRecorder) Lookup(reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockPaymentGateway)(nil).Lookup), reference)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentGateway) Refund(transactionId string, amountCents int, idempotencyKey string) (gateway.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", transactionId, amountCents, idempotencyKey)
+	ret0, _ := ret[0].(gateway.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentGatewayMockRecorder) Refund(transactionId, amountCents, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentGateway)(nil).Refund), transactionId, amountCents, idempotencyKey)
+}
+
+// Void mocks base method.
+func (m *MockPaymentGateway) Void(transactionId, idempotencyKey string) (gateway.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", transactionId, idempotencyKey)
+	ret0, _ := ret[0].(gateway.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockPaymentGatewayMockRecorder) Void(transactionId, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockPaymentGateway)(nil).Void), transactionId, idempotencyKey)
+}
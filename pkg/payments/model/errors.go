@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+var (
+	ErrPaymentNotFound       = errors.New("payment not found")
+	ErrPaymentDeclined       = errors.New("payment declined")
+	ErrPaymentVoided         = errors.New("payment was voided, retry with a new idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used for a different payment")
+	ErrMissingIdempotencyKey = errors.New("Idempotency-Key header is required")
+	ErrPaymentNotCapturable  = errors.New("payment is not authorized")
+	ErrPaymentLinked         = errors.New("payment already paid for an order")
+)
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	StatusPending    = "pending"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusDeclined   = "declined"
+	StatusVoided     = "voided"
+	StatusRefunded   = "refunded"
+)
+
+// Payment is one charge attempt, keyed by the client's idempotency key.
+type Payment struct {
+	Id             int       `json:"id"`
+	UserId         int       `json:"userId"`
+	OrderId        *int      `json:"orderId"`
+	IdempotencyKey string    `json:"-"`
+	AmountCents    int       `json:"amountCents"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	GatewayRef     *string   `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
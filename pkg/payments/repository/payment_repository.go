@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/payments/model"
+	"time"
+)
+
+const (
+	PaymentColumns         = `id, user_id, order_id, idempotency_key, amount_cents, currency, status, gateway_ref, created_at, updated_at`
+	InsertPaymentSQL       = `INSERT INTO payments(user_id, idempotency_key, amount_cents, currency, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $6) ON CONFLICT (idempotency_key) DO NOTHING RETURNING ` + PaymentColumns
+	SelectPaymentByKeySQL  = `SELECT ` + PaymentColumns + ` FROM payments WHERE idempotency_key = $1`
+	SelectPaymentByIdSQL   = `SELECT ` + PaymentColumns + ` FROM payments WHERE id = $1`
+	LockPaymentSQL         = `SELECT ` + PaymentColumns + ` FROM payments WHERE id = $1 FOR UPDATE`
+	UpdatePaymentStatusSQL = `UPDATE payments SET status = $1, gateway_ref = COALESCE($2, gateway_ref), updated_at = $3 WHERE id = $4`
+)
+
+type PaymentRepository interface {
+	CreatePayment(payment model.Payment) (model.Payment, bool, error)
+	GetPaymentByKey(idempotencyKey string) (model.Payment, error)
+	GetPayment(paymentId int) (model.Payment, error)
+	UpdateStatus(paymentId int, status string, gatewayRef *string, updatedAt time.Time) error
+	VoidPayment(paymentId int, release func(payment model.Payment) error) error
+}
+
+type paymentRepo struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) PaymentRepository {
+	return &paymentRepo{db: db}
+}
+
+// CreatePayment records a new attempt, or returns the existing one for the
+// idempotency key. The flag reports whether the payment was created.
+func (m paymentRepo) CreatePayment(p model.Payment) (model.Payment, bool, error) {
+	created, err := scanPayment(m.db.QueryRow(InsertPaymentSQL, p.UserId, p.IdempotencyKey, p.AmountCents, p.Currency, p.Status, p.CreatedAt))
+	if err == nil {
+		fmt.Println("Successfully inserted payment. Id:", created.Id)
+		return created, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return model.Payment{}, false, fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	existing, err := m.GetPaymentByKey(p.IdempotencyKey)
+	if err != nil {
+		return model.Payment{}, false, err
+	}
+	return existing, false, nil
+}
+
+func (m paymentRepo) GetPaymentByKey(idempotencyKey string) (model.Payment, error) {
+	return m.getPayment(SelectPaymentByKeySQL, idempotencyKey)
+}
+
+func (m paymentRepo) GetPayment(paymentId int) (model.Payment, error) {
+	return m.getPayment(SelectPaymentByIdSQL, paymentId)
+}
+
+func (m paymentRepo) getPayment(query string, arg any) (model.Payment, error) {
+	payment, err := scanPayment(m.db.QueryRow(query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Payment{}, model.ErrPaymentNotFound
+	}
+	if err != nil {
+		return model.Payment{}, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+	return payment, nil
+}
+
+func (m paymentRepo) UpdateStatus(paymentId int, status string, gatewayRef *string, updatedAt time.Time) error {
+	if _, err := m.db.Exec(UpdatePaymentStatusSQL, status, gatewayRef, updatedAt, paymentId); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	return nil
+}
+
+// VoidPayment marks an open payment voided once release has let go of the
+// hold at the gateway. The row stays locked throughout, so an order cannot be
+// linked to the payment halfway through, and a payment that already paid for
+// an order is refused. Payments that are already settled are left alone.
+func (m paymentRepo) VoidPayment(paymentId int, release func(payment model.Payment) error) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin void: %w", err)
+	}
+	defer tx.Rollback()
+
+	payment, err := scanPayment(tx.QueryRow(LockPaymentSQL, paymentId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrPaymentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock payment: %w", err)
+	}
+	if payment.OrderId != nil {
+		return model.ErrPaymentLinked
+	}
+	if payment.Status != model.StatusAuthorized && payment.Status != model.StatusPending {
+		return nil
+	}
+
+	if err = release(payment); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(UpdatePaymentStatusSQL, model.StatusVoided, nil, time.Now(), paymentId); err != nil {
+		return fmt.Errorf("failed to void payment: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit void: %w", err)
+	}
+	fmt.Println("Successfully voided payment. Id:", paymentId)
+	return nil
+}
+
+func scanPayment(row postgres.Scanner) (model.Payment, error) {
+	var p model.Payment
+	err := row.Scan(&p.Id, &p.UserId, &p.OrderId, &p.IdempotencyKey, &p.AmountCents, &p.Currency, &p.Status,
+		&p.GatewayRef, &p.CreatedAt, &p.UpdatedAt)
+	return p, err
+}
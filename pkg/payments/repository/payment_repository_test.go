@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/payments/model"
+	"testing"
+	"time"
+)
+
+type PaymentRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository PaymentRepository
+}
+
+func TestPaymentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PaymentRepositoryTestSuite))
+}
+
+func (suite *PaymentRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewPaymentRepository(suite.mockedDB)
+}
+
+func (suite *PaymentRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func paymentRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "user_id", "order_id", "idempotency_key", "amount_cents", "currency", "status",
+		"gateway_ref", "created_at", "updated_at"})
+}
+
+func (suite *PaymentRepositoryTestSuite) Test_CreatePayment_ShouldInsertNewPayment() {
+	now := time.Now()
+	payment := model.Payment{UserId: 1001, IdempotencyKey: "key-1", AmountCents: 500, Currency: "USD", Status: model.StatusPending, CreatedAt: now}
+	suite.mockDB.ExpectQuery(InsertPaymentSQL).WithArgs(1001, "key-1", 500, "USD", model.StatusPending, now).
+		WillReturnRows(paymentRows().AddRow(5, 1001, nil, "key-1", 500, "USD", model.StatusPending, nil, now, now))
+
+	created, isNew, err := suite.testRepository.CreatePayment(payment)
+
+	suite.Nil(err)
+	suite.True(isNew)
+	suite.Equal(5, created.Id)
+}
+
+func (suite *PaymentRepositoryTestSuite) Test_CreatePayment_ShouldReturnExistingPaymentForKey() {
+	now := time.Now()
+	payment := model.Payment{UserId: 1001, IdempotencyKey: "key-1", AmountCents: 500, Currency: "USD", Status: model.StatusPending, CreatedAt: now}
+	suite.mockDB.ExpectQuery(InsertPaymentSQL).WithArgs(1001, "key-1", 500, "USD", model.StatusPending, now).
+		WillReturnRows(paymentRows())
+	suite.mockDB.ExpectQuery(SelectPaymentByKeySQL).WithArgs("key-1").
+		WillReturnRows(paymentRows().AddRow(5, 1001, 10, "key-1", 500, "USD", model.StatusCaptured, "ch_1", now, now))
+
+	existing, isNew, err := suite.testRepository.CreatePayment(payment)
+
+	suite.Nil(err)
+	suite.False(isNew)
+	suite.Equal(model.StatusCaptured, existing.Status)
+	suite.Equal(10, *existing.OrderId)
+}
+
+func (suite *PaymentRepositoryTestSuite) Test_VoidPayment_ShouldRefusePaymentLinkedToOrder() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockPaymentSQL).WithArgs(5).
+		WillReturnRows(paymentRows().AddRow(5, 1001, 10, "key-1", 500, "USD", model.StatusAuthorized, "ch_1", now, now))
+	suite.mockDB.ExpectRollback()
+
+	err := suite.testRepository.VoidPayment(5, func(model.Payment) error {
+		suite.Fail("released a payment linked to an order")
+		return nil
+	})
+
+	suite.ErrorIs(err, model.ErrPaymentLinked)
+}
+
+func (suite *PaymentRepositoryTestSuite) Test_VoidPayment_ShouldMarkVoidedAfterRelease() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockPaymentSQL).WithArgs(5).
+		WillReturnRows(paymentRows().AddRow(5, 1001, nil, "key-1", 500, "USD", model.StatusAuthorized, "ch_1", now, now))
+	suite.mockDB.ExpectExec(UpdatePaymentStatusSQL).WithArgs(model.StatusVoided, nil, sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	released := false
+	err := suite.testRepository.VoidPayment(5, func(payment model.Payment) error {
+		released = payment.Id == 5
+		return nil
+	})
+
+	suite.Nil(err)
+	suite.True(released)
+}
+
+func (suite *PaymentRepositoryTestSuite) Test_GetPayment_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectPaymentByIdSQL).WithArgs(5).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetPayment(5)
+
+	suite.ErrorIs(err, model.ErrPaymentNotFound)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"movie-rent/constants"
+	"movie-rent/pkg/payments/clients/gateway"
+	"movie-rent/pkg/payments/model"
+	"movie-rent/pkg/payments/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/payments/service/payment_service.go -destination=pkg/payments/mocks/payment_service_mock.go -package=mocks
+
+type PaymentService interface {
+	Authorize(userId int, amountCents int, paymentToken string, idempotencyKey string) (model.Payment, error)
+	Capture(paymentId int) (model.Payment, error)
+	Void(paymentId int) error
+	GetPaymentByKey(idempotencyKey string) (model.Payment, error)
+}
+
+type paymentService struct {
+	repository repository.PaymentRepository
+	gateway    gateway.PaymentGateway
+}
+
+func NewPaymentService(repository repository.PaymentRepository, gateway gateway.PaymentGateway) PaymentService {
+	return paymentService{repository: repository, gateway: gateway}
+}
+
+// Authorize places a hold for the amount. A retry with the same key returns
+// the stored payment, or resumes an attempt that never got an answer from
+// the gateway; the gateway sees the same key, so the card is held only once.
+func (m paymentService) Authorize(userId int, amountCents int, paymentToken string, idempotencyKey string) (model.Payment, error) {
+	now := time.Now()
+	payment, created, err := m.repository.CreatePayment(model.Payment{
+		UserId:         userId,
+		IdempotencyKey: idempotencyKey,
+		AmountCents:    amountCents,
+		Currency:       constants.Currency,
+		Status:         model.StatusPending,
+		CreatedAt:      now,
+	})
+	if err != nil {
+		fmt.Println("failed to record payment:", err.Error())
+		return model.Payment{}, err
+	}
+
+	if !created {
+		if payment.UserId != userId || payment.AmountCents != amountCents {
+			return model.Payment{}, model.ErrIdempotencyKeyReused
+		}
+		switch payment.Status {
+		case model.StatusAuthorized, model.StatusCaptured:
+			return payment, nil
+		case model.StatusDeclined:
+			return model.Payment{}, model.ErrPaymentDeclined
+		case model.StatusVoided, model.StatusRefunded:
+			return model.Payment{}, model.ErrPaymentVoided
+		}
+	}
+
+	result, err := m.gateway.Authorize(gateway.AuthorizeRequest{
+		AmountCents:  amountCents,
+		Currency:     constants.Currency,
+		PaymentToken: paymentToken,
+		Reference:    idempotencyKey,
+	}, idempotencyKey)
+	if errors.Is(err, gateway.ErrDeclined) {
+		if err = m.repository.UpdateStatus(payment.Id, model.StatusDeclined, nil, time.Now()); err != nil {
+			fmt.Println("failed to record declined payment:", err.Error())
+		}
+		return model.Payment{}, model.ErrPaymentDeclined
+	}
+	if err != nil {
+		fmt.Println("failed to authorize payment:", err.Error())
+		return model.Payment{}, err
+	}
+
+	if err = m.setStatus(&payment, model.StatusAuthorized, &result.Id); err != nil {
+		return model.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (m paymentService) Capture(paymentId int) (model.Payment, error) {
+	payment, err := m.repository.GetPayment(paymentId)
+	if err != nil {
+		fmt.Println("failed to find payment:", err.Error())
+		return model.Payment{}, err
+	}
+	if payment.Status == model.StatusCaptured {
+		return payment, nil
+	}
+	if payment.Status != model.StatusAuthorized || payment.GatewayRef == nil {
+		return model.Payment{}, model.ErrPaymentNotCapturable
+	}
+
+	if _, err = m.gateway.Capture(*payment.GatewayRef, payment.IdempotencyKey+":capture"); err != nil {
+		fmt.Println("failed to capture payment:", err.Error())
+		return model.Payment{}, err
+	}
+	if err = m.setStatus(&payment, model.StatusCaptured, nil); err != nil {
+		return model.Payment{}, err
+	}
+	return payment, nil
+}
+
+// Void releases the hold of a payment whose order could not be placed. A
+// payment that is linked to an order is never voided.
+func (m paymentService) Void(paymentId int) error {
+	err := m.repository.VoidPayment(paymentId, m.release)
+	if err != nil {
+		fmt.Println("failed to void payment:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// release voids the authorization at the gateway. An attempt that never got
+// an answer is looked up by its key first, since the card may have been held
+// anyway.
+func (m paymentService) release(payment model.Payment) error {
+	if payment.GatewayRef == nil {
+		result, err := m.gateway.Lookup(payment.IdempotencyKey)
+		if errors.Is(err, gateway.ErrUnknownTransaction) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if result.Status != gateway.StatusAuthorized {
+			return nil
+		}
+		payment.GatewayRef = &result.Id
+	}
+
+	_, err := m.gateway.Void(*payment.GatewayRef, payment.IdempotencyKey+":void")
+	return err
+}
+
+func (m paymentService) GetPaymentByKey(idempotencyKey string) (model.Payment, error) {
+	return m.repository.GetPaymentByKey(idempotencyKey)
+}
+
+func (m paymentService) setStatus(payment *model.Payment, status string, gatewayRef *string) error {
+	now := time.Now()
+	if err := m.repository.UpdateStatus(payment.Id, status, gatewayRef, now); err != nil {
+		fmt.Println("failed to update payment:", err.Error())
+		return err
+	}
+	payment.Status = status
+	payment.UpdatedAt = now
+	if gatewayRef != nil {
+		payment.GatewayRef = gatewayRef
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/payments/clients/gateway"
+	"movie-rent/pkg/payments/mocks"
+	"movie-rent/pkg/payments/model"
+	"testing"
+)
+
+type PaymentServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockPaymentRepository
+	mockGateway    *mocks.MockPaymentGateway
+	paymentService PaymentService
+}
+
+func TestPaymentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PaymentServiceTestSuite))
+}
+
+func (suite *PaymentServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockPaymentRepository(suite.mockController)
+	suite.mockGateway = mocks.NewMockPaymentGateway(suite.mockController)
+	suite.paymentService = NewPaymentService(suite.mockRepository, suite.mockGateway)
+}
+
+func (suite *PaymentServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldAuthorizeNewPayment() {
+	pending := model.Payment{Id: 5, UserId: 1001, IdempotencyKey: "key-1", AmountCents: 500, Status: model.StatusPending}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(pending, true, nil).Times(1)
+	suite.mockGateway.EXPECT().Authorize(gateway.AuthorizeRequest{AmountCents: 500, Currency: "USD", PaymentToken: "tok_visa", Reference: "key-1"}, "key-1").
+		Return(gateway.Result{Id: "ch_1", Status: gateway.StatusAuthorized, AmountCents: 500}, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateStatus(5, model.StatusAuthorized, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	payment, err := suite.paymentService.Authorize(1001, 500, "tok_visa", "key-1")
+
+	suite.Nil(err)
+	suite.Equal(model.StatusAuthorized, payment.Status)
+	suite.Equal("ch_1", *payment.GatewayRef)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldNotChargeAgainForAuthorizedKey() {
+	ref := "ch_1"
+	existing := model.Payment{Id: 5, UserId: 1001, AmountCents: 500, Status: model.StatusAuthorized, GatewayRef: &ref}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(existing, false, nil).Times(1)
+
+	payment, err := suite.paymentService.Authorize(1001, 500, "tok_visa", "key-1")
+
+	suite.Nil(err)
+	suite.Equal(existing, payment)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldResumePendingPayment() {
+	existing := model.Payment{Id: 5, UserId: 1001, AmountCents: 500, Status: model.StatusPending}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(existing, false, nil).Times(1)
+	suite.mockGateway.EXPECT().Authorize(gomock.Any(), "key-1").Return(gateway.Result{Id: "ch_1"}, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateStatus(5, model.StatusAuthorized, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	payment, err := suite.paymentService.Authorize(1001, 500, "tok_visa", "key-1")
+
+	suite.Nil(err)
+	suite.Equal(model.StatusAuthorized, payment.Status)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldRejectKeyReusedForDifferentAmount() {
+	existing := model.Payment{Id: 5, UserId: 1001, AmountCents: 500, Status: model.StatusAuthorized}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(existing, false, nil).Times(1)
+
+	_, err := suite.paymentService.Authorize(1001, 700, "tok_visa", "key-1")
+
+	suite.ErrorIs(err, model.ErrIdempotencyKeyReused)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldRecordDecline() {
+	pending := model.Payment{Id: 5, UserId: 1001, AmountCents: 500, Status: model.StatusPending}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(pending, true, nil).Times(1)
+	suite.mockGateway.EXPECT().Authorize(gomock.Any(), "key-1").Return(gateway.Result{}, gateway.ErrDeclined).Times(1)
+	suite.mockRepository.EXPECT().UpdateStatus(5, model.StatusDeclined, nil, gomock.Any()).Return(nil).Times(1)
+
+	_, err := suite.paymentService.Authorize(1001, 500, gateway.DeclinedToken, "key-1")
+
+	suite.ErrorIs(err, model.ErrPaymentDeclined)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Authorize_ShouldLeavePaymentPendingWhenGatewayUnreachable() {
+	pending := model.Payment{Id: 5, UserId: 1001, AmountCents: 500, Status: model.StatusPending}
+	suite.mockRepository.EXPECT().CreatePayment(gomock.Any()).Return(pending, true, nil).Times(1)
+	suite.mockGateway.EXPECT().Authorize(gomock.Any(), "key-1").Return(gateway.Result{}, errors.New("timeout")).Times(1)
+
+	_, err := suite.paymentService.Authorize(1001, 500, "tok_visa", "key-1")
+
+	suite.NotNil(err)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Capture_ShouldCaptureWithDerivedKey() {
+	ref := "ch_1"
+	authorized := model.Payment{Id: 5, IdempotencyKey: "key-1", Status: model.StatusAuthorized, GatewayRef: &ref}
+	suite.mockRepository.EXPECT().GetPayment(5).Return(authorized, nil).Times(1)
+	suite.mockGateway.EXPECT().Capture("ch_1", "key-1:capture").Return(gateway.Result{Id: "ch_1"}, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateStatus(5, model.StatusCaptured, nil, gomock.Any()).Return(nil).Times(1)
+
+	payment, err := suite.paymentService.Capture(5)
+
+	suite.Nil(err)
+	suite.Equal(model.StatusCaptured, payment.Status)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Capture_ShouldSkipCapturedPayment() {
+	suite.mockRepository.EXPECT().GetPayment(5).Return(model.Payment{Id: 5, Status: model.StatusCaptured}, nil).Times(1)
+
+	payment, err := suite.paymentService.Capture(5)
+
+	suite.Nil(err)
+	suite.Equal(model.StatusCaptured, payment.Status)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Capture_ShouldReturnErrorWhenNotAuthorized() {
+	suite.mockRepository.EXPECT().GetPayment(5).Return(model.Payment{Id: 5, Status: model.StatusVoided}, nil).Times(1)
+
+	_, err := suite.paymentService.Capture(5)
+
+	suite.ErrorIs(err, model.ErrPaymentNotCapturable)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Void_ShouldReleaseAuthorization() {
+	ref := "ch_1"
+	authorized := model.Payment{Id: 5, IdempotencyKey: "key-1", Status: model.StatusAuthorized, GatewayRef: &ref}
+	suite.mockRepository.EXPECT().VoidPayment(5, gomock.Any()).DoAndReturn(func(paymentId int, release func(model.Payment) error) error {
+		return release(authorized)
+	}).Times(1)
+	suite.mockGateway.EXPECT().Void("ch_1", "key-1:void").Return(gateway.Result{Id: "ch_1"}, nil).Times(1)
+
+	err := suite.paymentService.Void(5)
+
+	suite.Nil(err)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Void_ShouldLookUpPendingPaymentAtGateway() {
+	pending := model.Payment{Id: 5, IdempotencyKey: "key-1", Status: model.StatusPending}
+	suite.mockRepository.EXPECT().VoidPayment(5, gomock.Any()).DoAndReturn(func(paymentId int, release func(model.Payment) error) error {
+		return release(pending)
+	}).Times(1)
+	suite.mockGateway.EXPECT().Lookup("key-1").Return(gateway.Result{Id: "ch_1", Status: gateway.StatusAuthorized}, nil).Times(1)
+	suite.mockGateway.EXPECT().Void("ch_1", "key-1:void").Return(gateway.Result{Id: "ch_1"}, nil).Times(1)
+
+	err := suite.paymentService.Void(5)
+
+	suite.Nil(err)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Void_ShouldKeepPaymentOpenWhenGatewayUnreachable() {
+	pending := model.Payment{Id: 5, IdempotencyKey: "key-1", Status: model.StatusPending}
+	suite.mockRepository.EXPECT().VoidPayment(5, gomock.Any()).DoAndReturn(func(paymentId int, release func(model.Payment) error) error {
+		return release(pending)
+	}).Times(1)
+	suite.mockGateway.EXPECT().Lookup("key-1").Return(gateway.Result{}, errors.New("timeout")).Times(1)
+
+	err := suite.paymentService.Void(5)
+
+	suite.NotNil(err)
+}
+
+func (suite *PaymentServiceTestSuite) Test_Void_ShouldRefusePaymentLinkedToOrder() {
+	suite.mockRepository.EXPECT().VoidPayment(5, gomock.Any()).Return(model.ErrPaymentLinked).Times(1)
+
+	err := suite.paymentService.Void(5)
+
+	suite.ErrorIs(err, model.ErrPaymentLinked)
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/person/model"
+	"movie-rent/pkg/person/service"
+	"net/http"
+	"strconv"
+)
+
+type PersonController struct {
+	service service.PersonService
+}
+
+func NewPersonController(service service.PersonService) PersonController {
+	return PersonController{service: service}
+}
+
+func (m *PersonController) GetPerson(ctx *gin.Context) {
+	personId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	person, err := m.service.GetPerson(personId)
+	if errors.Is(err, model.ErrPersonNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, person)
+}
+
+func (m *PersonController) GetMovieCredits(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	credits, err := m.service.GetMovieCredits(movieId)
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, credits)
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/person/mocks"
+	"movie-rent/pkg/person/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type PersonControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockPersonService *mocks.MockPersonService
+	testController    PersonController
+}
+
+func TestPersonControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonControllerTestSuite))
+}
+
+func (suite *PersonControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockPersonService = mocks.NewMockPersonService(suite.mockController)
+	suite.testController = NewPersonController(suite.mockPersonService)
+}
+
+func (suite *PersonControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *PersonControllerTestSuite) Test_GetPerson_ShouldReturnNotFound() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "42"}}
+	suite.mockPersonService.EXPECT().GetPerson(42).Return(model.PersonDetails{}, model.ErrPersonNotFound).Times(1)
+
+	suite.testController.GetPerson(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *PersonControllerTestSuite) Test_GetPerson_ShouldReturnBadRequestForInvalidId() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "tom"}}
+
+	suite.testController.GetPerson(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *PersonControllerTestSuite) Test_GetMovieCredits_ShouldReturnCastAndCrew() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	credits := model.MovieCredits{
+		MovieId: 7,
+		Cast:    []model.Credit{{PersonId: 3, Name: "Tom Hanks", Role: model.RoleActor, Character: "Forrest Gump"}},
+		Crew:    []model.Credit{},
+	}
+	suite.mockPersonService.EXPECT().GetMovieCredits(7).Return(credits, nil).Times(1)
+
+	suite.testController.GetMovieCredits(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"movieId":7,"cast":[{"personId":3,"name":"Tom Hanks","role":"actor","character":"Forrest Gump","billingOrder":0}],"crew":[]}`,
+		suite.recorder.Body.String())
+}
+
+func (suite *PersonControllerTestSuite) Test_GetMovieCredits_ShouldReturnNotFoundForUnknownMovie() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockPersonService.EXPECT().GetMovieCredits(7).Return(model.MovieCredits{}, movieModel.ErrMovieNotFound).Times(1)
+
+	suite.testController.GetMovieCredits(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/person/repository/person_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/person/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonRepository is a mock of PersonRepository interface.
+type MockPersonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonRepositoryMockRecorder
+}
+
+// MockPersonRepositoryMockRecorder is the mock recorder for MockPersonRepository.
+type MockPersonRepositoryMockRecorder struct {
+	mock *MockPersonRepository
+}
+
+// NewMockPersonRepository creates a new mock instance.
+func NewMockPersonRepository(ctrl *gomock.Controller) *MockPersonRepository {
+	mock := &MockPersonRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetFilmography mocks base method.
+func (m *MockPersonRepository) GetFilmography(personId int) ([]model.Filmography, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmography", personId)
+	ret0, _ := ret[0].([]model.Filmography)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmography indicates an expected call of GetFilmography.
+func (mr *MockPersonRepositoryMockRecorder) GetFilmography(personId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmography", reflect.TypeOf((*MockPersonRepository)(nil).GetFilmography), personId)
+}
+
+// GetMovieCredits mocks base method.
+func (m *MockPersonRepository) GetMovieCredits(movieId int) ([]model.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", movieId)
+	ret0, _ := ret[0].([]model.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockPersonRepositoryMockRecorder) GetMovieCredits(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockPersonRepository)(nil).GetMovieCredits), movieId)
+}
+
+// GetPerson mocks base method.
+func (m *MockPersonRepository) GetPerson(personId int) (model.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", personId)
+	ret0, _ := ret[0].(model.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockPersonRepositoryMockRecorder) GetPerson(personId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockPersonRepository)(nil).GetPerson), personId)
+}
+
+// SetMovieCredits mocks base method.
+func (m *MockPersonRepository) SetMovieCredits(movieId int, credits []model.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMovieCredits", movieId, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMovieCredits indicates an expected call of SetMovieCredits.
+func (mr *MockPersonRepositoryMockRecorder) SetMovieCredits(movieId, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMovieCredits", reflect.TypeOf((*MockPersonRepository)(nil).SetMovieCredits), movieId, credits)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/person/service/person_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/person/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonService is a mock of PersonService interface.
+type MockPersonService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonServiceMockRecorder
+}
+
+// MockPersonServiceMockRecorder is the mock recorder for MockPersonService.
+type MockPersonServiceMockRecorder struct {
+	mock *MockPersonService
+}
+
+// NewMockPersonService creates a new mock instance.
+func NewMockPersonService(ctrl *gomock.Controller) *MockPersonService {
+	mock := &MockPersonService{ctrl: ctrl}
+	mock.recorder = &MockPersonServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonService) EXPECT() *MockPersonServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMovieCredits mocks base method.
+func (m *MockPersonService) GetMovieCredits(movieId int) (model.MovieCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", movieId)
+	ret0, _ := ret[0].(model.MovieCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockPersonServiceMockRecorder) GetMovieCredits(movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockPersonService)(nil).GetMovieCredits), movieId)
+}
+
+// GetPerson mocks base method.
+func (m *MockPersonService) GetPerson(personId int) (model.PersonDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", personId)
+	ret0, _ := ret[0].(model.PersonDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockPersonServiceMockRecorder) GetPerson(personId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockPersonService)(nil).GetPerson), personId)
+}
+
+// ImportCredits mocks base method.
+func (m *MockPersonService) ImportCredits(movieId int, credits []model.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCredits", movieId, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCredits indicates an expected call of ImportCredits.
+func (mr *MockPersonServiceMockRecorder) ImportCredits(movieId, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCredits", reflect.TypeOf((*MockPersonService)(nil).ImportCredits), movieId, credits)
+}
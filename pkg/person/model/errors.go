@@ -0,0 +1,8 @@
+package model
+
+import "errors"
+
+var (
+	ErrPersonNotFound = errors.New("person not found")
+	ErrInvalidCredit  = errors.New("invalid credit")
+)
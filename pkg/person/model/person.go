@@ -0,0 +1,67 @@
+package model
+
+type Role string
+
+const (
+	RoleActor    Role = "actor"
+	RoleDirector Role = "director"
+	RoleWriter   Role = "writer"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleActor || r == RoleDirector || r == RoleWriter
+}
+
+// Credit is one person's part in a movie. Character is only set for actors;
+// BillingOrder ranks credits of the same role, lowest first. The Rapid feed
+// sends credits in this shape, identifying people by ImdbCode when it can.
+type Credit struct {
+	PersonId     int    `json:"personId"`
+	Name         string `json:"name"`
+	ImdbCode     string `json:"imdbCode,omitempty"`
+	Role         Role   `json:"role"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billingOrder"`
+}
+
+// MovieCredits splits a movie's credits into the cast, in billing order, and
+// the directors and writers.
+type MovieCredits struct {
+	MovieId int      `json:"movieId"`
+	Cast    []Credit `json:"cast"`
+	Crew    []Credit `json:"crew"`
+}
+
+type Person struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	ImdbCode string `json:"imdbCode,omitempty"`
+}
+
+// Filmography is one movie a person worked on and their part in it.
+type Filmography struct {
+	MovieId      int    `json:"movieId"`
+	Title        string `json:"title"`
+	ReleaseYear  int    `json:"releaseYear"`
+	Role         Role   `json:"role"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billingOrder"`
+}
+
+type PersonDetails struct {
+	Person
+	Credits []Filmography `json:"credits"`
+}
+
+// NewMovieCredits groups credits that arrive ordered by role and billing.
+func NewMovieCredits(movieId int, credits []Credit) MovieCredits {
+	result := MovieCredits{MovieId: movieId, Cast: []Credit{}, Crew: []Credit{}}
+	for _, credit := range credits {
+		if credit.Role == RoleActor {
+			result.Cast = append(result.Cast, credit)
+		} else {
+			result.Crew = append(result.Crew, credit)
+		}
+	}
+	return result
+}
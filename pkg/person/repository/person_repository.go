@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/person/model"
+)
+
+const (
+	SelectPersonSQL      = `SELECT id, name, COALESCE(imdb_code, '') FROM people WHERE id = $1`
+	SelectFilmographySQL = `SELECT m.id, m.title, m.release_year, c.role, COALESCE(c.character_name, ''), c.billing_order ` +
+		`FROM credits c JOIN movies m ON m.id = c.movie_id WHERE c.person_id = $1 AND m.deleted_at IS NULL ` +
+		`ORDER BY m.release_year DESC, m.id, c.role, c.billing_order`
+	SelectMovieCreditsSQL = `SELECT p.id, p.name, COALESCE(p.imdb_code, ''), c.role, COALESCE(c.character_name, ''), c.billing_order ` +
+		`FROM credits c JOIN people p ON p.id = c.person_id WHERE c.movie_id = $1 ORDER BY c.role, c.billing_order, p.name`
+	UpsertPersonByImdbSQL = `INSERT INTO people(name, imdb_code) VALUES ($1, $2) ` +
+		`ON CONFLICT (imdb_code) DO UPDATE SET name = EXCLUDED.name RETURNING id`
+	SelectPersonByNameSQL = `SELECT id FROM people WHERE imdb_code IS NULL AND LOWER(name) = LOWER($1) ORDER BY id LIMIT 1`
+	InsertPersonSQL       = `INSERT INTO people(name) VALUES ($1) RETURNING id`
+	DeleteMovieCreditsSQL = `DELETE FROM credits WHERE movie_id = $1`
+	InsertCreditSQL       = `INSERT INTO credits(movie_id, person_id, role, character_name, billing_order) ` +
+		`VALUES ($1, $2, $3, NULLIF($4, ''), $5)`
+)
+
+type PersonRepository interface {
+	GetPerson(personId int) (model.Person, error)
+	GetFilmography(personId int) ([]model.Filmography, error)
+	GetMovieCredits(movieId int) ([]model.Credit, error)
+	SetMovieCredits(movieId int, credits []model.Credit) error
+}
+
+type personRepo struct {
+	db *sqlx.DB
+}
+
+func NewPersonRepository(db *sqlx.DB) PersonRepository {
+	return &personRepo{db: db}
+}
+
+func (m personRepo) GetPerson(personId int) (model.Person, error) {
+	var person model.Person
+	err := m.db.QueryRow(SelectPersonSQL, personId).Scan(&person.Id, &person.Name, &person.ImdbCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Person{}, model.ErrPersonNotFound
+	}
+	if err != nil {
+		return model.Person{}, fmt.Errorf("failed to fetch person: %w", err)
+	}
+	return person, nil
+}
+
+// GetFilmography lists a person's credits, newest movie first.
+func (m personRepo) GetFilmography(personId int) ([]model.Filmography, error) {
+	rows, err := m.db.Query(SelectFilmographySQL, personId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch filmography: %w", err)
+	}
+	defer rows.Close()
+
+	films := []model.Filmography{}
+	for rows.Next() {
+		var film model.Filmography
+		if err := rows.Scan(&film.MovieId, &film.Title, &film.ReleaseYear, &film.Role, &film.Character, &film.BillingOrder); err != nil {
+			return nil, fmt.Errorf("failed to scan filmography: %w", err)
+		}
+		films = append(films, film)
+	}
+	return films, rows.Err()
+}
+
+// GetMovieCredits lists a movie's credits ordered by role, then billing.
+func (m personRepo) GetMovieCredits(movieId int) ([]model.Credit, error) {
+	rows, err := m.db.Query(SelectMovieCreditsSQL, movieId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch credits: %w", err)
+	}
+	defer rows.Close()
+
+	credits := []model.Credit{}
+	for rows.Next() {
+		var credit model.Credit
+		err := rows.Scan(&credit.PersonId, &credit.Name, &credit.ImdbCode, &credit.Role, &credit.Character, &credit.BillingOrder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit: %w", err)
+		}
+		credits = append(credits, credit)
+	}
+	return credits, rows.Err()
+}
+
+// SetMovieCredits replaces a movie's credits. People are matched by IMDb
+// code when the credit has one, otherwise by name among people without
+// one, and created when no match exists.
+func (m personRepo) SetMovieCredits(movieId int, credits []model.Credit) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin credits update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(DeleteMovieCreditsSQL, movieId); err != nil {
+		return fmt.Errorf("failed to clear credits: %w", err)
+	}
+	for _, credit := range credits {
+		personId, err := findOrCreatePerson(tx, credit)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(InsertCreditSQL, movieId, personId, credit.Role, credit.Character, credit.BillingOrder)
+		if postgres.IsViolation(err, postgres.ForeignKeyViolation) {
+			return movieModel.ErrMovieNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert credit: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit credits: %w", err)
+	}
+	return nil
+}
+
+func findOrCreatePerson(tx *sqlx.Tx, credit model.Credit) (int, error) {
+	var personId int
+	if credit.ImdbCode != "" {
+		if err := tx.QueryRow(UpsertPersonByImdbSQL, credit.Name, credit.ImdbCode).Scan(&personId); err != nil {
+			return 0, fmt.Errorf("failed to save person: %w", err)
+		}
+		return personId, nil
+	}
+
+	err := tx.QueryRow(SelectPersonByNameSQL, credit.Name).Scan(&personId)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.QueryRow(InsertPersonSQL, credit.Name).Scan(&personId)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to save person: %w", err)
+	}
+	return personId, nil
+}
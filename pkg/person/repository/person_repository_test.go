@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/person/model"
+	"testing"
+)
+
+type PersonRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository PersonRepository
+}
+
+func TestPersonRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonRepositoryTestSuite))
+}
+
+func (suite *PersonRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewPersonRepository(suite.mockedDB)
+}
+
+func (suite *PersonRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *PersonRepositoryTestSuite) Test_GetPerson_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectPersonSQL).WithArgs(42).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetPerson(42)
+
+	suite.ErrorIs(err, model.ErrPersonNotFound)
+}
+
+func (suite *PersonRepositoryTestSuite) Test_GetMovieCredits_ShouldReturnCredits() {
+	suite.mockDB.ExpectQuery(SelectMovieCreditsSQL).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "imdb_code", "role", "character_name", "billing_order"}).
+			AddRow(3, "Tom Hanks", "nm0000158", "actor", "Forrest Gump", 0).
+			AddRow(9, "Robert Zemeckis", "", "director", "", 0))
+
+	credits, err := suite.testRepository.GetMovieCredits(7)
+
+	suite.Nil(err)
+	suite.Equal([]model.Credit{
+		{PersonId: 3, Name: "Tom Hanks", ImdbCode: "nm0000158", Role: model.RoleActor, Character: "Forrest Gump"},
+		{PersonId: 9, Name: "Robert Zemeckis", Role: model.RoleDirector},
+	}, credits)
+}
+
+func (suite *PersonRepositoryTestSuite) Test_SetMovieCredits_ShouldMatchPeopleByImdbCodeThenName() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(DeleteMovieCreditsSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockDB.ExpectQuery(UpsertPersonByImdbSQL).WithArgs("Tom Hanks", "nm0000158").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	suite.mockDB.ExpectExec(InsertCreditSQL).WithArgs(7, 3, model.RoleActor, "Forrest Gump", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(SelectPersonByNameSQL).WithArgs("Eric Roth").WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectQuery(InsertPersonSQL).WithArgs("Eric Roth").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	suite.mockDB.ExpectExec(InsertCreditSQL).WithArgs(7, 11, model.RoleWriter, "", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.SetMovieCredits(7, []model.Credit{
+		{Name: "Tom Hanks", ImdbCode: "nm0000158", Role: model.RoleActor, Character: "Forrest Gump"},
+		{Name: "Eric Roth", Role: model.RoleWriter},
+	})
+
+	suite.Nil(err)
+}
+
+func (suite *PersonRepositoryTestSuite) Test_SetMovieCredits_ShouldReturnMovieNotFound() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectExec(DeleteMovieCreditsSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectQuery(SelectPersonByNameSQL).WithArgs("Eric Roth").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	suite.mockDB.ExpectExec(InsertCreditSQL).WithArgs(7, 11, model.RoleWriter, "", 0).
+		WillReturnError(&pq.Error{Code: postgres.ForeignKeyViolation})
+	suite.mockDB.ExpectRollback()
+
+	err := suite.testRepository.SetMovieCredits(7, []model.Credit{{Name: "Eric Roth", Role: model.RoleWriter}})
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
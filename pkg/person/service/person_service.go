@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	movieRepository "movie-rent/pkg/movie/repository"
+	"movie-rent/pkg/person/model"
+	"movie-rent/pkg/person/repository"
+	"strings"
+)
+
+// go:generate mockgen -source=pkg/person/service/person_service.go -destination=pkg/person/mocks/person_service_mock.go -package=mocks
+
+type PersonService interface {
+	GetPerson(personId int) (model.PersonDetails, error)
+	GetMovieCredits(movieId int) (model.MovieCredits, error)
+	ImportCredits(movieId int, credits []model.Credit) error
+}
+
+type personService struct {
+	repository      repository.PersonRepository
+	movieRepository movieRepository.MovieRepository
+}
+
+func NewPersonService(repository repository.PersonRepository, movieRepository movieRepository.MovieRepository) PersonService {
+	return personService{repository: repository, movieRepository: movieRepository}
+}
+
+func (m personService) GetPerson(personId int) (model.PersonDetails, error) {
+	person, err := m.repository.GetPerson(personId)
+	if err != nil {
+		fmt.Println("failed to find person:", err.Error())
+		return model.PersonDetails{}, err
+	}
+	films, err := m.repository.GetFilmography(personId)
+	if err != nil {
+		fmt.Println("failed to find filmography:", err.Error())
+		return model.PersonDetails{}, err
+	}
+	return model.PersonDetails{Person: person, Credits: films}, nil
+}
+
+func (m personService) GetMovieCredits(movieId int) (model.MovieCredits, error) {
+	if _, err := m.movieRepository.GetMovieBy(movieId); err != nil {
+		fmt.Println("failed to find movie for credits:", err.Error())
+		return model.MovieCredits{}, err
+	}
+	credits, err := m.repository.GetMovieCredits(movieId)
+	if err != nil {
+		fmt.Println("failed to find credits:", err.Error())
+		return model.MovieCredits{}, err
+	}
+	return model.NewMovieCredits(movieId, credits), nil
+}
+
+// ImportCredits replaces a movie's credits with those from the feed. Credits
+// without a name or with an unknown role are skipped rather than failing the
+// whole movie, as are repeats.
+func (m personService) ImportCredits(movieId int, credits []model.Credit) error {
+	valid := make([]model.Credit, 0, len(credits))
+	seen := map[string]bool{}
+	for _, credit := range credits {
+		credit, err := normalizeCredit(credit)
+		if err != nil {
+			fmt.Println("skipping credit for movie", movieId, ":", err.Error())
+			continue
+		}
+		key := strings.ToLower(credit.ImdbCode + "|" + credit.Name + "|" + string(credit.Role) + "|" + credit.Character)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		valid = append(valid, credit)
+	}
+	return m.repository.SetMovieCredits(movieId, valid)
+}
+
+func normalizeCredit(credit model.Credit) (model.Credit, error) {
+	credit.Name = strings.TrimSpace(credit.Name)
+	credit.ImdbCode = strings.TrimSpace(credit.ImdbCode)
+	credit.Role = model.Role(strings.ToLower(strings.TrimSpace(string(credit.Role))))
+	credit.Character = strings.TrimSpace(credit.Character)
+	if credit.Name == "" {
+		return model.Credit{}, fmt.Errorf("%w: name is missing", model.ErrInvalidCredit)
+	}
+	if !credit.Role.IsValid() {
+		return model.Credit{}, fmt.Errorf("%w: unknown role %q", model.ErrInvalidCredit, credit.Role)
+	}
+	if credit.Role != model.RoleActor {
+		credit.Character = ""
+	}
+	return credit, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/person/mocks"
+	"movie-rent/pkg/person/model"
+	"testing"
+)
+
+type PersonServiceTestSuite struct {
+	suite.Suite
+	mockController      *gomock.Controller
+	mockRepository      *mocks.MockPersonRepository
+	mockMovieRepository *movieMocks.MockMovieRepository
+
+	personService PersonService
+}
+
+func TestPersonServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonServiceTestSuite))
+}
+
+func (suite *PersonServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockPersonRepository(suite.mockController)
+	suite.mockMovieRepository = movieMocks.NewMockMovieRepository(suite.mockController)
+
+	suite.personService = NewPersonService(suite.mockRepository, suite.mockMovieRepository)
+}
+
+func (suite *PersonServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *PersonServiceTestSuite) Test_GetPerson_ShouldIncludeFilmography() {
+	person := model.Person{Id: 3, Name: "Tom Hanks"}
+	films := []model.Filmography{{MovieId: 7, Title: "Forrest Gump", ReleaseYear: 1994, Role: model.RoleActor, Character: "Forrest Gump"}}
+	suite.mockRepository.EXPECT().GetPerson(3).Return(person, nil).Times(1)
+	suite.mockRepository.EXPECT().GetFilmography(3).Return(films, nil).Times(1)
+
+	details, err := suite.personService.GetPerson(3)
+
+	suite.Nil(err)
+	suite.Equal(model.PersonDetails{Person: person, Credits: films}, details)
+}
+
+func (suite *PersonServiceTestSuite) Test_GetMovieCredits_ShouldSplitCastAndCrew() {
+	credits := []model.Credit{
+		{PersonId: 3, Name: "Tom Hanks", Role: model.RoleActor, Character: "Forrest Gump"},
+		{PersonId: 4, Name: "Robin Wright", Role: model.RoleActor, Character: "Jenny Curran", BillingOrder: 1},
+		{PersonId: 9, Name: "Robert Zemeckis", Role: model.RoleDirector},
+	}
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{Id: 7}, nil).Times(1)
+	suite.mockRepository.EXPECT().GetMovieCredits(7).Return(credits, nil).Times(1)
+
+	result, err := suite.personService.GetMovieCredits(7)
+
+	suite.Nil(err)
+	suite.Equal(model.MovieCredits{MovieId: 7, Cast: credits[:2], Crew: credits[2:]}, result)
+}
+
+func (suite *PersonServiceTestSuite) Test_GetMovieCredits_ShouldReturnNotFoundForUnknownMovie() {
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{}, movieModel.ErrMovieNotFound).Times(1)
+
+	_, err := suite.personService.GetMovieCredits(7)
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *PersonServiceTestSuite) Test_ImportCredits_ShouldSkipInvalidAndRepeatedCredits() {
+	credits := []model.Credit{
+		{Name: " Tom Hanks ", Role: "Actor", Character: "Forrest Gump"},
+		{Name: "Tom Hanks", Role: model.RoleActor, Character: "Forrest Gump"},
+		{Name: "", Role: model.RoleActor},
+		{Name: "Alan Silvestri", Role: "composer"},
+		{Name: "Robert Zemeckis", Role: model.RoleDirector, Character: "Himself"},
+	}
+	suite.mockRepository.EXPECT().SetMovieCredits(7, []model.Credit{
+		{Name: "Tom Hanks", Role: model.RoleActor, Character: "Forrest Gump"},
+		{Name: "Robert Zemeckis", Role: model.RoleDirector},
+	}).Return(nil).Times(1)
+
+	err := suite.personService.ImportCredits(7, credits)
+
+	suite.Nil(err)
+}
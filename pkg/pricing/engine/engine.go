@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"movie-rent/pkg/pricing/model"
+	"time"
+)
+
+// Engine prices a cart from its lines. It is pure: the same lines, rules and
+// time always produce the same quote.
+type Engine struct {
+	taxRateBps int
+	lineRules  []LineRule
+}
+
+func NewEngine(taxRateBps int, lineRules ...LineRule) Engine {
+	return Engine{taxRateBps: taxRateBps, lineRules: lineRules}
+}
+
+// Price runs the line rules over every line, then any cart rules, and totals
+// the result. Tax is charged on the discounted total.
+func (e Engine) Price(lines []model.Line, now time.Time, cartRules ...CartRule) model.Quote {
+	priced := make([]model.PricedLine, 0, len(lines))
+	for _, line := range lines {
+		list := line.BasePriceCents * line.RentalDays
+		p := model.PricedLine{
+			Line:           line,
+			ListPriceCents: list,
+			PriceCents:     list,
+			TotalCents:     list,
+			Adjustments:    []model.Adjustment{},
+		}
+		for _, rule := range e.lineRules {
+			rule.ApplyLine(&p, now)
+		}
+		priced = append(priced, p)
+	}
+
+	for _, rule := range cartRules {
+		rule.ApplyCart(priced, now)
+	}
+
+	quote := model.Quote{Lines: priced}
+	for _, line := range priced {
+		quote.SubtotalCents += line.PriceCents
+		quote.DiscountCents += line.DiscountCents
+	}
+	taxable := quote.SubtotalCents - quote.DiscountCents
+	quote.TaxCents = (taxable*e.taxRateBps + 5000) / 10000
+	quote.TotalCents = taxable + quote.TaxCents
+	return quote
+}
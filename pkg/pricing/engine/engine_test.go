@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/pricing/model"
+	"testing"
+	"time"
+)
+
+type EngineTestSuite struct {
+	suite.Suite
+	now    time.Time
+	engine Engine
+}
+
+func TestEngineTestSuite(t *testing.T) {
+	suite.Run(t, new(EngineTestSuite))
+}
+
+func (suite *EngineTestSuite) SetupTest() {
+	suite.now = time.Date(2025, time.October, 10, 12, 0, 0, 0, time.UTC)
+	suite.engine = NewEngine(800, DefaultLineRules(2, 50)...)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldChargeDailyRateForSingleDay() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "Action", ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}, suite.now)
+
+	suite.Equal(300, quote.SubtotalCents)
+	suite.Equal(0, quote.DiscountCents)
+	suite.Equal(24, quote.TaxCents)
+	suite.Equal(324, quote.TotalCents)
+	suite.Empty(quote.Lines[0].Adjustments)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldAddNewReleasePremium() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "Action", ReleaseYear: 2024, RentalDays: 1, BasePriceCents: 300}}, suite.now)
+
+	suite.Equal(450, quote.Lines[0].PriceCents)
+	suite.Equal([]model.Adjustment{{Rule: "new_release", AmountCents: 150}}, quote.Lines[0].Adjustments)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldNotTreatOlderTitlesAsNewReleases() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "Action", ReleaseYear: 2023, RentalDays: 1, BasePriceCents: 300}}, suite.now)
+
+	suite.Equal(300, quote.Lines[0].PriceCents)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldDiscountGenreCaseInsensitively() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "documentary", ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}, suite.now)
+
+	suite.Equal(300, quote.Lines[0].PriceCents)
+	suite.Equal(60, quote.Lines[0].DiscountCents)
+	suite.Equal(240, quote.Lines[0].TotalCents)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldApplyDurationMultiplierAsDiscount() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "Action", ReleaseYear: 1990, RentalDays: 7, BasePriceCents: 300}}, suite.now)
+
+	suite.Equal(2100, quote.Lines[0].ListPriceCents)
+	suite.Equal(600, quote.Lines[0].DiscountCents)
+	suite.Equal(1500, quote.Lines[0].TotalCents)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldTotalEveryLine() {
+	lines := []model.Line{
+		{ItemId: 1, Genre: "Action", ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300},
+		{ItemId: 2, Genre: "Horror", ReleaseYear: 2025, RentalDays: 3, BasePriceCents: 200},
+	}
+
+	quote := suite.engine.Price(lines, suite.now)
+
+	// 300 + (600 + 300 new release), with 3 days charged at 2.5 daily rates.
+	suite.Equal(1200, quote.SubtotalCents)
+	suite.Equal(150, quote.DiscountCents)
+	suite.Equal(84, quote.TaxCents)
+	suite.Equal(1134, quote.TotalCents)
+}
+
+type halfOffFirstLine struct{}
+
+func (halfOffFirstLine) ApplyCart(lines []model.PricedLine, now time.Time) {
+	lines[0].Discount("promo", lines[0].TotalCents/2)
+}
+
+func (suite *EngineTestSuite) Test_Price_ShouldRunCartRulesAfterLineRules() {
+	quote := suite.engine.Price([]model.Line{{ItemId: 1, Genre: "Action", ReleaseYear: 1990, RentalDays: 1, BasePriceCents: 300}}, suite.now, halfOffFirstLine{})
+
+	suite.Equal(150, quote.DiscountCents)
+	suite.Equal(162, quote.TotalCents)
+}
+
+func (suite *EngineTestSuite) Test_Discount_ShouldNotTakeLineBelowZero() {
+	line := model.PricedLine{PriceCents: 100, TotalCents: 100}
+
+	taken := line.Discount("promo", 250)
+
+	suite.Equal(100, taken)
+	suite.Equal(0, line.TotalCents)
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"movie-rent/pkg/pricing/model"
+	"strings"
+	"time"
+)
+
+// LineRule adjusts the price of a single line.
+type LineRule interface {
+	ApplyLine(line *model.PricedLine, now time.Time)
+}
+
+// CartRule adjusts lines with knowledge of the whole cart, after every line
+// rule has run.
+type CartRule interface {
+	ApplyCart(lines []model.PricedLine, now time.Time)
+}
+
+// NewReleaseRule charges a premium on titles released in the last Years
+// calendar years, including the current one.
+type NewReleaseRule struct {
+	Years          int
+	PremiumPercent int
+}
+
+func (r NewReleaseRule) ApplyLine(line *model.PricedLine, now time.Time) {
+	if line.ReleaseYear < now.Year()-r.Years+1 {
+		return
+	}
+	line.Surcharge("new_release", line.ListPriceCents*r.PremiumPercent/100)
+}
+
+// GenreRule moves the price of a genre up or down by a percentage.
+type GenreRule struct {
+	Percents map[string]int
+}
+
+func (r GenreRule) ApplyLine(line *model.PricedLine, now time.Time) {
+	for genre, percent := range r.Percents {
+		if !strings.EqualFold(genre, line.Genre) {
+			continue
+		}
+		amount := line.ListPriceCents * percent / 100
+		if amount > 0 {
+			line.Surcharge("genre", amount)
+		} else {
+			line.Discount("genre", -amount)
+		}
+		return
+	}
+}
+
+// DurationRule prices longer rentals below the daily rate. Multipliers are
+// the number of daily rates charged, in hundredths, per rental length.
+type DurationRule struct {
+	Multipliers map[int]int
+}
+
+func (r DurationRule) ApplyLine(line *model.PricedLine, now time.Time) {
+	multiplier, ok := r.Multipliers[line.RentalDays]
+	if !ok || line.RentalDays == 0 {
+		return
+	}
+	charged := line.PriceCents * multiplier / (100 * line.RentalDays)
+	line.Discount("duration", line.PriceCents-charged)
+}
+
+// DefaultLineRules is the house pricing: a 50% premium on this and last
+// year's releases, cheaper documentaries and family titles, and multi-day
+// rentals at a reduced daily rate.
+func DefaultLineRules(newReleaseYears int, newReleasePremiumPercent int) []LineRule {
+	return []LineRule{
+		NewReleaseRule{Years: newReleaseYears, PremiumPercent: newReleasePremiumPercent},
+		GenreRule{Percents: map[string]int{"Documentary": -20, "Family": -10, "Animation": -10}},
+		DurationRule{Multipliers: map[int]int{1: 100, 3: 250, 7: 500}},
+	}
+}
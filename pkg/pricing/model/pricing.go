@@ -0,0 +1,60 @@
+package model
+
+// Line is a single rental to be priced.
+type Line struct {
+	ItemId         int
+	MovieId        int
+	Genre          string
+	ReleaseYear    int
+	RentalDays     int
+	BasePriceCents int // daily rate
+}
+
+// Adjustment records what a rule did to a line or cart. Surcharges are
+// positive and discounts negative.
+type Adjustment struct {
+	Rule        string `json:"rule"`
+	AmountCents int    `json:"amountCents"`
+}
+
+type PricedLine struct {
+	Line           `json:"-"`
+	ListPriceCents int          `json:"listPriceCents"`
+	PriceCents     int          `json:"priceCents"`
+	DiscountCents  int          `json:"discountCents"`
+	TotalCents     int          `json:"totalCents"`
+	Adjustments    []Adjustment `json:"adjustments"`
+}
+
+// Surcharge raises the line price.
+func (l *PricedLine) Surcharge(rule string, amountCents int) {
+	if amountCents == 0 {
+		return
+	}
+	l.PriceCents += amountCents
+	l.TotalCents += amountCents
+	l.Adjustments = append(l.Adjustments, Adjustment{Rule: rule, AmountCents: amountCents})
+}
+
+// Discount lowers the line total, never below zero, and returns the amount
+// actually taken off.
+func (l *PricedLine) Discount(rule string, amountCents int) int {
+	if amountCents > l.TotalCents {
+		amountCents = l.TotalCents
+	}
+	if amountCents <= 0 {
+		return 0
+	}
+	l.DiscountCents += amountCents
+	l.TotalCents -= amountCents
+	l.Adjustments = append(l.Adjustments, Adjustment{Rule: rule, AmountCents: -amountCents})
+	return amountCents
+}
+
+type Quote struct {
+	Lines         []PricedLine `json:"lines"`
+	SubtotalCents int          `json:"subtotalCents"`
+	DiscountCents int          `json:"discountCents"`
+	TaxCents      int          `json:"taxCents"`
+	TotalCents    int          `json:"totalCents"`
+}
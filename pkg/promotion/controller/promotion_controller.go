@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/promotion/model"
+	"movie-rent/pkg/promotion/service"
+	"net/http"
+)
+
+type PromotionController struct {
+	service service.PromotionService
+}
+
+func NewPromotionController(service service.PromotionService) PromotionController {
+	return PromotionController{service: service}
+}
+
+func (m *PromotionController) CreatePromotion(ctx *gin.Context) {
+	var request model.PromotionRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	promotion, err := m.service.CreatePromotion(request)
+	if errors.Is(err, model.ErrInvalidPromotion) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicatePromotion) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, promotion)
+}
+
+func (m *PromotionController) GetPromotions(ctx *gin.Context) {
+	promotions, err := m.service.GetPromotions()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, promotions)
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/promotion/mocks"
+	"movie-rent/pkg/promotion/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type PromotionControllerTestSuite struct {
+	suite.Suite
+	context              *gin.Context
+	recorder             *httptest.ResponseRecorder
+	mockController       *gomock.Controller
+	mockPromotionService *mocks.MockPromotionService
+	testController       PromotionController
+}
+
+func TestPromotionControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(PromotionControllerTestSuite))
+}
+
+func (suite *PromotionControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockPromotionService = mocks.NewMockPromotionService(suite.mockController)
+	suite.testController = NewPromotionController(suite.mockPromotionService)
+}
+
+func (suite *PromotionControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *PromotionControllerTestSuite) Test_CreatePromotion_ShouldReturnBadRequestWhenKindUnknown() {
+	body := `{"code":"SPOOKY","kind":"bogus","startsAt":"2025-10-01T00:00:00Z"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+
+	suite.testController.CreatePromotion(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *PromotionControllerTestSuite) Test_CreatePromotion_ShouldReturnConflictWhenCodeTaken() {
+	body := `{"code":"SPOOKY","kind":"percentage","percentOff":20,"startsAt":"2025-10-01T00:00:00Z"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+	suite.mockPromotionService.EXPECT().CreatePromotion(gomock.Any()).Return(model.Promotion{}, model.ErrDuplicatePromotion).Times(1)
+
+	suite.testController.CreatePromotion(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *PromotionControllerTestSuite) Test_CreatePromotion_ShouldCreatePromotion() {
+	body := `{"code":"SPOOKY","kind":"percentage","percentOff":20,"genre":"Horror","startsAt":"2025-10-01T00:00:00Z","endsAt":"2025-11-01T00:00:00Z"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+	suite.mockPromotionService.EXPECT().CreatePromotion(gomock.Any()).DoAndReturn(func(request model.PromotionRequest) (model.Promotion, error) {
+		suite.Equal("Horror", *request.Genre)
+		return model.Promotion{Id: 3, Code: "SPOOKY"}, nil
+	}).Times(1)
+
+	suite.testController.CreatePromotion(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/promotion/repository/promotion_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/promotion/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionRepository) CreatePromotion(promotion model.Promotion) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", promotion)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) CreatePromotion(promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).CreatePromotion), promotion)
+}
+
+// GetCartPromotion mocks base method.
+func (m *MockPromotionRepository) GetCartPromotion(userId int) (model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartPromotion", userId)
+	ret0, _ := ret[0].(model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartPromotion indicates an expected call of GetCartPromotion.
+func (mr *MockPromotionRepositoryMockRecorder) GetCartPromotion(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartPromotion", reflect.TypeOf((*MockPromotionRepository)(nil).GetCartPromotion), userId)
+}
+
+// GetPromotionByCode mocks base method.
+func (m *MockPromotionRepository) GetPromotionByCode(code string) (model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionByCode", code)
+	ret0, _ := ret[0].(model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionByCode indicates an expected call of GetPromotionByCode.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotionByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionByCode", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotionByCode), code)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionRepository) GetPromotions() ([]model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions")
+	ret0, _ := ret[0].([]model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotions))
+}
+
+// GetUsage mocks base method.
+func (m *MockPromotionRepository) GetUsage(promotionId, userId int) (model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", promotionId, userId)
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockPromotionRepositoryMockRecorder) GetUsage(promotionId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockPromotionRepository)(nil).GetUsage), promotionId, userId)
+}
+
+// RemoveCartPromotion mocks base method.
+func (m *MockPromotionRepository) RemoveCartPromotion(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCartPromotion", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCartPromotion indicates an expected call of RemoveCartPromotion.
+func (mr *MockPromotionRepositoryMockRecorder) RemoveCartPromotion(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartPromotion", reflect.TypeOf((*MockPromotionRepository)(nil).RemoveCartPromotion), userId)
+}
+
+// SetCartPromotion mocks base method.
+func (m *MockPromotionRepository) SetCartPromotion(userId, promotionId int, appliedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartPromotion", userId, promotionId, appliedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartPromotion indicates an expected call of SetCartPromotion.
+func (mr *MockPromotionRepositoryMockRecorder) SetCartPromotion(userId, promotionId, appliedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartPromotion", reflect.TypeOf((*MockPromotionRepository)(nil).SetCartPromotion), userId, promotionId, appliedAt)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/promotion/service/promotion_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/promotion/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionService is a mock of PromotionService interface.
+type MockPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceMockRecorder
+}
+
+// MockPromotionServiceMockRecorder is the mock recorder for MockPromotionService.
+type MockPromotionServiceMockRecorder struct {
+	mock *MockPromotionService
+}
+
+// NewMockPromotionService creates a new mock instance.
+func NewMockPromotionService(ctrl *gomock.Controller) *MockPromotionService {
+	mock := &MockPromotionService{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionService) EXPECT() *MockPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyToCart mocks base method.
+func (m *MockPromotionService) ApplyToCart(userId int, promotion model.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyToCart", userId, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyToCart indicates an expected call of ApplyToCart.
+func (mr *MockPromotionServiceMockRecorder) ApplyToCart(userId, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyToCart", reflect.TypeOf((*MockPromotionService)(nil).ApplyToCart), userId, promotion)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionService) CreatePromotion(request model.PromotionRequest) (model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", request)
+	ret0, _ := ret[0].(model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionServiceMockRecorder) CreatePromotion(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionService)(nil).CreatePromotion), request)
+}
+
+// GetCartPromotion mocks base method.
+func (m *MockPromotionService) GetCartPromotion(userId int) (*model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartPromotion", userId)
+	ret0, _ := ret[0].(*model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartPromotion indicates an expected call of GetCartPromotion.
+func (mr *MockPromotionServiceMockRecorder) GetCartPromotion(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartPromotion", reflect.TypeOf((*MockPromotionService)(nil).GetCartPromotion), userId)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionService) GetPromotions() ([]model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions")
+	ret0, _ := ret[0].([]model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionServiceMockRecorder) GetPromotions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionService)(nil).GetPromotions))
+}
+
+// Redeemable mocks base method.
+func (m *MockPromotionService) Redeemable(userId int, code string) (model.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeemable", userId, code)
+	ret0, _ := ret[0].(model.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeemable indicates an expected call of Redeemable.
+func (mr *MockPromotionServiceMockRecorder) Redeemable(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeemable", reflect.TypeOf((*MockPromotionService)(nil).Redeemable), userId, code)
+}
+
+// RemoveFromCart mocks base method.
+func (m *MockPromotionService) RemoveFromCart(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCart", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromCart indicates an expected call of RemoveFromCart.
+func (mr *MockPromotionServiceMockRecorder) RemoveFromCart(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockPromotionService)(nil).RemoveFromCart), userId)
+}
@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+var (
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrDuplicatePromotion     = errors.New("promotion code already exists")
+	ErrInvalidPromotion       = errors.New("promotion is missing the values for its kind")
+	ErrPromotionInactive      = errors.New("promotion is not active")
+	ErrPromotionUsedUp        = errors.New("promotion has no uses left")
+	ErrPromotionNotApplicable = errors.New("promotion does not apply to any cart item")
+	ErrNoPromotionApplied     = errors.New("no promotion applied to cart")
+)
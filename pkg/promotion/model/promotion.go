@@ -0,0 +1,118 @@
+package model
+
+import (
+	pricingModel "movie-rent/pkg/pricing/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	KindPercentage = "percentage"
+	KindFixed      = "fixed"
+	KindBuyXGetY   = "buy_x_get_y"
+)
+
+type Promotion struct {
+	Id             int        `json:"id"`
+	Code           string     `json:"code"`
+	Kind           string     `json:"kind"`
+	PercentOff     int        `json:"percentOff,omitempty"`
+	AmountOffCents int        `json:"amountOffCents,omitempty"`
+	BuyQuantity    int        `json:"buyQuantity,omitempty"`
+	GetQuantity    int        `json:"getQuantity,omitempty"`
+	Genre          *string    `json:"genre"`
+	MovieId        *int       `json:"movieId"`
+	StartsAt       time.Time  `json:"startsAt"`
+	EndsAt         *time.Time `json:"endsAt"`
+	MaxUses        *int       `json:"maxUses"`
+	MaxUsesPerUser *int       `json:"maxUsesPerUser"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type PromotionRequest struct {
+	Code           string     `json:"code"  binding:"required"`
+	Kind           string     `json:"kind"  binding:"required,oneof=percentage fixed buy_x_get_y"`
+	PercentOff     int        `json:"percentOff"  binding:"gte=0,lte=100"`
+	AmountOffCents int        `json:"amountOffCents"  binding:"gte=0"`
+	BuyQuantity    int        `json:"buyQuantity"  binding:"gte=0"`
+	GetQuantity    int        `json:"getQuantity"  binding:"gte=0"`
+	Genre          *string    `json:"genre"`
+	MovieId        *int       `json:"movieId"`
+	StartsAt       time.Time  `json:"startsAt"  binding:"required"`
+	EndsAt         *time.Time `json:"endsAt"`
+	MaxUses        *int       `json:"maxUses"  binding:"omitempty,gt=0"`
+	MaxUsesPerUser *int       `json:"maxUsesPerUser"  binding:"omitempty,gt=0"`
+}
+
+type ApplyPromotionRequest struct {
+	Code string `json:"code"  binding:"required"`
+}
+
+// Usage is how often a promotion has been redeemed, overall and by one user.
+type Usage struct {
+	Total   int
+	ForUser int
+}
+
+// NormalizeCode makes codes case-insensitive for customers.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p Promotion) IsActive(now time.Time) bool {
+	if now.Before(p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+func (p Promotion) HasUsesLeft(usage Usage) bool {
+	if p.MaxUses != nil && usage.Total >= *p.MaxUses {
+		return false
+	}
+	return p.MaxUsesPerUser == nil || usage.ForUser < *p.MaxUsesPerUser
+}
+
+// IsEligible reports whether the promotion covers the line's movie.
+func (p Promotion) IsEligible(line pricingModel.Line) bool {
+	if p.MovieId != nil && *p.MovieId != line.MovieId {
+		return false
+	}
+	return p.Genre == nil || strings.EqualFold(*p.Genre, line.Genre)
+}
+
+// ApplyCart discounts the eligible lines; it lets a promotion run as a cart
+// rule in the pricing engine.
+func (p Promotion) ApplyCart(lines []pricingModel.PricedLine, now time.Time) {
+	var eligible []*pricingModel.PricedLine
+	for i := range lines {
+		if p.IsEligible(lines[i].Line) {
+			eligible = append(eligible, &lines[i])
+		}
+	}
+
+	rule := "promo:" + p.Code
+	switch p.Kind {
+	case KindPercentage:
+		for _, line := range eligible {
+			line.Discount(rule, line.TotalCents*p.PercentOff/100)
+		}
+	case KindFixed:
+		remaining := p.AmountOffCents
+		for _, line := range eligible {
+			remaining -= line.Discount(rule, remaining)
+		}
+	case KindBuyXGetY:
+		group := p.BuyQuantity + p.GetQuantity
+		if group == 0 {
+			return
+		}
+		// The cheapest lines are the free ones.
+		sort.SliceStable(eligible, func(i, j int) bool { return eligible[i].TotalCents < eligible[j].TotalCents })
+		free := len(eligible) / group * p.GetQuantity
+		for _, line := range eligible[:free] {
+			line.Discount(rule, line.TotalCents)
+		}
+	}
+}
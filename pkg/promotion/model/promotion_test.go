@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	pricingModel "movie-rent/pkg/pricing/model"
+	"testing"
+	"time"
+)
+
+type PromotionTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestPromotionTestSuite(t *testing.T) {
+	suite.Run(t, new(PromotionTestSuite))
+}
+
+func (suite *PromotionTestSuite) SetupTest() {
+	suite.now = time.Date(2025, time.October, 10, 12, 0, 0, 0, time.UTC)
+}
+
+func priced(movieId int, genre string, totalCents int) pricingModel.PricedLine {
+	return pricingModel.PricedLine{
+		Line:       pricingModel.Line{MovieId: movieId, Genre: genre},
+		PriceCents: totalCents,
+		TotalCents: totalCents,
+	}
+}
+
+func (suite *PromotionTestSuite) Test_ApplyCart_ShouldDiscountOnlyEligibleGenre() {
+	genre := "horror"
+	promotion := Promotion{Code: "SPOOKY", Kind: KindPercentage, PercentOff: 20, Genre: &genre}
+	lines := []pricingModel.PricedLine{priced(1, "Horror", 500), priced(2, "Comedy", 500)}
+
+	promotion.ApplyCart(lines, suite.now)
+
+	suite.Equal(100, lines[0].DiscountCents)
+	suite.Equal(0, lines[1].DiscountCents)
+	suite.Equal("promo:SPOOKY", lines[0].Adjustments[0].Rule)
+}
+
+func (suite *PromotionTestSuite) Test_ApplyCart_ShouldSpreadFixedAmountAcrossLines() {
+	promotion := Promotion{Code: "FIVER", Kind: KindFixed, AmountOffCents: 500}
+	lines := []pricingModel.PricedLine{priced(1, "Action", 300), priced(2, "Action", 400)}
+
+	promotion.ApplyCart(lines, suite.now)
+
+	suite.Equal(300, lines[0].DiscountCents)
+	suite.Equal(200, lines[1].DiscountCents)
+}
+
+func (suite *PromotionTestSuite) Test_ApplyCart_ShouldMakeCheapestLinesFree() {
+	promotion := Promotion{Code: "TWOFORONE", Kind: KindBuyXGetY, BuyQuantity: 1, GetQuantity: 1}
+	lines := []pricingModel.PricedLine{priced(1, "Action", 300), priced(2, "Action", 200), priced(3, "Action", 400)}
+
+	promotion.ApplyCart(lines, suite.now)
+
+	suite.Equal(0, lines[0].DiscountCents)
+	suite.Equal(200, lines[1].DiscountCents)
+	suite.Equal(0, lines[2].DiscountCents)
+}
+
+func (suite *PromotionTestSuite) Test_ApplyCart_ShouldRespectMovieEligibility() {
+	movieId := 2
+	promotion := Promotion{Code: "ONE", Kind: KindPercentage, PercentOff: 50, MovieId: &movieId}
+	lines := []pricingModel.PricedLine{priced(1, "Action", 300), priced(2, "Action", 200)}
+
+	promotion.ApplyCart(lines, suite.now)
+
+	suite.Equal(0, lines[0].DiscountCents)
+	suite.Equal(100, lines[1].DiscountCents)
+}
+
+func (suite *PromotionTestSuite) Test_IsActive_ShouldHonourValidityWindow() {
+	endsAt := suite.now.Add(time.Hour)
+	promotion := Promotion{StartsAt: suite.now.Add(-time.Hour), EndsAt: &endsAt}
+
+	suite.True(promotion.IsActive(suite.now))
+	suite.False(promotion.IsActive(endsAt))
+	suite.False(promotion.IsActive(suite.now.Add(-2 * time.Hour)))
+}
+
+func (suite *PromotionTestSuite) Test_HasUsesLeft_ShouldCheckGlobalAndPerUserLimits() {
+	maxUses, perUser := 10, 1
+	promotion := Promotion{MaxUses: &maxUses, MaxUsesPerUser: &perUser}
+
+	suite.True(promotion.HasUsesLeft(Usage{Total: 9, ForUser: 0}))
+	suite.False(promotion.HasUsesLeft(Usage{Total: 10, ForUser: 0}))
+	suite.False(promotion.HasUsesLeft(Usage{Total: 3, ForUser: 1}))
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/promotion/model"
+	"time"
+)
+
+const (
+	PromotionColumns = `id, code, kind, percent_off, amount_off_cents, buy_quantity, get_quantity, genre, movie_id, ` +
+		`starts_at, ends_at, max_uses, max_uses_per_user, created_at`
+	InsertPromotionSQL = `INSERT INTO promotions(code, kind, percent_off, amount_off_cents, buy_quantity, get_quantity, genre, movie_id, ` +
+		`starts_at, ends_at, max_uses, max_uses_per_user, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+	SelectPromotionsSQL      = `SELECT ` + PromotionColumns + ` FROM promotions ORDER BY starts_at DESC, id DESC`
+	SelectPromotionByCodeSQL = `SELECT ` + PromotionColumns + ` FROM promotions WHERE code = $1`
+	SelectCartPromotionSQL   = `SELECT ` + PromotionColumns + ` FROM promotions WHERE id = (SELECT promotion_id FROM cart_promotions WHERE user_id = $1)`
+	SelectPromotionUsageSQL  = `SELECT COUNT(*), COUNT(*) FILTER (WHERE user_id = $2) FROM rental_orders WHERE promotion_id = $1`
+	UpsertCartPromotionSQL   = `INSERT INTO cart_promotions(user_id, promotion_id, applied_at) VALUES ($1, $2, $3) ` +
+		`ON CONFLICT (user_id) DO UPDATE SET promotion_id = EXCLUDED.promotion_id, applied_at = EXCLUDED.applied_at`
+	DeleteCartPromotionSQL = `DELETE FROM cart_promotions WHERE user_id = $1`
+)
+
+type PromotionRepository interface {
+	CreatePromotion(promotion model.Promotion) (int, error)
+	GetPromotions() ([]model.Promotion, error)
+	GetPromotionByCode(code string) (model.Promotion, error)
+	GetUsage(promotionId int, userId int) (model.Usage, error)
+	SetCartPromotion(userId int, promotionId int, appliedAt time.Time) error
+	GetCartPromotion(userId int) (model.Promotion, error)
+	RemoveCartPromotion(userId int) error
+}
+
+type promotionRepo struct {
+	db *sqlx.DB
+}
+
+func NewPromotionRepository(db *sqlx.DB) PromotionRepository {
+	return &promotionRepo{db: db}
+}
+
+func (m promotionRepo) CreatePromotion(p model.Promotion) (int, error) {
+	var id int
+	err := m.db.QueryRow(InsertPromotionSQL, p.Code, p.Kind, p.PercentOff, p.AmountOffCents, p.BuyQuantity, p.GetQuantity,
+		p.Genre, p.MovieId, p.StartsAt, p.EndsAt, p.MaxUses, p.MaxUsesPerUser, p.CreatedAt).Scan(&id)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrDuplicatePromotion
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert promotion: %w", err)
+	}
+	fmt.Println("Successfully inserted promotion. Id:", id)
+	return id, nil
+}
+
+func (m promotionRepo) GetPromotions() ([]model.Promotion, error) {
+	rows, err := m.db.Query(SelectPromotionsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch promotions: %w", err)
+	}
+	defer rows.Close()
+
+	promotions := []model.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion: %w", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, rows.Err()
+}
+
+func (m promotionRepo) GetPromotionByCode(code string) (model.Promotion, error) {
+	promotion, err := scanPromotion(m.db.QueryRow(SelectPromotionByCodeSQL, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Promotion{}, model.ErrPromotionNotFound
+	}
+	if err != nil {
+		return model.Promotion{}, fmt.Errorf("failed to fetch promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+func (m promotionRepo) GetUsage(promotionId int, userId int) (model.Usage, error) {
+	var usage model.Usage
+	err := m.db.QueryRow(SelectPromotionUsageSQL, promotionId, userId).Scan(&usage.Total, &usage.ForUser)
+	if err != nil {
+		return model.Usage{}, fmt.Errorf("failed to fetch promotion usage: %w", err)
+	}
+	return usage, nil
+}
+
+func (m promotionRepo) SetCartPromotion(userId int, promotionId int, appliedAt time.Time) error {
+	if _, err := m.db.Exec(UpsertCartPromotionSQL, userId, promotionId, appliedAt); err != nil {
+		return fmt.Errorf("failed to apply promotion: %w", err)
+	}
+	return nil
+}
+
+func (m promotionRepo) GetCartPromotion(userId int) (model.Promotion, error) {
+	promotion, err := scanPromotion(m.db.QueryRow(SelectCartPromotionSQL, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Promotion{}, model.ErrNoPromotionApplied
+	}
+	if err != nil {
+		return model.Promotion{}, fmt.Errorf("failed to fetch cart promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+func (m promotionRepo) RemoveCartPromotion(userId int) error {
+	res, err := m.db.Exec(DeleteCartPromotionSQL, userId)
+	if err != nil {
+		return fmt.Errorf("failed to remove promotion: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrNoPromotionApplied
+	}
+	return nil
+}
+
+func scanPromotion(row postgres.Scanner) (model.Promotion, error) {
+	var p model.Promotion
+	err := row.Scan(&p.Id, &p.Code, &p.Kind, &p.PercentOff, &p.AmountOffCents, &p.BuyQuantity, &p.GetQuantity,
+		&p.Genre, &p.MovieId, &p.StartsAt, &p.EndsAt, &p.MaxUses, &p.MaxUsesPerUser, &p.CreatedAt)
+	return p, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/promotion/model"
+	"testing"
+)
+
+type PromotionRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository PromotionRepository
+}
+
+func TestPromotionRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PromotionRepositoryTestSuite))
+}
+
+func (suite *PromotionRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewPromotionRepository(suite.mockedDB)
+}
+
+func (suite *PromotionRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *PromotionRepositoryTestSuite) Test_GetPromotionByCode_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectPromotionByCodeSQL).WithArgs("NOPE").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetPromotionByCode("NOPE")
+
+	suite.ErrorIs(err, model.ErrPromotionNotFound)
+}
+
+func (suite *PromotionRepositoryTestSuite) Test_GetUsage_ShouldCountOrdersOverallAndForUser() {
+	suite.mockDB.ExpectQuery(SelectPromotionUsageSQL).WithArgs(3, 1001).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "for_user"}).AddRow(12, 1))
+
+	usage, err := suite.testRepository.GetUsage(3, 1001)
+
+	suite.Nil(err)
+	suite.Equal(model.Usage{Total: 12, ForUser: 1}, usage)
+}
+
+func (suite *PromotionRepositoryTestSuite) Test_RemoveCartPromotion_ShouldReturnErrorWhenNoneApplied() {
+	suite.mockDB.ExpectExec(DeleteCartPromotionSQL).WithArgs(1001).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.RemoveCartPromotion(1001)
+
+	suite.ErrorIs(err, model.ErrNoPromotionApplied)
+}
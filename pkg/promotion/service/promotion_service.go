@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"movie-rent/pkg/promotion/model"
+	"movie-rent/pkg/promotion/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/promotion/service/promotion_service.go -destination=pkg/promotion/mocks/promotion_service_mock.go -package=mocks
+
+type PromotionService interface {
+	CreatePromotion(request model.PromotionRequest) (model.Promotion, error)
+	GetPromotions() ([]model.Promotion, error)
+	Redeemable(userId int, code string) (model.Promotion, error)
+	ApplyToCart(userId int, promotion model.Promotion) error
+	GetCartPromotion(userId int) (*model.Promotion, error)
+	RemoveFromCart(userId int) error
+}
+
+type promotionService struct {
+	repository repository.PromotionRepository
+}
+
+func NewPromotionService(repository repository.PromotionRepository) PromotionService {
+	return promotionService{repository: repository}
+}
+
+func (m promotionService) CreatePromotion(request model.PromotionRequest) (model.Promotion, error) {
+	promotion := model.Promotion{
+		Code:           model.NormalizeCode(request.Code),
+		Kind:           request.Kind,
+		Genre:          request.Genre,
+		MovieId:        request.MovieId,
+		StartsAt:       request.StartsAt,
+		EndsAt:         request.EndsAt,
+		MaxUses:        request.MaxUses,
+		MaxUsesPerUser: request.MaxUsesPerUser,
+		CreatedAt:      time.Now(),
+	}
+	switch request.Kind {
+	case model.KindPercentage:
+		promotion.PercentOff = request.PercentOff
+		if promotion.PercentOff == 0 {
+			return model.Promotion{}, model.ErrInvalidPromotion
+		}
+	case model.KindFixed:
+		promotion.AmountOffCents = request.AmountOffCents
+		if promotion.AmountOffCents == 0 {
+			return model.Promotion{}, model.ErrInvalidPromotion
+		}
+	case model.KindBuyXGetY:
+		promotion.BuyQuantity = request.BuyQuantity
+		promotion.GetQuantity = request.GetQuantity
+		if promotion.BuyQuantity == 0 || promotion.GetQuantity == 0 {
+			return model.Promotion{}, model.ErrInvalidPromotion
+		}
+	}
+	if request.EndsAt != nil && !request.EndsAt.After(request.StartsAt) {
+		return model.Promotion{}, model.ErrInvalidPromotion
+	}
+
+	id, err := m.repository.CreatePromotion(promotion)
+	if err != nil {
+		fmt.Println("failed to create promotion:", err.Error())
+		return model.Promotion{}, err
+	}
+	promotion.Id = id
+	return promotion, nil
+}
+
+func (m promotionService) GetPromotions() ([]model.Promotion, error) {
+	promotions, err := m.repository.GetPromotions()
+	if err != nil {
+		fmt.Println("failed to find promotions:", err.Error())
+		return []model.Promotion{}, err
+	}
+	return promotions, nil
+}
+
+// Redeemable looks the code up and checks that the user may still use it.
+func (m promotionService) Redeemable(userId int, code string) (model.Promotion, error) {
+	promotion, err := m.repository.GetPromotionByCode(model.NormalizeCode(code))
+	if err != nil {
+		fmt.Println("failed to find promotion:", err.Error())
+		return model.Promotion{}, err
+	}
+	if err = m.checkRedeemable(userId, promotion); err != nil {
+		return model.Promotion{}, err
+	}
+	return promotion, nil
+}
+
+func (m promotionService) ApplyToCart(userId int, promotion model.Promotion) error {
+	if err := m.repository.SetCartPromotion(userId, promotion.Id, time.Now()); err != nil {
+		fmt.Println("failed to apply promotion:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetCartPromotion returns the promotion applied to the user's cart, or nil
+// when there is none. A promotion that lapsed or ran out since it was applied
+// is reported as an error, so checkout does not quietly charge full price.
+func (m promotionService) GetCartPromotion(userId int) (*model.Promotion, error) {
+	promotion, err := m.repository.GetCartPromotion(userId)
+	if errors.Is(err, model.ErrNoPromotionApplied) {
+		return nil, nil
+	}
+	if err != nil {
+		fmt.Println("failed to find cart promotion:", err.Error())
+		return nil, err
+	}
+
+	if err = m.checkRedeemable(userId, promotion); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (m promotionService) RemoveFromCart(userId int) error {
+	if err := m.repository.RemoveCartPromotion(userId); err != nil {
+		fmt.Println("failed to remove promotion:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (m promotionService) checkRedeemable(userId int, promotion model.Promotion) error {
+	if !promotion.IsActive(time.Now()) {
+		return model.ErrPromotionInactive
+	}
+	usage, err := m.repository.GetUsage(promotion.Id, userId)
+	if err != nil {
+		fmt.Println("failed to find promotion usage:", err.Error())
+		return err
+	}
+	if !promotion.HasUsesLeft(usage) {
+		return model.ErrPromotionUsedUp
+	}
+	return nil
+}
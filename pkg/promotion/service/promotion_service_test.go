@@ -0,0 +1,106 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/promotion/mocks"
+	"movie-rent/pkg/promotion/model"
+	"testing"
+	"time"
+)
+
+type PromotionServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockPromotionRepository
+
+	promotionService PromotionService
+}
+
+func TestPromotionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PromotionServiceTestSuite))
+}
+
+func (suite *PromotionServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockPromotionRepository(suite.mockController)
+
+	suite.promotionService = NewPromotionService(suite.mockRepository)
+}
+
+func (suite *PromotionServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *PromotionServiceTestSuite) Test_CreatePromotion_ShouldRejectPercentageWithoutPercent() {
+	request := model.PromotionRequest{Code: "SPOOKY", Kind: model.KindPercentage, StartsAt: time.Now()}
+
+	_, err := suite.promotionService.CreatePromotion(request)
+
+	suite.ErrorIs(err, model.ErrInvalidPromotion)
+}
+
+func (suite *PromotionServiceTestSuite) Test_CreatePromotion_ShouldRejectWindowEndingBeforeStart() {
+	startsAt := time.Now()
+	endsAt := startsAt.Add(-time.Hour)
+	request := model.PromotionRequest{Code: "FIVER", Kind: model.KindFixed, AmountOffCents: 500, StartsAt: startsAt, EndsAt: &endsAt}
+
+	_, err := suite.promotionService.CreatePromotion(request)
+
+	suite.ErrorIs(err, model.ErrInvalidPromotion)
+}
+
+func (suite *PromotionServiceTestSuite) Test_CreatePromotion_ShouldStoreNormalizedCode() {
+	request := model.PromotionRequest{Code: " twoForOne ", Kind: model.KindBuyXGetY, BuyQuantity: 1, GetQuantity: 1, PercentOff: 30, StartsAt: time.Now()}
+	suite.mockRepository.EXPECT().CreatePromotion(gomock.Any()).DoAndReturn(func(promotion model.Promotion) (int, error) {
+		suite.Equal("TWOFORONE", promotion.Code)
+		suite.Equal(0, promotion.PercentOff)
+		return 4, nil
+	}).Times(1)
+
+	promotion, err := suite.promotionService.CreatePromotion(request)
+
+	suite.Nil(err)
+	suite.Equal(4, promotion.Id)
+}
+
+func (suite *PromotionServiceTestSuite) Test_Redeemable_ShouldRejectExpiredPromotion() {
+	endsAt := time.Now().Add(-time.Hour)
+	promotion := model.Promotion{Id: 3, Code: "SPOOKY", StartsAt: endsAt.Add(-time.Hour), EndsAt: &endsAt}
+	suite.mockRepository.EXPECT().GetPromotionByCode("SPOOKY").Return(promotion, nil).Times(1)
+
+	_, err := suite.promotionService.Redeemable(1001, "spooky")
+
+	suite.ErrorIs(err, model.ErrPromotionInactive)
+}
+
+func (suite *PromotionServiceTestSuite) Test_Redeemable_ShouldRejectWhenUserUsedItUp() {
+	perUser := 1
+	promotion := model.Promotion{Id: 3, Code: "SPOOKY", StartsAt: time.Now().Add(-time.Hour), MaxUsesPerUser: &perUser}
+	suite.mockRepository.EXPECT().GetPromotionByCode("SPOOKY").Return(promotion, nil).Times(1)
+	suite.mockRepository.EXPECT().GetUsage(3, 1001).Return(model.Usage{Total: 5, ForUser: 1}, nil).Times(1)
+
+	_, err := suite.promotionService.Redeemable(1001, "SPOOKY")
+
+	suite.ErrorIs(err, model.ErrPromotionUsedUp)
+}
+
+func (suite *PromotionServiceTestSuite) Test_GetCartPromotion_ShouldReturnErrorWhenPromotionLapsed() {
+	endsAt := time.Now().Add(-time.Hour)
+	promotion := model.Promotion{Id: 3, Code: "SPOOKY", StartsAt: endsAt.Add(-time.Hour), EndsAt: &endsAt}
+	suite.mockRepository.EXPECT().GetCartPromotion(1001).Return(promotion, nil).Times(1)
+
+	applied, err := suite.promotionService.GetCartPromotion(1001)
+
+	suite.ErrorIs(err, model.ErrPromotionInactive)
+	suite.Nil(applied)
+}
+
+func (suite *PromotionServiceTestSuite) Test_GetCartPromotion_ShouldReturnNilWhenNoneApplied() {
+	suite.mockRepository.EXPECT().GetCartPromotion(1001).Return(model.Promotion{}, model.ErrNoPromotionApplied).Times(1)
+
+	applied, err := suite.promotionService.GetCartPromotion(1001)
+
+	suite.Nil(err)
+	suite.Nil(applied)
+}
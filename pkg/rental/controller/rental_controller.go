@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
+	"movie-rent/pkg/rental/model"
+	"movie-rent/pkg/rental/service"
+	"net/http"
+	"strconv"
+)
+
+type RentalController struct {
+	service service.RentalService
+}
+
+func NewRentalController(service service.RentalService) RentalController {
+	return RentalController{service: service}
+}
+
+func (m *RentalController) ReturnRental(ctx *gin.Context) {
+	fmt.Println("Returning rental")
+	id := ctx.Param("id")
+	rentalId, err := strconv.Atoi(id)
+	if id == "" || err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+
+	rental, err := m.service.ReturnRental(rentalId, userId)
+	if errors.Is(err, model.ErrRentalNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrRentalNotActive) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, rental)
+}
+
+func (m *RentalController) GetRentals(ctx *gin.Context) {
+	fmt.Println("Fetching rentals")
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	if id := ctx.Query("userId"); id != "" {
+		queryId, err := strconv.Atoi(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, "userId is invalid")
+			return
+		}
+		if queryId != userId {
+			ctx.JSON(http.StatusForbidden, authModel.ErrForbidden.Error())
+			return
+		}
+	}
+
+	history, err := m.service.GetRentals(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+func (m *RentalController) ReportLost(ctx *gin.Context) {
+	fmt.Println("Reporting rental lost")
+	id := ctx.Param("id")
+	rentalId, err := strconv.Atoi(id)
+	if id == "" || err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+
+	rental, err := m.service.ReportLost(rentalId, userId)
+	if errors.Is(err, model.ErrRentalNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrRentalNotActive) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, rental)
+}
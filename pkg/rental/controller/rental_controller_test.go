@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/middleware"
+	"movie-rent/pkg/rental/mocks"
+	"movie-rent/pkg/rental/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type RentalControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockRentalService *mocks.MockRentalService
+	testController    RentalController
+}
+
+func TestRentalControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(RentalControllerTestSuite))
+}
+
+func (suite *RentalControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Set(middleware.UserIdKey, 1001)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRentalService = mocks.NewMockRentalService(suite.mockController)
+	suite.testController = NewRentalController(suite.mockRentalService)
+}
+
+func (suite *RentalControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *RentalControllerTestSuite) Test_ReturnRental_ShouldReturnBadRequestWhenIdNotANumber() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/abc/return", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	suite.testController.ReturnRental(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_ReturnRental_ShouldReturnNotFoundWhenRentalMissing() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/1/return", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockRentalService.EXPECT().ReturnRental(1, 1001).Return(model.Rental{}, model.ErrRentalNotFound).Times(1)
+
+	suite.testController.ReturnRental(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_ReturnRental_ShouldReturnConflictWhenAlreadyReturned() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/1/return", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockRentalService.EXPECT().ReturnRental(1, 1001).Return(model.Rental{}, model.ErrRentalNotActive).Times(1)
+
+	suite.testController.ReturnRental(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_ReturnRental_ShouldReturnRental() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/1/return", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockRentalService.EXPECT().ReturnRental(1, 1001).Return(model.Rental{Id: 1, Status: model.StatusReturned}, nil).Times(1)
+
+	suite.testController.ReturnRental(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_ReportLost_ShouldReturnConflictWhenAlreadyClosed() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/1/lost", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockRentalService.EXPECT().ReportLost(1, 1001).Return(model.Rental{}, model.ErrRentalNotActive).Times(1)
+
+	suite.testController.ReportLost(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_ReportLost_ShouldReturnLostRental() {
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/rentals/1/lost", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1"}}
+	suite.mockRentalService.EXPECT().ReportLost(1, 1001).Return(model.Rental{Id: 1, Status: model.StatusLost}, nil).Times(1)
+
+	suite.testController.ReportLost(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_GetRentals_ShouldReturnForbiddenForAnotherUser() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/rentals?userId=2002", nil)
+
+	suite.testController.GetRentals(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_GetRentals_ShouldDefaultToCaller() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/rentals", nil)
+	suite.mockRentalService.EXPECT().GetRentals(1001).Return(model.RentalHistory{}, nil).Times(1)
+
+	suite.testController.GetRentals(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_GetRentals_ShouldReturnInternalServerErrorWhenServiceCallFailed() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/rentals?userId=1001", nil)
+	suite.mockRentalService.EXPECT().GetRentals(1001).Return(model.RentalHistory{}, errors.New("error")).Times(1)
+
+	suite.testController.GetRentals(suite.context)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
+
+func (suite *RentalControllerTestSuite) Test_GetRentals_ShouldReturnRentalHistory() {
+	history := model.RentalHistory{Active: []model.Rental{{Id: 1}}, Past: []model.Rental{}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/rentals?userId=1001", nil)
+	suite.mockRentalService.EXPECT().GetRentals(1001).Return(history, nil).Times(1)
+
+	suite.testController.GetRentals(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/rental/repository/rental_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/rental/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRentalRepository is a mock of RentalRepository interface.
+type MockRentalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRentalRepositoryMockRecorder
+}
+
+// MockRentalRepositoryMockRecorder is the mock recorder for MockRentalRepository.
+type MockRentalRepositoryMockRecorder struct {
+	mock *MockRentalRepository
+}
+
+// NewMockRentalRepository creates a new mock instance.
+func NewMockRentalRepository(ctrl *gomock.Controller) *MockRentalRepository {
+	mock := &MockRentalRepository{ctrl: ctrl}
+	mock.recorder = &MockRentalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRentalRepository) EXPECT() *MockRentalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockRentalRepository) CreateOrder(order model.RentalOrder) (model.RentalOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", order)
+	ret0, _ := ret[0].(model.RentalOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockRentalRepositoryMockRecorder) CreateOrder(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockRentalRepository)(nil).CreateOrder), order)
+}
+
+// GetOrder mocks base method.
+func (m *MockRentalRepository) GetOrder(orderId int) (model.RentalOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", orderId)
+	ret0, _ := ret[0].(model.RentalOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockRentalRepositoryMockRecorder) GetOrder(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockRentalRepository)(nil).GetOrder), orderId)
+}
+
+// GetOverdueRentals mocks base method.
+func (m *MockRentalRepository) GetOverdueRentals() ([]model.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueRentals")
+	ret0, _ := ret[0].([]model.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueRentals indicates an expected call of GetOverdueRentals.
+func (mr *MockRentalRepositoryMockRecorder) GetOverdueRentals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueRentals", reflect.TypeOf((*MockRentalRepository)(nil).GetOverdueRentals))
+}
+
+// GetRental mocks base method.
+func (m *MockRentalRepository) GetRental(rentalId int) (model.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRental", rentalId)
+	ret0, _ := ret[0].(model.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRental indicates an expected call of GetRental.
+func (mr *MockRentalRepositoryMockRecorder) GetRental(rentalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRental", reflect.TypeOf((*MockRentalRepository)(nil).GetRental), rentalId)
+}
+
+// GetRentals mocks base method.
+func (m *MockRentalRepository) GetRentals(userId int) ([]model.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRentals", userId)
+	ret0, _ := ret[0].([]model.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRentals indicates an expected call of GetRentals.
+func (mr *MockRentalRepositoryMockRecorder) GetRentals(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentals", reflect.TypeOf((*MockRentalRepository)(nil).GetRentals), userId)
+}
+
+// MarkLost mocks base method.
+func (m *MockRentalRepository) MarkLost(rentalId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLost", rentalId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkLost indicates an expected call of MarkLost.
+func (mr *MockRentalRepositoryMockRecorder) MarkLost(rentalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLost", reflect.TypeOf((*MockRentalRepository)(nil).MarkLost), rentalId)
+}
+
+// MarkOverdue mocks base method.
+func (m *MockRentalRepository) MarkOverdue(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockRentalRepositoryMockRecorder) MarkOverdue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockRentalRepository)(nil).MarkOverdue), now)
+}
+
+// MarkReturned mocks base method.
+func (m *MockRentalRepository) MarkReturned(rentalId int, returnedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReturned", rentalId, returnedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReturned indicates an expected call of MarkReturned.
+func (mr *MockRentalRepositoryMockRecorder) MarkReturned(rentalId, returnedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReturned", reflect.TypeOf((*MockRentalRepository)(nil).MarkReturned), rentalId, returnedAt)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/rental/service/rental_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/rental/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRentalService is a mock of RentalService interface.
+type MockRentalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRentalServiceMockRecorder
+}
+
+// MockRentalServiceMockRecorder is the mock recorder for MockRentalService.
+type MockRentalServiceMockRecorder struct {
+	mock *MockRentalService
+}
+
+// NewMockRentalService creates a new mock instance.
+func NewMockRentalService(ctrl *gomock.Controller) *MockRentalService {
+	mock := &MockRentalService{ctrl: ctrl}
+	mock.recorder = &MockRentalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRentalService) EXPECT() *MockRentalServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRentals mocks base method.
+func (m *MockRentalService) GetRentals(userId int) (model.RentalHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRentals", userId)
+	ret0, _ := ret[0].(model.RentalHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRentals indicates an expected call of GetRentals.
+func (mr *MockRentalServiceMockRecorder) GetRentals(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRentals", reflect.TypeOf((*MockRentalService)(nil).GetRentals), userId)
+}
+
+// ReportLost mocks base method.
+func (m *MockRentalService) ReportLost(rentalId, userId int) (model.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportLost", rentalId, userId)
+	ret0, _ := ret[0].(model.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportLost indicates an expected call of ReportLost.
+func (mr *MockRentalServiceMockRecorder) ReportLost(rentalId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportLost", reflect.TypeOf((*MockRentalService)(nil).ReportLost), rentalId, userId)
+}
+
+// ReturnRental mocks base method.
+func (m *MockRentalService) ReturnRental(rentalId, userId int) (model.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnRental", rentalId, userId)
+	ret0, _ := ret[0].(model.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnRental indicates an expected call of ReturnRental.
+func (mr *MockRentalServiceMockRecorder) ReturnRental(rentalId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnRental", reflect.TypeOf((*MockRentalService)(nil).ReturnRental), rentalId, userId)
+}
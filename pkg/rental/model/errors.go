@@ -0,0 +1,11 @@
+package model
+
+import "errors"
+
+var (
+	ErrRentalNotFound  = errors.New("rental not found")
+	ErrOrderNotFound   = errors.New("rental order not found")
+	ErrRentalNotActive = errors.New("rental is already closed")
+	ErrCartChanged     = errors.New("cart changed during checkout")
+	ErrMovieWithdrawn  = errors.New("a movie in the cart is no longer offered")
+)
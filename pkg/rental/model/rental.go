@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+const (
+	StatusActive   = "active"
+	StatusOverdue  = "overdue"
+	StatusReturned = "returned"
+	StatusLost     = "lost"
+)
+
+type RentalOrder struct {
+	Id            int       `json:"id"`
+	UserId        int       `json:"userId"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Rentals       []Rental  `json:"rentals"`
+	PromotionId   *int      `json:"-"`
+	PromotionCode string    `json:"promotionCode,omitempty"`
+	SubtotalCents int       `json:"subtotalCents"`
+	DiscountCents int       `json:"discountCents"`
+	TaxCents      int       `json:"taxCents"`
+	TotalCents    int       `json:"totalCents"`
+	PaymentId     *int      `json:"paymentId,omitempty"`
+}
+
+type Rental struct {
+	Id          int        `json:"id"`
+	OrderId     int        `json:"orderId"`
+	CartItemId  int        `json:"-"`
+	UserId      int        `json:"userId"`
+	MovieId     int        `json:"movieId"`
+	MovieName   string     `json:"movieName"`
+	ReleaseYear int        `json:"releaseYear"`
+	Status      string     `json:"status"`
+	RentedAt    time.Time  `json:"rentedAt"`
+	DueAt       time.Time  `json:"dueAt"`
+	ReturnedAt  *time.Time `json:"returnedAt"`
+	CopyId      *int       `json:"copyId"`
+}
+
+type RentalHistory struct {
+	Active []Rental `json:"active"`
+	Past   []Rental `json:"past"`
+}
+
+// IsOpen reports whether the copy is still out with the customer.
+func (r Rental) IsOpen() bool {
+	return r.Status == StatusActive || r.Status == StatusOverdue
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	paymentModel "movie-rent/pkg/payments/model"
+	promotionModel "movie-rent/pkg/promotion/model"
+	"movie-rent/pkg/rental/model"
+	"time"
+)
+
+const (
+	InsertRentalOrderSQL = `INSERT INTO rental_orders(user_id, created_at, promotion_id, subtotal_cents, discount_cents, tax_cents, total_cents) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	LockPromotionSQL     = `SELECT (p.max_uses IS NULL OR (SELECT COUNT(*) FROM rental_orders o WHERE o.promotion_id = p.id) < p.max_uses) ` +
+		`AND (p.max_uses_per_user IS NULL OR (SELECT COUNT(*) FROM rental_orders o WHERE o.promotion_id = p.id AND o.user_id = $2) < p.max_uses_per_user) ` +
+		`FROM promotions p WHERE p.id = $1 FOR UPDATE`
+	DeleteCartPromotionSQL = `DELETE FROM cart_promotions WHERE user_id = $1`
+	LockOrderPaymentSQL    = `SELECT order_id, status FROM payments WHERE id = $1 FOR UPDATE`
+	LinkPaymentSQL         = `UPDATE payments SET order_id = $1 WHERE id = $2 AND order_id IS NULL`
+	SelectRentalOrderSQL   = `SELECT o.id, o.user_id, o.created_at, o.promotion_id, COALESCE(p.code, ''), o.subtotal_cents, o.discount_cents, o.tax_cents, o.total_cents ` +
+		`FROM rental_orders o LEFT JOIN promotions p ON p.id = o.promotion_id WHERE o.id = $1`
+	SelectRentalsByOrderSQL = `SELECT id, order_id, user_id, movie_id, movie_name, release_year, status, rented_at, due_at, returned_at, copy_id FROM rentals WHERE order_id = $1 ORDER BY id`
+	InsertRentalSQL         = `INSERT INTO rentals(order_id, user_id, movie_id, movie_name, release_year, status, rented_at, due_at, copy_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	DeleteCartItemSQL       = `DELETE FROM movie_carts c USING movies m WHERE c.id = $1 AND c.user_id = $2 AND m.id = c.movie_id RETURNING c.copy_id, m.deleted_at IS NOT NULL`
+	UpdateCopyRentedSQL     = `UPDATE movie_copies SET status = 'rented', updated_at = now() WHERE id = $1 AND status = 'reserved'`
+	SelectRentalByIdSQL     = `SELECT id, order_id, user_id, movie_id, movie_name, release_year, status, rented_at, due_at, returned_at, copy_id FROM rentals WHERE id = $1`
+	SelectRentalsByUserSQL  = `SELECT id, order_id, user_id, movie_id, movie_name, release_year, status, rented_at, due_at, returned_at, copy_id FROM rentals WHERE user_id = $1 ORDER BY rented_at DESC, id DESC`
+	UpdateRentalReturnSQL   = `UPDATE rentals SET status = $1, returned_at = $2 WHERE id = $3 AND status IN ('active', 'overdue') RETURNING copy_id`
+	UpdateCopyReturnedSQL   = `UPDATE movie_copies SET status = 'available', updated_at = now() WHERE id = $1 AND status = 'rented'`
+	UpdateRentalLostSQL     = `UPDATE rentals SET status = $1 WHERE id = $2 AND status IN ('active', 'overdue') RETURNING copy_id`
+	UpdateCopyLostSQL       = `UPDATE movie_copies SET status = 'lost', updated_at = now() WHERE id = $1 AND status = 'rented'`
+	UpdateRentalsOverdueSQL = `UPDATE rentals SET status = 'overdue' WHERE status = 'active' AND due_at < $1`
+	SelectOverdueRentalsSQL = `SELECT id, order_id, user_id, movie_id, movie_name, release_year, status, rented_at, due_at, returned_at, copy_id FROM rentals WHERE status = 'overdue' ORDER BY due_at, id`
+)
+
+type RentalRepository interface {
+	CreateOrder(order model.RentalOrder) (model.RentalOrder, error)
+	GetOrder(orderId int) (model.RentalOrder, error)
+	GetRental(rentalId int) (model.Rental, error)
+	GetRentals(userId int) ([]model.Rental, error)
+	MarkReturned(rentalId int, returnedAt time.Time) error
+	MarkLost(rentalId int) error
+	MarkOverdue(now time.Time) (int64, error)
+	GetOverdueRentals() ([]model.Rental, error)
+}
+
+type rentalRepo struct {
+	db *sqlx.DB
+}
+
+func NewRentalRepository(db *sqlx.DB) RentalRepository {
+	return &rentalRepo{db: db}
+}
+
+// CreateOrder stores the order and its rentals, removes the checked out cart
+// rows and turns their reserved copies into rented ones in a single
+// transaction, so the cart is only emptied once the rentals are committed.
+// A promotion on the order is locked while its usage limits are rechecked, so
+// concurrent checkouts cannot redeem it past them. The payment that paid for
+// the order is locked first and linked to it in the same transaction, so
+// checkouts sharing an idempotency key run one after the other and the later
+// one learns the payment already paid for an order.
+func (m rentalRepo) CreateOrder(order model.RentalOrder) (model.RentalOrder, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return model.RentalOrder{}, fmt.Errorf("failed to begin checkout: %w", err)
+	}
+	defer tx.Rollback()
+
+	if order.PaymentId != nil {
+		var linkedOrderId *int
+		var status string
+		err = tx.QueryRow(LockOrderPaymentSQL, *order.PaymentId).Scan(&linkedOrderId, &status)
+		if err != nil {
+			return model.RentalOrder{}, fmt.Errorf("failed to lock payment: %w", err)
+		}
+		if linkedOrderId != nil {
+			return model.RentalOrder{}, paymentModel.ErrPaymentLinked
+		}
+		if status != paymentModel.StatusAuthorized {
+			return model.RentalOrder{}, paymentModel.ErrPaymentVoided
+		}
+	}
+
+	if order.PromotionId != nil {
+		var usesLeft bool
+		err = tx.QueryRow(LockPromotionSQL, *order.PromotionId, order.UserId).Scan(&usesLeft)
+		if err != nil {
+			return model.RentalOrder{}, fmt.Errorf("failed to lock promotion: %w", err)
+		}
+		if !usesLeft {
+			return model.RentalOrder{}, promotionModel.ErrPromotionUsedUp
+		}
+	}
+
+	err = tx.QueryRow(InsertRentalOrderSQL, order.UserId, order.CreatedAt, order.PromotionId, order.SubtotalCents,
+		order.DiscountCents, order.TaxCents, order.TotalCents).Scan(&order.Id)
+	if err != nil {
+		return model.RentalOrder{}, fmt.Errorf("failed to insert rental order: %w", err)
+	}
+
+	for i := range order.Rentals {
+		rental := &order.Rentals[i]
+		rental.OrderId = order.Id
+
+		var withdrawn bool
+		err = tx.QueryRow(DeleteCartItemSQL, rental.CartItemId, order.UserId).Scan(&rental.CopyId, &withdrawn)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RentalOrder{}, model.ErrCartChanged
+		}
+		if err != nil {
+			return model.RentalOrder{}, fmt.Errorf("failed to remove cart item: %w", err)
+		}
+		if withdrawn {
+			return model.RentalOrder{}, model.ErrMovieWithdrawn
+		}
+
+		if rental.CopyId != nil {
+			res, err := tx.Exec(UpdateCopyRentedSQL, *rental.CopyId)
+			if err != nil {
+				return model.RentalOrder{}, fmt.Errorf("failed to rent copy: %w", err)
+			}
+			if affected, _ := res.RowsAffected(); affected != 1 {
+				return model.RentalOrder{}, model.ErrCartChanged
+			}
+		}
+
+		err = tx.QueryRow(InsertRentalSQL, rental.OrderId, rental.UserId, rental.MovieId, rental.MovieName,
+			rental.ReleaseYear, rental.Status, rental.RentedAt, rental.DueAt, rental.CopyId).Scan(&rental.Id)
+		if err != nil {
+			return model.RentalOrder{}, fmt.Errorf("failed to insert rental: %w", err)
+		}
+	}
+
+	if order.PaymentId != nil {
+		res, err := tx.Exec(LinkPaymentSQL, order.Id, *order.PaymentId)
+		if err != nil {
+			return model.RentalOrder{}, fmt.Errorf("failed to link payment: %w", err)
+		}
+		if affected, _ := res.RowsAffected(); affected != 1 {
+			return model.RentalOrder{}, model.ErrCartChanged
+		}
+	}
+
+	if _, err = tx.Exec(DeleteCartPromotionSQL, order.UserId); err != nil {
+		return model.RentalOrder{}, fmt.Errorf("failed to clear cart promotion: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return model.RentalOrder{}, fmt.Errorf("failed to commit checkout: %w", err)
+	}
+	fmt.Println("Successfully created rental order. Id:", order.Id)
+	return order, nil
+}
+
+func (m rentalRepo) GetOrder(orderId int) (model.RentalOrder, error) {
+	var o model.RentalOrder
+	err := m.db.QueryRow(SelectRentalOrderSQL, orderId).Scan(&o.Id, &o.UserId, &o.CreatedAt, &o.PromotionId,
+		&o.PromotionCode, &o.SubtotalCents, &o.DiscountCents, &o.TaxCents, &o.TotalCents)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.RentalOrder{}, model.ErrOrderNotFound
+	}
+	if err != nil {
+		return model.RentalOrder{}, fmt.Errorf("failed to fetch rental order: %w", err)
+	}
+
+	o.Rentals, err = m.queryRentals(SelectRentalsByOrderSQL, orderId)
+	if err != nil {
+		return model.RentalOrder{}, err
+	}
+	return o, nil
+}
+
+func (m rentalRepo) GetRental(rentalId int) (model.Rental, error) {
+	rental, err := scanRental(m.db.QueryRow(SelectRentalByIdSQL, rentalId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Rental{}, model.ErrRentalNotFound
+	}
+	if err != nil {
+		return model.Rental{}, fmt.Errorf("failed to fetch rental: %w", err)
+	}
+	return rental, nil
+}
+
+func (m rentalRepo) GetRentals(userId int) ([]model.Rental, error) {
+	rentals, err := m.queryRentals(SelectRentalsByUserSQL, userId)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Successfully fetched rentals", len(rentals))
+	return rentals, nil
+}
+
+func (m rentalRepo) GetOverdueRentals() ([]model.Rental, error) {
+	return m.queryRentals(SelectOverdueRentalsSQL)
+}
+
+func (m rentalRepo) queryRentals(query string, args ...any) ([]model.Rental, error) {
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rentals: %w", err)
+	}
+	defer rows.Close()
+
+	var rentals []model.Rental
+	for rows.Next() {
+		rental, err := scanRental(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rental: %w", err)
+		}
+		rentals = append(rentals, rental)
+	}
+	return rentals, rows.Err()
+}
+
+// MarkOverdue flags every open rental past its due date and reports how many
+// were flagged.
+func (m rentalRepo) MarkOverdue(now time.Time) (int64, error) {
+	res, err := m.db.Exec(UpdateRentalsOverdueSQL, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark rentals overdue: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// MarkReturned closes the rental and puts its copy back on the shelf in one
+// transaction.
+func (m rentalRepo) MarkReturned(rentalId int, returnedAt time.Time) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin return: %w", err)
+	}
+	defer tx.Rollback()
+
+	var copyId *int
+	err = tx.QueryRow(UpdateRentalReturnSQL, model.StatusReturned, returnedAt, rentalId).Scan(&copyId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrRentalNotActive
+	}
+	if err != nil {
+		return fmt.Errorf("failed to return rental: %w", err)
+	}
+
+	if copyId != nil {
+		if _, err = tx.Exec(UpdateCopyReturnedSQL, *copyId); err != nil {
+			return fmt.Errorf("failed to return copy: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit return: %w", err)
+	}
+	return nil
+}
+
+// MarkLost closes the rental as lost and writes its copy off in one
+// transaction.
+func (m rentalRepo) MarkLost(rentalId int) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin lost report: %w", err)
+	}
+	defer tx.Rollback()
+
+	var copyId *int
+	err = tx.QueryRow(UpdateRentalLostSQL, model.StatusLost, rentalId).Scan(&copyId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrRentalNotActive
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mark rental lost: %w", err)
+	}
+
+	if copyId != nil {
+		if _, err = tx.Exec(UpdateCopyLostSQL, *copyId); err != nil {
+			return fmt.Errorf("failed to write off copy: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit lost report: %w", err)
+	}
+	return nil
+}
+
+func scanRental(row postgres.Scanner) (model.Rental, error) {
+	var r model.Rental
+	err := row.Scan(&r.Id, &r.OrderId, &r.UserId, &r.MovieId, &r.MovieName, &r.ReleaseYear, &r.Status,
+		&r.RentedAt, &r.DueAt, &r.ReturnedAt, &r.CopyId)
+	return r, err
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	paymentModel "movie-rent/pkg/payments/model"
+	promotionModel "movie-rent/pkg/promotion/model"
+	"movie-rent/pkg/rental/model"
+	"testing"
+	"time"
+)
+
+type RentalRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository RentalRepository
+}
+
+func TestRentalRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RentalRepositoryTestSuite))
+}
+
+func (suite *RentalRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewRentalRepository(suite.mockedDB)
+}
+
+func (suite *RentalRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *RentalRepositoryTestSuite) order(now time.Time) model.RentalOrder {
+	return model.RentalOrder{
+		UserId:    1001,
+		CreatedAt: now,
+		Rentals: []model.Rental{{
+			CartItemId: 7, UserId: 1001, MovieId: 4563, MovieName: "Hero", ReleaseYear: 1990,
+			Status: model.StatusActive, RentedAt: now, DueAt: now.AddDate(0, 0, 3),
+		}},
+	}
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldCommitOrderAndEmptyCart() {
+	now := time.Now()
+	order := suite.order(now)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(InsertRentalOrderSQL).WithArgs(1001, now, nil, 0, 0, 0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	suite.mockDB.ExpectQuery(DeleteCartItemSQL).WithArgs(7, 1001).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id", "withdrawn"}).AddRow(30, false))
+	suite.mockDB.ExpectExec(UpdateCopyRentedSQL).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(InsertRentalSQL).WithArgs(10, 1001, 4563, "Hero", 1990, model.StatusActive, now, now.AddDate(0, 0, 3), 30).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+	suite.mockDB.ExpectExec(DeleteCartPromotionSQL).WithArgs(1001).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectCommit()
+
+	created, err := suite.testRepository.CreateOrder(order)
+
+	suite.Nil(err)
+	suite.Equal(10, created.Id)
+	suite.Equal(20, created.Rentals[0].Id)
+	suite.Equal(10, created.Rentals[0].OrderId)
+	suite.Equal(30, *created.Rentals[0].CopyId)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldRollbackWhenPromotionUsedUp() {
+	now := time.Now()
+	order := suite.order(now)
+	promotionId := 3
+	order.PromotionId = &promotionId
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockPromotionSQL).WithArgs(3, 1001).WillReturnRows(sqlmock.NewRows([]string{"uses_left"}).AddRow(false))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.CreateOrder(order)
+
+	suite.ErrorIs(err, promotionModel.ErrPromotionUsedUp)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldRollbackWhenCartItemAlreadyRemoved() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(InsertRentalOrderSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	suite.mockDB.ExpectQuery(DeleteCartItemSQL).WithArgs(7, 1001).WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.CreateOrder(suite.order(now))
+
+	suite.ErrorIs(err, model.ErrCartChanged)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldRollbackWhenMovieWithdrawn() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(InsertRentalOrderSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	suite.mockDB.ExpectQuery(DeleteCartItemSQL).WithArgs(7, 1001).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id", "withdrawn"}).AddRow(30, true))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.CreateOrder(suite.order(now))
+
+	suite.ErrorIs(err, model.ErrMovieWithdrawn)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldLinkPaymentToOrder() {
+	now := time.Now()
+	order := suite.order(now)
+	paymentId := 5
+	order.PaymentId = &paymentId
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockOrderPaymentSQL).WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"order_id", "status"}).AddRow(nil, paymentModel.StatusAuthorized))
+	suite.mockDB.ExpectQuery(InsertRentalOrderSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	suite.mockDB.ExpectQuery(DeleteCartItemSQL).WithArgs(7, 1001).WillReturnRows(sqlmock.NewRows([]string{"copy_id", "withdrawn"}).AddRow(nil, false))
+	suite.mockDB.ExpectQuery(InsertRentalSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+	suite.mockDB.ExpectExec(LinkPaymentSQL).WithArgs(10, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(DeleteCartPromotionSQL).WithArgs(1001).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectCommit()
+
+	created, err := suite.testRepository.CreateOrder(order)
+
+	suite.Nil(err)
+	suite.Equal(5, *created.PaymentId)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldRollbackWhenPaymentAlreadyPaidForOrder() {
+	order := suite.order(time.Now())
+	paymentId := 5
+	order.PaymentId = &paymentId
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockOrderPaymentSQL).WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"order_id", "status"}).AddRow(10, paymentModel.StatusAuthorized))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.CreateOrder(order)
+
+	suite.ErrorIs(err, paymentModel.ErrPaymentLinked)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_GetOrder_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectRentalOrderSQL).WithArgs(10).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetOrder(10)
+
+	suite.ErrorIs(err, model.ErrOrderNotFound)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_GetRental_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectRentalByIdSQL).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetRental(1)
+
+	suite.ErrorIs(err, model.ErrRentalNotFound)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_CreateOrder_ShouldRollbackWhenCopyNoLongerReserved() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(InsertRentalOrderSQL).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	suite.mockDB.ExpectQuery(DeleteCartItemSQL).WithArgs(7, 1001).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id", "withdrawn"}).AddRow(30, false))
+	suite.mockDB.ExpectExec(UpdateCopyRentedSQL).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.CreateOrder(suite.order(now))
+
+	suite.ErrorIs(err, model.ErrCartChanged)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_MarkReturned_ShouldReturnErrorWhenRentalNotActive() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(UpdateRentalReturnSQL).WithArgs(model.StatusReturned, now, 1).
+		WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	err := suite.testRepository.MarkReturned(1, now)
+
+	suite.ErrorIs(err, model.ErrRentalNotActive)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_MarkReturned_ShouldPutCopyBackOnShelf() {
+	now := time.Now()
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(UpdateRentalReturnSQL).WithArgs(model.StatusReturned, now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id"}).AddRow(30))
+	suite.mockDB.ExpectExec(UpdateCopyReturnedSQL).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.MarkReturned(1, now)
+
+	suite.Nil(err)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_MarkLost_ShouldWriteOffCopy() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(UpdateRentalLostSQL).WithArgs(model.StatusLost, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id"}).AddRow(30))
+	suite.mockDB.ExpectExec(UpdateCopyLostSQL).WithArgs(30).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.MarkLost(1)
+
+	suite.Nil(err)
+}
+
+func (suite *RentalRepositoryTestSuite) Test_MarkOverdue_ShouldReportFlaggedRentals() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(UpdateRentalsOverdueSQL).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	count, err := suite.testRepository.MarkOverdue(now)
+
+	suite.Nil(err)
+	suite.Equal(int64(2), count)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	fineModel "movie-rent/pkg/fine/model"
+	fineService "movie-rent/pkg/fine/service"
+	holdService "movie-rent/pkg/hold/service"
+	"movie-rent/pkg/rental/model"
+	"movie-rent/pkg/rental/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/rental/service/rental_service.go -destination=pkg/rental/mocks/rental_service_mock.go -package=mocks
+
+type RentalService interface {
+	ReturnRental(rentalId int, userId int) (model.Rental, error)
+	GetRentals(userId int) (model.RentalHistory, error)
+	ReportLost(rentalId int, userId int) (model.Rental, error)
+}
+
+type rentalService struct {
+	repository  repository.RentalRepository
+	holdService holdService.HoldService
+	fineService fineService.FineService
+}
+
+func NewRentalService(repository repository.RentalRepository, holdService holdService.HoldService,
+	fineService fineService.FineService) RentalService {
+	return rentalService{repository: repository, holdService: holdService, fineService: fineService}
+}
+
+func (m rentalService) ReturnRental(rentalId int, userId int) (model.Rental, error) {
+	rental, err := m.repository.GetRental(rentalId)
+	if err != nil {
+		fmt.Println("failed to find rental:", err.Error())
+		return model.Rental{}, err
+	}
+	if rental.UserId != userId {
+		return model.Rental{}, model.ErrRentalNotFound
+	}
+	if !rental.IsOpen() {
+		return model.Rental{}, model.ErrRentalNotActive
+	}
+
+	// Late fees are booked before the return so a failure leaves the rental
+	// open; accrual only books the difference, so a retry never double charges.
+	returnedAt := time.Now()
+	if err = m.fineService.AccrueLateFee(rental, returnedAt); err != nil {
+		fmt.Println("failed to charge late fee:", err.Error())
+		return model.Rental{}, err
+	}
+	if err = m.repository.MarkReturned(rentalId, returnedAt); err != nil {
+		fmt.Println("failed to return rental:", err.Error())
+		return model.Rental{}, err
+	}
+
+	// The return is already committed; a failed allocation is picked up by the
+	// next hold expiry sweep instead of failing the request.
+	if err = m.holdService.AllocateNext(rental.MovieId); err != nil {
+		fmt.Println("failed to allocate returned copy:", err.Error())
+	}
+
+	rental.Status = model.StatusReturned
+	rental.ReturnedAt = &returnedAt
+	return rental, nil
+}
+
+func (m rentalService) GetRentals(userId int) (model.RentalHistory, error) {
+	rentals, err := m.repository.GetRentals(userId)
+	if err != nil {
+		fmt.Println("failed to find rentals:", err.Error())
+		return model.RentalHistory{}, err
+	}
+
+	history := model.RentalHistory{Active: []model.Rental{}, Past: []model.Rental{}}
+	for _, rental := range rentals {
+		if rental.IsOpen() {
+			history.Active = append(history.Active, rental)
+		} else {
+			history.Past = append(history.Past, rental)
+		}
+	}
+	return history, nil
+}
+
+func (m rentalService) ReportLost(rentalId int, userId int) (model.Rental, error) {
+	rental, err := m.repository.GetRental(rentalId)
+	if err != nil {
+		fmt.Println("failed to find rental:", err.Error())
+		return model.Rental{}, err
+	}
+	if rental.UserId != userId {
+		return model.Rental{}, model.ErrRentalNotFound
+	}
+	if !rental.IsOpen() {
+		return model.Rental{}, model.ErrRentalNotActive
+	}
+
+	if err = m.fineService.ChargeLostItem(rental); err != nil && !errors.Is(err, fineModel.ErrAlreadyCharged) {
+		fmt.Println("failed to charge lost rental:", err.Error())
+		return model.Rental{}, err
+	}
+	if err = m.repository.MarkLost(rentalId); err != nil {
+		fmt.Println("failed to mark rental lost:", err.Error())
+		return model.Rental{}, err
+	}
+
+	rental.Status = model.StatusLost
+	return rental, nil
+}
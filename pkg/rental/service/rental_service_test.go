@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	fineMocks "movie-rent/pkg/fine/mocks"
+	fineModel "movie-rent/pkg/fine/model"
+	holdMocks "movie-rent/pkg/hold/mocks"
+	"movie-rent/pkg/rental/mocks"
+	"movie-rent/pkg/rental/model"
+	"testing"
+	"time"
+)
+
+type RentalServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockRentalRepository
+	mockHold       *holdMocks.MockHoldService
+	mockFine       *fineMocks.MockFineService
+
+	rentalService RentalService
+}
+
+func TestRentalServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RentalServiceTestSuite))
+}
+
+func (suite *RentalServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockRentalRepository(suite.mockController)
+	suite.mockHold = holdMocks.NewMockHoldService(suite.mockController)
+	suite.mockFine = fineMocks.NewMockFineService(suite.mockController)
+
+	suite.rentalService = NewRentalService(suite.mockRepository, suite.mockHold, suite.mockFine)
+}
+
+func (suite *RentalServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldReturnErrorWhenRentalNotFound() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{}, model.ErrRentalNotFound).Times(1)
+
+	_, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.ErrorIs(err, model.ErrRentalNotFound)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldReturnErrorWhenRentalBelongsToAnotherUser() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 2002, Status: model.StatusActive}, nil).Times(1)
+
+	_, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.ErrorIs(err, model.ErrRentalNotFound)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldReturnErrorWhenAlreadyReturned() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, Status: model.StatusReturned}, nil).Times(1)
+
+	_, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.ErrorIs(err, model.ErrRentalNotActive)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldReturnErrorWhenMarkReturnedFailed() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, Status: model.StatusActive}, nil).Times(1)
+	suite.mockFine.EXPECT().AccrueLateFee(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().MarkReturned(1, gomock.Any()).Return(fmt.Errorf("error")).Times(1)
+
+	_, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.NotNil(err)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldMarkRentalReturned() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, MovieId: 4563, Status: model.StatusActive}, nil).Times(1)
+	suite.mockFine.EXPECT().AccrueLateFee(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().MarkReturned(1, gomock.Any()).Return(nil).Times(1)
+	suite.mockHold.EXPECT().AllocateNext(4563).Return(nil).Times(1)
+
+	rental, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.Nil(err)
+	suite.Equal(model.StatusReturned, rental.Status)
+	suite.NotNil(rental.ReturnedAt)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldSucceedWhenHoldAllocationFailed() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, MovieId: 4563, Status: model.StatusActive}, nil).Times(1)
+	suite.mockFine.EXPECT().AccrueLateFee(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().MarkReturned(1, gomock.Any()).Return(nil).Times(1)
+	suite.mockHold.EXPECT().AllocateNext(4563).Return(fmt.Errorf("error")).Times(1)
+
+	rental, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.Nil(err)
+	suite.Equal(model.StatusReturned, rental.Status)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReturnRental_ShouldKeepRentalOpenWhenLateFeeFailed() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, Status: model.StatusOverdue}, nil).Times(1)
+	suite.mockFine.EXPECT().AccrueLateFee(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error")).Times(1)
+
+	_, err := suite.rentalService.ReturnRental(1, 1001)
+
+	suite.NotNil(err)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReportLost_ShouldReturnErrorWhenAlreadyClosed() {
+	suite.mockRepository.EXPECT().GetRental(1).Return(model.Rental{Id: 1, UserId: 1001, Status: model.StatusLost}, nil).Times(1)
+
+	_, err := suite.rentalService.ReportLost(1, 1001)
+
+	suite.ErrorIs(err, model.ErrRentalNotActive)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReportLost_ShouldChargeReplacementAndWriteOffCopy() {
+	rental := model.Rental{Id: 1, UserId: 1001, MovieId: 4563, Status: model.StatusOverdue}
+	suite.mockRepository.EXPECT().GetRental(1).Return(rental, nil).Times(1)
+	suite.mockFine.EXPECT().ChargeLostItem(rental).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().MarkLost(1).Return(nil).Times(1)
+
+	lost, err := suite.rentalService.ReportLost(1, 1001)
+
+	suite.Nil(err)
+	suite.Equal(model.StatusLost, lost.Status)
+}
+
+func (suite *RentalServiceTestSuite) Test_ReportLost_ShouldRetryWhenAlreadyCharged() {
+	rental := model.Rental{Id: 1, UserId: 1001, Status: model.StatusActive}
+	suite.mockRepository.EXPECT().GetRental(1).Return(rental, nil).Times(1)
+	suite.mockFine.EXPECT().ChargeLostItem(rental).Return(fineModel.ErrAlreadyCharged).Times(1)
+	suite.mockRepository.EXPECT().MarkLost(1).Return(nil).Times(1)
+
+	_, err := suite.rentalService.ReportLost(1, 1001)
+
+	suite.Nil(err)
+}
+
+func (suite *RentalServiceTestSuite) Test_GetRentals_ShouldReturnErrorWhenRepositoryFailed() {
+	suite.mockRepository.EXPECT().GetRentals(1001).Return(nil, fmt.Errorf("error")).Times(1)
+
+	_, err := suite.rentalService.GetRentals(1001)
+
+	suite.NotNil(err)
+}
+
+func (suite *RentalServiceTestSuite) Test_GetRentals_ShouldSplitActiveAndPastRentals() {
+	returnedAt := time.Now()
+	rentals := []model.Rental{
+		{Id: 2, UserId: 1001, Status: model.StatusActive},
+		{Id: 1, UserId: 1001, Status: model.StatusReturned, ReturnedAt: &returnedAt},
+	}
+	suite.mockRepository.EXPECT().GetRentals(1001).Return(rentals, nil).Times(1)
+
+	history, err := suite.rentalService.GetRentals(1001)
+
+	suite.Nil(err)
+	suite.Equal([]model.Rental{rentals[0]}, history.Active)
+	suite.Equal([]model.Rental{rentals[1]}, history.Past)
+}
@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/auth/middleware"
+	authModel "movie-rent/pkg/auth/model"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/review/model"
+	"movie-rent/pkg/review/service"
+	"net/http"
+	"strconv"
+)
+
+type ReviewController struct {
+	service service.ReviewService
+}
+
+func NewReviewController(service service.ReviewService) ReviewController {
+	return ReviewController{service: service}
+}
+
+func (m *ReviewController) AddReview(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.ReviewRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	review, err := m.service.AddReview(movieId, userId, request)
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrReviewNotAllowed) {
+		ctx.JSON(http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateReview) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, review)
+}
+
+func (m *ReviewController) GetReviews(ctx *gin.Context) {
+	movieId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	limit := 0
+	if text := ctx.Query("limit"); text != "" {
+		if limit, err = strconv.Atoi(text); err != nil {
+			ctx.JSON(http.StatusBadRequest, "limit must be a number")
+			return
+		}
+	}
+
+	page, err := m.service.GetReviews(movieId, ctx.Query("sort"), limit, ctx.Query("cursor"))
+	if errors.Is(err, model.ErrInvalidQuery) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (m *ReviewController) VoteReview(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	reviewId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.VoteRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	review, err := m.service.VoteReview(reviewId, userId, *request.Helpful)
+	if errors.Is(err, model.ErrReviewNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrOwnReview) {
+		ctx.JSON(http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, review)
+}
+
+func (m *ReviewController) ReportReview(ctx *gin.Context) {
+	userId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	reviewId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.ReportRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	err = m.service.ReportReview(reviewId, userId, request)
+	if errors.Is(err, model.ErrInvalidReport) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrReviewNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateReport) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (m *ReviewController) GetModerationQueue(ctx *gin.Context) {
+	limit := 0
+	if text := ctx.Query("limit"); text != "" {
+		var err error
+		if limit, err = strconv.Atoi(text); err != nil {
+			ctx.JSON(http.StatusBadRequest, "limit must be a number")
+			return
+		}
+	}
+
+	reviews, err := m.service.GetModerationQueue(limit)
+	if errors.Is(err, model.ErrInvalidQuery) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, reviews)
+}
+
+func (m *ReviewController) ModerateReview(ctx *gin.Context) {
+	moderatorId, ok := middleware.UserId(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, authModel.ErrUnauthenticated.Error())
+		return
+	}
+	reviewId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+	var request model.ModerationRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	review, err := m.service.ModerateReview(reviewId, moderatorId, request)
+	if errors.Is(err, model.ErrInvalidModeration) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrReviewNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, review)
+}
+
+func (m *ReviewController) GetModerations(ctx *gin.Context) {
+	reviewId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	moderations, err := m.service.GetModerations(reviewId)
+	if errors.Is(err, model.ErrReviewNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, moderations)
+}
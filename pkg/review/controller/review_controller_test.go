@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/auth/middleware"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/review/mocks"
+	"movie-rent/pkg/review/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ReviewControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockReviewService *mocks.MockReviewService
+	testController    ReviewController
+}
+
+func TestReviewControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewControllerTestSuite))
+}
+
+func (suite *ReviewControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockReviewService = mocks.NewMockReviewService(suite.mockController)
+	suite.testController = NewReviewController(suite.mockReviewService)
+	suite.context.Set(middleware.UserIdKey, 1001)
+}
+
+func (suite *ReviewControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *ReviewControllerTestSuite) Test_AddReview_ShouldReturnCreatedReview() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/7/reviews", strings.NewReader(`{"rating":4,"body":"Great"}`))
+	suite.mockReviewService.EXPECT().AddReview(7, 1001, model.ReviewRequest{Rating: 4, Body: "Great"}).
+		Return(model.Review{Id: 12, MovieId: 7, UserId: 1001, Rating: 4, Body: "Great", Status: model.StatusApproved, CreatedAt: createdAt}, nil).
+		Times(1)
+
+	suite.testController.AddReview(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.JSONEq(`{"id":12,"movieId":7,"userId":1001,"rating":4,"body":"Great","status":"approved","helpfulCount":0,"unhelpfulCount":0,`+
+		`"createdAt":"2024-05-01T12:00:00Z"}`,
+		suite.recorder.Body.String())
+}
+
+func (suite *ReviewControllerTestSuite) Test_AddReview_ShouldRejectRatingOutOfRange() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/7/reviews", strings.NewReader(`{"rating":6}`))
+
+	suite.testController.AddReview(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_AddReview_ShouldForbidUserWithoutReturnedRental() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/7/reviews", strings.NewReader(`{"rating":4}`))
+	suite.mockReviewService.EXPECT().AddReview(7, 1001, model.ReviewRequest{Rating: 4}).
+		Return(model.Review{}, model.ErrReviewNotAllowed).Times(1)
+
+	suite.testController.AddReview(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_AddReview_ShouldReturnConflictForSecondReview() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/movie/7/reviews", strings.NewReader(`{"rating":4}`))
+	suite.mockReviewService.EXPECT().AddReview(7, 1001, model.ReviewRequest{Rating: 4}).
+		Return(model.Review{}, model.ErrDuplicateReview).Times(1)
+
+	suite.testController.AddReview(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_GetReviews_ShouldPassSortAndCursor() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/7/reviews?sort=helpful&limit=5&cursor=abc", nil)
+	suite.mockReviewService.EXPECT().GetReviews(7, model.SortHelpful, 5, "abc").
+		Return(model.ReviewPage{Items: []model.Review{}, Limit: 5, Sort: model.SortHelpful}, nil).Times(1)
+
+	suite.testController.GetReviews(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"items":[],"total":0,"limit":5,"sort":"helpful"}`, suite.recorder.Body.String())
+}
+
+func (suite *ReviewControllerTestSuite) Test_GetReviews_ShouldReturnBadRequestForInvalidQuery() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/7/reviews?sort=oldest", nil)
+	suite.mockReviewService.EXPECT().GetReviews(7, "oldest", 0, "").Return(model.ReviewPage{}, model.ErrInvalidQuery).Times(1)
+
+	suite.testController.GetReviews(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_GetReviews_ShouldReturnNotFoundForUnknownMovie() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/movie/7/reviews", nil)
+	suite.mockReviewService.EXPECT().GetReviews(7, "", 0, "").Return(model.ReviewPage{}, movieModel.ErrMovieNotFound).Times(1)
+
+	suite.testController.GetReviews(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_VoteReview_ShouldRequireHelpfulFlag() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/votes", strings.NewReader(`{}`))
+
+	suite.testController.VoteReview(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_VoteReview_ShouldAcceptUnhelpfulVote() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/votes", strings.NewReader(`{"helpful":false}`))
+	suite.mockReviewService.EXPECT().VoteReview(12, 1001, false).Return(model.Review{Id: 12, UnhelpfulCount: 1}, nil).Times(1)
+
+	suite.testController.VoteReview(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_VoteReview_ShouldForbidVotingOnOwnReview() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/votes", strings.NewReader(`{"helpful":true}`))
+	suite.mockReviewService.EXPECT().VoteReview(12, 1001, true).Return(model.Review{}, model.ErrOwnReview).Times(1)
+
+	suite.testController.VoteReview(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_ReportReview_ShouldReturnNoContent() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/reports", strings.NewReader(`{"reasonCode":"spam"}`))
+	suite.mockReviewService.EXPECT().ReportReview(12, 1001, model.ReportRequest{ReasonCode: model.ReasonSpam}).Return(nil).Times(1)
+
+	suite.testController.ReportReview(suite.context)
+
+	suite.Equal(http.StatusNoContent, suite.context.Writer.Status())
+}
+
+func (suite *ReviewControllerTestSuite) Test_ReportReview_ShouldReturnConflictForSecondReport() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/reports", strings.NewReader(`{"reasonCode":"spam"}`))
+	suite.mockReviewService.EXPECT().ReportReview(12, 1001, model.ReportRequest{ReasonCode: model.ReasonSpam}).
+		Return(model.ErrDuplicateReport).Times(1)
+
+	suite.testController.ReportReview(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_ModerateReview_ShouldPassModerator() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/moderation",
+		strings.NewReader(`{"status":"rejected","reasonCode":"offensive","note":"slur"}`))
+	request := model.ModerationRequest{Status: model.StatusRejected, ReasonCode: model.ReasonOffensive, Note: "slur"}
+	suite.mockReviewService.EXPECT().ModerateReview(12, 1001, request).
+		Return(model.Review{Id: 12, Status: model.StatusRejected, ReasonCode: model.ReasonOffensive}, nil).Times(1)
+
+	suite.testController.ModerateReview(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_ModerateReview_ShouldRejectUnknownStatus() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/reviews/12/moderation", strings.NewReader(`{"status":"pending"}`))
+
+	suite.testController.ModerateReview(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ReviewControllerTestSuite) Test_GetModerations_ShouldReturnAuditTrail() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	moderatorId := 50
+	suite.context.Params = gin.Params{{Key: "id", Value: "12"}}
+	suite.mockReviewService.EXPECT().GetModerations(12).Return([]model.Moderation{
+		{Id: 1, ReviewId: 12, Status: model.StatusPending, ReasonCode: model.ReasonBannedWords, Note: "matched: darn", CreatedAt: createdAt},
+		{Id: 2, ReviewId: 12, ModeratorId: &moderatorId, Status: model.StatusApproved, CreatedAt: createdAt},
+	}, nil).Times(1)
+
+	suite.testController.GetModerations(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`[{"id":1,"reviewId":12,"moderatorId":null,"status":"pending","reasonCode":"banned_words","note":"matched: darn",`+
+		`"createdAt":"2024-05-01T12:00:00Z"},{"id":2,"reviewId":12,"moderatorId":50,"status":"approved","createdAt":"2024-05-01T12:00:00Z"}]`,
+		suite.recorder.Body.String())
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/review/service/review_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/review/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewService is a mock of ReviewService interface.
+type MockReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewServiceMockRecorder
+}
+
+// MockReviewServiceMockRecorder is the mock recorder for MockReviewService.
+type MockReviewServiceMockRecorder struct {
+	mock *MockReviewService
+}
+
+// NewMockReviewService creates a new mock instance.
+func NewMockReviewService(ctrl *gomock.Controller) *MockReviewService {
+	mock := &MockReviewService{ctrl: ctrl}
+	mock.recorder = &MockReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// AddReview mocks base method.
+func (m *MockReviewService) AddReview(movieId, userId int, request model.ReviewRequest) (model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReview", movieId, userId, request)
+	ret0, _ := ret[0].(model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReview indicates an expected call of AddReview.
+func (mr *MockReviewServiceMockRecorder) AddReview(movieId, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReview", reflect.TypeOf((*MockReviewService)(nil).AddReview), movieId, userId, request)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockReviewService) GetModerationQueue(limit int) ([]model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", limit)
+	ret0, _ := ret[0].([]model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockReviewServiceMockRecorder) GetModerationQueue(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockReviewService)(nil).GetModerationQueue), limit)
+}
+
+// GetModerations mocks base method.
+func (m *MockReviewService) GetModerations(reviewId int) ([]model.Moderation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerations", reviewId)
+	ret0, _ := ret[0].([]model.Moderation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerations indicates an expected call of GetModerations.
+func (mr *MockReviewServiceMockRecorder) GetModerations(reviewId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerations", reflect.TypeOf((*MockReviewService)(nil).GetModerations), reviewId)
+}
+
+// GetReviews mocks base method.
+func (m *MockReviewService) GetReviews(movieId int, sort string, limit int, cursor string) (model.ReviewPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", movieId, sort, limit, cursor)
+	ret0, _ := ret[0].(model.ReviewPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockReviewServiceMockRecorder) GetReviews(movieId, sort, limit, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockReviewService)(nil).GetReviews), movieId, sort, limit, cursor)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewService) ModerateReview(reviewId, moderatorId int, request model.ModerationRequest) (model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", reviewId, moderatorId, request)
+	ret0, _ := ret[0].(model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewServiceMockRecorder) ModerateReview(reviewId, moderatorId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewService)(nil).ModerateReview), reviewId, moderatorId, request)
+}
+
+// ReportReview mocks base method.
+func (m *MockReviewService) ReportReview(reviewId, userId int, request model.ReportRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportReview", reviewId, userId, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportReview indicates an expected call of ReportReview.
+func (mr *MockReviewServiceMockRecorder) ReportReview(reviewId, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportReview", reflect.TypeOf((*MockReviewService)(nil).ReportReview), reviewId, userId, request)
+}
+
+// VoteReview mocks base method.
+func (m *MockReviewService) VoteReview(reviewId, userId int, helpful bool) (model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteReview", reviewId, userId, helpful)
+	ret0, _ := ret[0].(model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteReview indicates an expected call of VoteReview.
+func (mr *MockReviewServiceMockRecorder) VoteReview(reviewId, userId, helpful interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteReview", reflect.TypeOf((*MockReviewService)(nil).VoteReview), reviewId, userId, helpful)
+}
@@ -0,0 +1,14 @@
+package model
+
+import "errors"
+
+var (
+	ErrReviewNotFound    = errors.New("review not found")
+	ErrDuplicateReview   = errors.New("user has already reviewed this movie")
+	ErrReviewNotAllowed  = errors.New("only customers who have returned a rental of this movie can review it")
+	ErrDuplicateReport   = errors.New("user has already reported this review")
+	ErrOwnReview         = errors.New("users cannot vote on their own review")
+	ErrInvalidReport     = errors.New("invalid report")
+	ErrInvalidModeration = errors.New("invalid moderation")
+	ErrInvalidQuery      = errors.New("invalid query")
+)
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// Reason codes explain why a review was held, reported or rejected. The
+// system sets banned_words and reported itself; customers and moderators
+// choose from the rest.
+const (
+	ReasonBannedWords = "banned_words"
+	ReasonReported    = "reported"
+	ReasonSpam        = "spam"
+	ReasonOffensive   = "offensive"
+	ReasonSpoiler     = "spoiler"
+	ReasonOffTopic    = "off_topic"
+	ReasonOther       = "other"
+)
+
+var reportReasons = []string{ReasonSpam, ReasonOffensive, ReasonSpoiler, ReasonOffTopic, ReasonOther}
+
+// IsReportReason reports whether customers may give the code when reporting
+// a review.
+func IsReportReason(code string) bool {
+	return contains(reportReasons, code)
+}
+
+// IsRejectReason reports whether moderators may give the code when
+// rejecting a review.
+func IsRejectReason(code string) bool {
+	return code == ReasonBannedWords || contains(reportReasons, code)
+}
+
+// Moderation is one entry in a review's audit trail. ModeratorId is nil when
+// the system changed the status itself.
+type Moderation struct {
+	Id          int       `json:"id"`
+	ReviewId    int       `json:"reviewId"`
+	ModeratorId *int      `json:"moderatorId"`
+	Status      string    `json:"status"`
+	ReasonCode  string    `json:"reasonCode,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ModerationRequest struct {
+	Status     string `json:"status" binding:"required,oneof=approved rejected"`
+	ReasonCode string `json:"reasonCode"`
+	Note       string `json:"note" binding:"max=500"`
+}
+
+type Report struct {
+	ReviewId   int
+	UserId     int
+	ReasonCode string
+	Note       string
+	CreatedAt  time.Time
+}
+
+type ReportRequest struct {
+	ReasonCode string `json:"reasonCode" binding:"required"`
+	Note       string `json:"note" binding:"max=500"`
+}
+
+type Vote struct {
+	ReviewId  int
+	UserId    int
+	Helpful   bool
+	CreatedAt time.Time
+}
+
+type VoteRequest struct {
+	Helpful *bool `json:"helpful" binding:"required"`
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ModerationTestSuite struct {
+	suite.Suite
+}
+
+func TestModerationTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationTestSuite))
+}
+
+func (suite *ModerationTestSuite) Test_IsRejectReason_ShouldAcceptBannedWordsButNotReported() {
+	suite.True(IsRejectReason(ReasonBannedWords))
+	suite.True(IsRejectReason(ReasonSpam))
+	suite.False(IsRejectReason(ReasonReported))
+	suite.False(IsReportReason(ReasonBannedWords))
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	SortNewest  = "newest"
+	SortHelpful = "helpful"
+
+	DefaultLimit = 10
+	MaxLimit     = 50
+)
+
+// ReviewQuery is one page of a movie's reviews, newest first or most
+// helpful first. Ties fall back to newest, then id, so the order is total.
+type ReviewQuery struct {
+	MovieId int
+	Sort    string
+	Limit   int
+	Cursor  *Cursor
+}
+
+// Cursor holds the sort values of the last review on the previous page.
+type Cursor struct {
+	Sort         string    `json:"s"`
+	HelpfulCount int       `json:"h"`
+	CreatedAt    time.Time `json:"t"`
+	Id           int       `json:"i"`
+}
+
+// NewReviewQuery validates the listing parameters. An empty sort means
+// newest and a limit of 0 the default. Every error wraps ErrInvalidQuery.
+func NewReviewQuery(movieId int, sort string, limit int, cursorText string) (ReviewQuery, error) {
+	if sort == "" {
+		sort = SortNewest
+	}
+	if sort != SortNewest && sort != SortHelpful {
+		return ReviewQuery{}, fmt.Errorf("%w: sort must be %s or %s", ErrInvalidQuery, SortNewest, SortHelpful)
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		return ReviewQuery{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxLimit)
+	}
+	query := ReviewQuery{MovieId: movieId, Sort: sort, Limit: limit}
+	if cursorText != "" {
+		cursor, err := DecodeCursor(cursorText, sort)
+		if err != nil {
+			return ReviewQuery{}, err
+		}
+		query.Cursor = &cursor
+	}
+	return query, nil
+}
+
+func CursorAfter(review Review, sort string) Cursor {
+	return Cursor{Sort: sort, HelpfulCount: review.HelpfulCount, CreatedAt: review.CreatedAt, Id: review.Id}
+}
+
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor rejects cursors that were issued for a different order.
+func DecodeCursor(token string, sort string) (Cursor, error) {
+	var cursor Cursor
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(payload, &cursor)
+	}
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: cursor is invalid", ErrInvalidQuery)
+	}
+	if cursor.Sort != sort {
+		return Cursor{}, fmt.Errorf("%w: cursor does not match the sort order", ErrInvalidQuery)
+	}
+	return cursor, nil
+}
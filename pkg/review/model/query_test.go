@@ -0,0 +1,58 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+}
+
+func TestQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldDefaultToNewest() {
+	query, err := NewReviewQuery(7, "", 0, "")
+
+	suite.Nil(err)
+	suite.Equal(ReviewQuery{MovieId: 7, Sort: SortNewest, Limit: DefaultLimit}, query)
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldRejectUnknownSort() {
+	_, err := NewReviewQuery(7, "rating", 0, "")
+
+	suite.ErrorIs(err, ErrInvalidQuery)
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldRejectLimitAboveMax() {
+	_, err := NewReviewQuery(7, SortHelpful, MaxLimit+1, "")
+
+	suite.ErrorIs(err, ErrInvalidQuery)
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldDecodeCursor() {
+	review := Review{Id: 4, HelpfulCount: 3, CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	token := CursorAfter(review, SortHelpful).Encode()
+
+	query, err := NewReviewQuery(7, SortHelpful, 5, token)
+
+	suite.Nil(err)
+	suite.Equal(&Cursor{Sort: SortHelpful, HelpfulCount: 3, CreatedAt: review.CreatedAt, Id: 4}, query.Cursor)
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldRejectCursorFromOtherSort() {
+	token := CursorAfter(Review{Id: 4}, SortNewest).Encode()
+
+	_, err := NewReviewQuery(7, SortHelpful, 5, token)
+
+	suite.ErrorIs(err, ErrInvalidQuery)
+}
+
+func (suite *QueryTestSuite) Test_NewReviewQuery_ShouldRejectGarbledCursor() {
+	_, err := NewReviewQuery(7, SortNewest, 5, "not a cursor")
+
+	suite.ErrorIs(err, ErrInvalidQuery)
+}
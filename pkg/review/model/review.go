@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Review is a customer's rating of a movie. Only approved reviews are
+// listed and counted towards the movie's rating.
+type Review struct {
+	Id             int       `json:"id"`
+	MovieId        int       `json:"movieId"`
+	UserId         int       `json:"userId"`
+	Rating         int       `json:"rating"`
+	Body           string    `json:"body"`
+	Status         string    `json:"status"`
+	ReasonCode     string    `json:"reasonCode,omitempty"`
+	HelpfulCount   int       `json:"helpfulCount"`
+	UnhelpfulCount int       `json:"unhelpfulCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Body   string `json:"body" binding:"max=2000"`
+}
+
+// ReviewPage is one page of a movie's reviews. Total counts all of them.
+type ReviewPage struct {
+	Items []Review `json:"items"`
+	Total int      `json:"total"`
+	Limit int      `json:"limit"`
+	Sort  string   `json:"sort"`
+	Next  string   `json:"next,omitempty"`
+}
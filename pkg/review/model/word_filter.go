@@ -0,0 +1,40 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordFilter finds banned words in review text. Words match whole and
+// regardless of case, so a banned "ass" does not catch "class".
+type WordFilter struct {
+	words map[string]bool
+}
+
+func NewWordFilter(words []string) WordFilter {
+	filter := WordFilter{words: map[string]bool{}}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			filter.words[word] = true
+		}
+	}
+	return filter
+}
+
+// Match returns the banned words the text uses, each once, in the order
+// they first appear.
+func (f WordFilter) Match(text string) []string {
+	matches := []string{}
+	seen := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+		if f.words[word] && !seen[word] {
+			seen[word] = true
+			matches = append(matches, word)
+		}
+	}
+	return matches
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type WordFilterTestSuite struct {
+	suite.Suite
+}
+
+func TestWordFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(WordFilterTestSuite))
+}
+
+func (suite *WordFilterTestSuite) Test_Match_ShouldFindWholeWordsIgnoringCase() {
+	filter := NewWordFilter([]string{"darn", " Heck "})
+
+	suite.Equal([]string{"heck", "darn"}, filter.Match("What the HECK, darn it. Darn!"))
+}
+
+func (suite *WordFilterTestSuite) Test_Match_ShouldIgnoreWordsInsideOtherWords() {
+	filter := NewWordFilter([]string{"ass"})
+
+	suite.Empty(filter.Match("A classic, a masterpiece of class."))
+}
+
+func (suite *WordFilterTestSuite) Test_Match_ShouldMatchNothingWithoutBannedWords() {
+	filter := NewWordFilter(nil)
+
+	suite.Empty(filter.Match("anything goes"))
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/review/model"
+)
+
+const (
+	ReviewColumns   = `id, movie_id, user_id, rating, body, status, COALESCE(reason_code, ''), helpful_count, unhelpful_count, created_at`
+	LockMovieSQL    = `SELECT id FROM movies WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	InsertReviewSQL = `INSERT INTO reviews(movie_id, user_id, rating, body, status, reason_code, created_at) ` +
+		`SELECT $1, $2, $3, $4, $5, NULLIF($6, ''), $7 ` +
+		`WHERE EXISTS (SELECT 1 FROM rentals WHERE movie_id = $1 AND user_id = $2 AND status = 'returned') RETURNING id`
+	LockMovieRatingSQL   = `SELECT id FROM movies WHERE id = $1 FOR UPDATE`
+	UpdateMovieRatingSQL = `UPDATE movies SET (average_rating, rating_count) = ` +
+		`(SELECT ROUND(AVG(rating), 2), COUNT(*) FROM reviews WHERE movie_id = $1 AND status = 'approved') WHERE id = $1`
+	SelectReviewsSQL = `SELECT ` + ReviewColumns + ` FROM reviews WHERE movie_id = $1 AND status = 'approved'`
+	NewestAfterSQL   = ` AND (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT $4`
+	NewestFirstSQL   = ` ORDER BY created_at DESC, id DESC LIMIT $2`
+	HelpfulAfterSQL  = ` AND (helpful_count, created_at, id) < ($2, $3, $4) ORDER BY helpful_count DESC, created_at DESC, id DESC LIMIT $5`
+	HelpfulFirstSQL  = ` ORDER BY helpful_count DESC, created_at DESC, id DESC LIMIT $2`
+
+	SelectReviewByIdSQL      = `SELECT ` + ReviewColumns + ` FROM reviews WHERE id = $1`
+	LockReviewSQL            = `SELECT ` + ReviewColumns + ` FROM reviews WHERE id = $1 FOR UPDATE`
+	SelectModerationQueueSQL = `SELECT ` + ReviewColumns + ` FROM reviews WHERE status = 'pending' ORDER BY created_at, id LIMIT $1`
+	UpdateReviewStatusSQL    = `UPDATE reviews SET status = $1, reason_code = NULLIF($2, ''), moderated_at = $3 WHERE id = $4 ` +
+		`RETURNING ` + ReviewColumns
+	InsertModerationSQL = `INSERT INTO review_moderations(review_id, moderator_id, status, reason_code, note, created_at) ` +
+		`VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)`
+	SelectModerationsSQL = `SELECT id, review_id, moderator_id, status, COALESCE(reason_code, ''), COALESCE(note, ''), created_at ` +
+		`FROM review_moderations WHERE review_id = $1 ORDER BY created_at, id`
+	InsertReportSQL = `INSERT INTO review_reports(review_id, user_id, reason_code, note, created_at) ` +
+		`VALUES ($1, $2, $3, NULLIF($4, ''), $5)`
+	CountOpenReportsSQL = `SELECT COUNT(*) FROM review_reports r JOIN reviews v ON v.id = r.review_id ` +
+		`WHERE r.review_id = $1 AND r.created_at > COALESCE(v.moderated_at, '-infinity')`
+	UpsertVoteSQL = `INSERT INTO review_votes(review_id, user_id, helpful, created_at) VALUES ($1, $2, $3, $4) ` +
+		`ON CONFLICT (review_id, user_id) DO UPDATE SET helpful = EXCLUDED.helpful, created_at = EXCLUDED.created_at`
+	UpdateVoteCountsSQL = `UPDATE reviews SET (helpful_count, unhelpful_count) = ` +
+		`(SELECT COUNT(*) FILTER (WHERE helpful), COUNT(*) FILTER (WHERE NOT helpful) FROM review_votes WHERE review_id = $1) ` +
+		`WHERE id = $1 RETURNING ` + ReviewColumns
+)
+
+type ReviewRepository interface {
+	AddReview(review model.Review, hold *model.Moderation) (int, error)
+	GetReview(reviewId int) (model.Review, error)
+	GetReviews(query model.ReviewQuery) ([]model.Review, error)
+	GetModerationQueue(limit int) ([]model.Review, error)
+	GetModerations(reviewId int) ([]model.Moderation, error)
+	Moderate(moderation model.Moderation) (model.Review, error)
+	Report(report model.Report, threshold int) (bool, error)
+	Vote(vote model.Vote) (model.Review, error)
+}
+
+type reviewRepo struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) ReviewRepository {
+	return &reviewRepo{db: db}
+}
+
+// AddReview stores the review if the user has returned a rental of the
+// movie, and recomputes the movie's rating in the same transaction. The
+// movie row is locked first so concurrent reviews cannot leave a stale
+// average behind. A review held for moderation comes with the audit entry
+// explaining why.
+func (m reviewRepo) AddReview(review model.Review, hold *model.Moderation) (int, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin review: %w", err)
+	}
+	defer tx.Rollback()
+
+	var movieId int
+	err = tx.QueryRow(LockMovieSQL, review.MovieId).Scan(&movieId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, movieModel.ErrMovieNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock movie: %w", err)
+	}
+
+	var id int
+	err = tx.QueryRow(InsertReviewSQL, review.MovieId, review.UserId, review.Rating, review.Body, review.Status,
+		review.ReasonCode, review.CreatedAt).Scan(&id)
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrDuplicateReview
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, model.ErrReviewNotAllowed
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert review: %w", err)
+	}
+	if hold != nil {
+		hold.ReviewId = id
+		if err = addModeration(tx, *hold); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = tx.Exec(UpdateMovieRatingSQL, review.MovieId); err != nil {
+		return 0, fmt.Errorf("failed to update movie rating: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit review: %w", err)
+	}
+	return id, nil
+}
+
+// GetReviews returns up to query.Limit+1 reviews past the cursor, so the
+// caller can tell whether another page follows.
+func (m reviewRepo) GetReviews(query model.ReviewQuery) ([]model.Review, error) {
+	sqlQuery := SelectReviewsSQL
+	args := []any{query.MovieId}
+	switch {
+	case query.Sort == model.SortHelpful && query.Cursor != nil:
+		sqlQuery += HelpfulAfterSQL
+		args = append(args, query.Cursor.HelpfulCount, query.Cursor.CreatedAt, query.Cursor.Id)
+	case query.Sort == model.SortHelpful:
+		sqlQuery += HelpfulFirstSQL
+	case query.Cursor != nil:
+		sqlQuery += NewestAfterSQL
+		args = append(args, query.Cursor.CreatedAt, query.Cursor.Id)
+	default:
+		sqlQuery += NewestFirstSQL
+	}
+	args = append(args, query.Limit+1)
+
+	return m.queryReviews(sqlQuery, args...)
+}
+
+func (m reviewRepo) GetReview(reviewId int) (model.Review, error) {
+	review, err := scanReview(m.db.QueryRow(SelectReviewByIdSQL, reviewId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Review{}, model.ErrReviewNotFound
+	}
+	if err != nil {
+		return model.Review{}, fmt.Errorf("failed to fetch review: %w", err)
+	}
+	return review, nil
+}
+
+// GetModerationQueue lists pending reviews, oldest first.
+func (m reviewRepo) GetModerationQueue(limit int) ([]model.Review, error) {
+	return m.queryReviews(SelectModerationQueueSQL, limit)
+}
+
+func (m reviewRepo) GetModerations(reviewId int) ([]model.Moderation, error) {
+	rows, err := m.db.Query(SelectModerationsSQL, reviewId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch moderations: %w", err)
+	}
+	defer rows.Close()
+
+	moderations := []model.Moderation{}
+	for rows.Next() {
+		var moderation model.Moderation
+		err := rows.Scan(&moderation.Id, &moderation.ReviewId, &moderation.ModeratorId, &moderation.Status, &moderation.ReasonCode,
+			&moderation.Note, &moderation.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation: %w", err)
+		}
+		moderations = append(moderations, moderation)
+	}
+	return moderations, rows.Err()
+}
+
+// Moderate sets the review's status, records who set it and recomputes the
+// movie's rating, since the review may have entered or left it.
+func (m reviewRepo) Moderate(moderation model.Moderation) (model.Review, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return model.Review{}, fmt.Errorf("failed to begin moderation: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = lockReview(tx, moderation.ReviewId); err != nil {
+		return model.Review{}, err
+	}
+	review, err := setStatus(tx, moderation)
+	if err != nil {
+		return model.Review{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		return model.Review{}, fmt.Errorf("failed to commit moderation: %w", err)
+	}
+	return review, nil
+}
+
+// Report records a customer's report of an approved review. Once the review
+// has threshold reports since it was last moderated it goes back to the
+// queue, and Report returns true.
+func (m reviewRepo) Report(report model.Report, threshold int) (bool, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin report: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := lockReview(tx, report.ReviewId)
+	if err != nil {
+		return false, err
+	}
+	if review.Status != model.StatusApproved {
+		return false, model.ErrReviewNotFound
+	}
+	_, err = tx.Exec(InsertReportSQL, report.ReviewId, report.UserId, report.ReasonCode, report.Note, report.CreatedAt)
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return false, model.ErrDuplicateReport
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert report: %w", err)
+	}
+
+	var reports int
+	if err = tx.QueryRow(CountOpenReportsSQL, report.ReviewId).Scan(&reports); err != nil {
+		return false, fmt.Errorf("failed to count reports: %w", err)
+	}
+	held := reports >= threshold
+	if held {
+		hold := model.Moderation{ReviewId: report.ReviewId, Status: model.StatusPending, ReasonCode: model.ReasonReported,
+			CreatedAt: report.CreatedAt}
+		if _, err = setStatus(tx, hold); err != nil {
+			return false, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit report: %w", err)
+	}
+	return held, nil
+}
+
+// Vote records the user's vote on an approved review, replacing any earlier
+// vote of theirs, and returns the review with its recounted votes.
+func (m reviewRepo) Vote(vote model.Vote) (model.Review, error) {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return model.Review{}, fmt.Errorf("failed to begin vote: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := lockReview(tx, vote.ReviewId)
+	if err != nil {
+		return model.Review{}, err
+	}
+	if review.Status != model.StatusApproved {
+		return model.Review{}, model.ErrReviewNotFound
+	}
+	if review.UserId == vote.UserId {
+		return model.Review{}, model.ErrOwnReview
+	}
+	if _, err = tx.Exec(UpsertVoteSQL, vote.ReviewId, vote.UserId, vote.Helpful, vote.CreatedAt); err != nil {
+		return model.Review{}, fmt.Errorf("failed to record vote: %w", err)
+	}
+	if review, err = scanReview(tx.QueryRow(UpdateVoteCountsSQL, vote.ReviewId)); err != nil {
+		return model.Review{}, fmt.Errorf("failed to count votes: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.Review{}, fmt.Errorf("failed to commit vote: %w", err)
+	}
+	return review, nil
+}
+
+func (m reviewRepo) queryReviews(query string, args ...any) ([]model.Review, error) {
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []model.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
+
+func lockReview(tx *sqlx.Tx, reviewId int) (model.Review, error) {
+	review, err := scanReview(tx.QueryRow(LockReviewSQL, reviewId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Review{}, model.ErrReviewNotFound
+	}
+	if err != nil {
+		return model.Review{}, fmt.Errorf("failed to lock review: %w", err)
+	}
+	return review, nil
+}
+
+// setStatus applies a moderation to a locked review, records it and
+// recomputes the movie's rating. The movie row is locked before the recount,
+// as in AddReview.
+func setStatus(tx *sqlx.Tx, moderation model.Moderation) (model.Review, error) {
+	review, err := scanReview(tx.QueryRow(UpdateReviewStatusSQL, moderation.Status, moderation.ReasonCode, moderation.CreatedAt,
+		moderation.ReviewId))
+	if err != nil {
+		return model.Review{}, fmt.Errorf("failed to update review status: %w", err)
+	}
+	if err = addModeration(tx, moderation); err != nil {
+		return model.Review{}, err
+	}
+	if _, err = tx.Exec(LockMovieRatingSQL, review.MovieId); err != nil {
+		return model.Review{}, fmt.Errorf("failed to lock movie: %w", err)
+	}
+	if _, err = tx.Exec(UpdateMovieRatingSQL, review.MovieId); err != nil {
+		return model.Review{}, fmt.Errorf("failed to update movie rating: %w", err)
+	}
+	return review, nil
+}
+
+func addModeration(tx *sqlx.Tx, moderation model.Moderation) error {
+	_, err := tx.Exec(InsertModerationSQL, moderation.ReviewId, moderation.ModeratorId, moderation.Status, moderation.ReasonCode,
+		moderation.Note, moderation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record moderation: %w", err)
+	}
+	return nil
+}
+
+func scanReview(row postgres.Scanner) (model.Review, error) {
+	var review model.Review
+	err := row.Scan(&review.Id, &review.MovieId, &review.UserId, &review.Rating, &review.Body, &review.Status, &review.ReasonCode,
+		&review.HelpfulCount, &review.UnhelpfulCount, &review.CreatedAt)
+	return review, err
+}
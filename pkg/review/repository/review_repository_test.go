@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/review/model"
+	"testing"
+	"time"
+)
+
+type ReviewRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository ReviewRepository
+}
+
+func TestReviewRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewRepositoryTestSuite))
+}
+
+func (suite *ReviewRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewReviewRepository(suite.mockedDB)
+}
+
+func (suite *ReviewRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+var reviewRowColumns = []string{"id", "movie_id", "user_id", "rating", "body", "status", "reason_code", "helpful_count", "unhelpful_count", "created_at"}
+
+func (suite *ReviewRepositoryTestSuite) Test_AddReview_ShouldUpdateMovieRating() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockMovieSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(InsertReviewSQL).WithArgs(7, 3, 4, "Great", model.StatusApproved, "", now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	suite.mockDB.ExpectExec(UpdateMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	id, err := suite.testRepository.AddReview(model.Review{MovieId: 7, UserId: 3, Rating: 4, Body: "Great", Status: model.StatusApproved, CreatedAt: now}, nil)
+
+	suite.Nil(err)
+	suite.Equal(12, id)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_AddReview_ShouldRecordWhyReviewIsHeld() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockMovieSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(InsertReviewSQL).WithArgs(7, 3, 1, "Darn", model.StatusPending, model.ReasonBannedWords, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	suite.mockDB.ExpectExec(InsertModerationSQL).WithArgs(12, nil, model.StatusPending, model.ReasonBannedWords, "matched: darn", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(UpdateMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	review := model.Review{MovieId: 7, UserId: 3, Rating: 1, Body: "Darn", Status: model.StatusPending, ReasonCode: model.ReasonBannedWords,
+		CreatedAt: now}
+	hold := model.Moderation{Status: model.StatusPending, ReasonCode: model.ReasonBannedWords, Note: "matched: darn", CreatedAt: now}
+	id, err := suite.testRepository.AddReview(review, &hold)
+
+	suite.Nil(err)
+	suite.Equal(12, id)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_AddReview_ShouldReturnMovieNotFound() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockMovieSQL).WithArgs(7).WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.AddReview(model.Review{MovieId: 7, UserId: 3, Rating: 4, Status: model.StatusApproved}, nil)
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_AddReview_ShouldRejectUserWithoutReturnedRental() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockMovieSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(InsertReviewSQL).WithArgs(7, 3, 4, "", model.StatusApproved, "", sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.AddReview(model.Review{MovieId: 7, UserId: 3, Rating: 4, Status: model.StatusApproved}, nil)
+
+	suite.ErrorIs(err, model.ErrReviewNotAllowed)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_AddReview_ShouldReturnDuplicate() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockMovieSQL).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	suite.mockDB.ExpectQuery(InsertReviewSQL).WithArgs(7, 3, 4, "", model.StatusApproved, "", sqlmock.AnyArg()).WillReturnError(&pq.Error{Code: postgres.UniqueViolation})
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.AddReview(model.Review{MovieId: 7, UserId: 3, Rating: 4, Status: model.StatusApproved}, nil)
+
+	suite.ErrorIs(err, model.ErrDuplicateReview)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_GetReviews_ShouldReadNewestFirst() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectQuery(SelectReviewsSQL+NewestFirstSQL).WithArgs(7, 11).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 4, "Great", "approved", "", 2, 1, createdAt))
+
+	reviews, err := suite.testRepository.GetReviews(model.ReviewQuery{MovieId: 7, Sort: model.SortNewest, Limit: 10})
+
+	suite.Nil(err)
+	suite.Equal([]model.Review{{Id: 12, MovieId: 7, UserId: 3, Rating: 4, Body: "Great", Status: model.StatusApproved,
+		HelpfulCount: 2, UnhelpfulCount: 1, CreatedAt: createdAt}}, reviews)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_GetReviews_ShouldContinueAfterHelpfulCursor() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cursor := model.Cursor{Sort: model.SortHelpful, HelpfulCount: 5, CreatedAt: createdAt, Id: 12}
+	suite.mockDB.ExpectQuery(SelectReviewsSQL+HelpfulAfterSQL).WithArgs(7, 5, createdAt, 12, 3).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns))
+
+	reviews, err := suite.testRepository.GetReviews(model.ReviewQuery{MovieId: 7, Sort: model.SortHelpful, Limit: 2, Cursor: &cursor})
+
+	suite.Nil(err)
+	suite.Empty(reviews)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Moderate_ShouldAuditAndRecomputeRating() {
+	now := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	moderatorId := 50
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 1, "Darn", "pending", "banned_words", 0, 0, createdAt))
+	suite.mockDB.ExpectQuery(UpdateReviewStatusSQL).WithArgs(model.StatusRejected, model.ReasonOffensive, now, 12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 1, "Darn", "rejected", "offensive", 0, 0, createdAt))
+	suite.mockDB.ExpectExec(InsertModerationSQL).WithArgs(12, 50, model.StatusRejected, model.ReasonOffensive, "", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(LockMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(UpdateMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	review, err := suite.testRepository.Moderate(model.Moderation{ReviewId: 12, ModeratorId: &moderatorId, Status: model.StatusRejected,
+		ReasonCode: model.ReasonOffensive, CreatedAt: now})
+
+	suite.Nil(err)
+	suite.Equal(model.StatusRejected, review.Status)
+	suite.Equal(model.ReasonOffensive, review.ReasonCode)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Moderate_ShouldReturnNotFound() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).WillReturnError(sql.ErrNoRows)
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.Moderate(model.Moderation{ReviewId: 12, Status: model.StatusApproved})
+
+	suite.ErrorIs(err, model.ErrReviewNotFound)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Report_ShouldHoldReviewAtThreshold() {
+	now := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Spoilers", "approved", "", 0, 0, createdAt))
+	suite.mockDB.ExpectExec(InsertReportSQL).WithArgs(12, 4, model.ReasonSpoiler, "", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(CountOpenReportsSQL).WithArgs(12).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	suite.mockDB.ExpectQuery(UpdateReviewStatusSQL).WithArgs(model.StatusPending, model.ReasonReported, now, 12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Spoilers", "pending", "reported", 0, 0, createdAt))
+	suite.mockDB.ExpectExec(InsertModerationSQL).WithArgs(12, nil, model.StatusPending, model.ReasonReported, "", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(LockMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectExec(UpdateMovieRatingSQL).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectCommit()
+
+	held, err := suite.testRepository.Report(model.Report{ReviewId: 12, UserId: 4, ReasonCode: model.ReasonSpoiler, CreatedAt: now}, 3)
+
+	suite.Nil(err)
+	suite.True(held)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Report_ShouldKeepReviewBelowThreshold() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Spoilers", "approved", "", 0, 0, createdAt))
+	suite.mockDB.ExpectExec(InsertReportSQL).WithArgs(12, 4, model.ReasonSpoiler, "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(CountOpenReportsSQL).WithArgs(12).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockDB.ExpectCommit()
+
+	held, err := suite.testRepository.Report(model.Report{ReviewId: 12, UserId: 4, ReasonCode: model.ReasonSpoiler}, 3)
+
+	suite.Nil(err)
+	suite.False(held)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Report_ShouldReturnDuplicate() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Spoilers", "approved", "", 0, 0, createdAt))
+	suite.mockDB.ExpectExec(InsertReportSQL).WithArgs(12, 4, model.ReasonSpam, "", sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: postgres.UniqueViolation})
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.Report(model.Report{ReviewId: 12, UserId: 4, ReasonCode: model.ReasonSpam}, 3)
+
+	suite.ErrorIs(err, model.ErrDuplicateReport)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Vote_ShouldRecountVotes() {
+	now := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Great", "approved", "", 2, 0, createdAt))
+	suite.mockDB.ExpectExec(UpsertVoteSQL).WithArgs(12, 4, false, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockDB.ExpectQuery(UpdateVoteCountsSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Great", "approved", "", 2, 1, createdAt))
+	suite.mockDB.ExpectCommit()
+
+	review, err := suite.testRepository.Vote(model.Vote{ReviewId: 12, UserId: 4, Helpful: false, CreatedAt: now})
+
+	suite.Nil(err)
+	suite.Equal(2, review.HelpfulCount)
+	suite.Equal(1, review.UnhelpfulCount)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Vote_ShouldRejectOwnReview() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Great", "approved", "", 0, 0, createdAt))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.Vote(model.Vote{ReviewId: 12, UserId: 3, Helpful: true})
+
+	suite.ErrorIs(err, model.ErrOwnReview)
+}
+
+func (suite *ReviewRepositoryTestSuite) Test_Vote_ShouldHideUnapprovedReview() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockReviewSQL).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows(reviewRowColumns).AddRow(12, 7, 3, 5, "Great", "pending", "reported", 0, 0, createdAt))
+	suite.mockDB.ExpectRollback()
+
+	_, err := suite.testRepository.Vote(model.Vote{ReviewId: 12, UserId: 4, Helpful: true})
+
+	suite.ErrorIs(err, model.ErrReviewNotFound)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"movie-rent/config"
+	movieRepository "movie-rent/pkg/movie/repository"
+	"movie-rent/pkg/review/model"
+	"movie-rent/pkg/review/repository"
+	"strings"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/review/service/review_service.go -destination=pkg/review/mocks/review_service_mock.go -package=mocks
+
+type ReviewService interface {
+	AddReview(movieId int, userId int, request model.ReviewRequest) (model.Review, error)
+	GetReviews(movieId int, sort string, limit int, cursor string) (model.ReviewPage, error)
+	VoteReview(reviewId int, userId int, helpful bool) (model.Review, error)
+	ReportReview(reviewId int, userId int, request model.ReportRequest) error
+	GetModerationQueue(limit int) ([]model.Review, error)
+	ModerateReview(reviewId int, moderatorId int, request model.ModerationRequest) (model.Review, error)
+	GetModerations(reviewId int) ([]model.Moderation, error)
+}
+
+type reviewService struct {
+	repository      repository.ReviewRepository
+	movieRepository movieRepository.MovieRepository
+	bannedWords     model.WordFilter
+	policy          config.ReviewConfig
+}
+
+func NewReviewService(repository repository.ReviewRepository, movieRepository movieRepository.MovieRepository,
+	policy config.ReviewConfig) ReviewService {
+	return reviewService{repository: repository, movieRepository: movieRepository,
+		bannedWords: model.NewWordFilter(policy.BannedWords), policy: policy}
+}
+
+// AddReview publishes the review straight away unless it uses a banned
+// word, in which case it waits for a moderator.
+func (m reviewService) AddReview(movieId int, userId int, request model.ReviewRequest) (model.Review, error) {
+	review := model.Review{
+		MovieId:   movieId,
+		UserId:    userId,
+		Rating:    request.Rating,
+		Body:      strings.TrimSpace(request.Body),
+		Status:    model.StatusApproved,
+		CreatedAt: time.Now(),
+	}
+	var hold *model.Moderation
+	if matches := m.bannedWords.Match(review.Body); len(matches) > 0 {
+		review.Status = model.StatusPending
+		review.ReasonCode = model.ReasonBannedWords
+		hold = &model.Moderation{
+			Status:     model.StatusPending,
+			ReasonCode: model.ReasonBannedWords,
+			Note:       "matched: " + strings.Join(matches, ", "),
+			CreatedAt:  review.CreatedAt,
+		}
+	}
+	id, err := m.repository.AddReview(review, hold)
+	if err != nil {
+		fmt.Println("failed to add review:", err.Error())
+		return model.Review{}, err
+	}
+	review.Id = id
+	return review, nil
+}
+
+// GetReviews pages through a movie's reviews. The total is the movie's
+// rating count, which the repository keeps in step with its reviews.
+func (m reviewService) GetReviews(movieId int, sort string, limit int, cursor string) (model.ReviewPage, error) {
+	query, err := model.NewReviewQuery(movieId, sort, limit, cursor)
+	if err != nil {
+		return model.ReviewPage{}, err
+	}
+	movie, err := m.movieRepository.GetMovieBy(movieId)
+	if err != nil {
+		fmt.Println("failed to find movie for reviews:", err.Error())
+		return model.ReviewPage{}, err
+	}
+	reviews, err := m.repository.GetReviews(query)
+	if err != nil {
+		fmt.Println("failed to find reviews:", err.Error())
+		return model.ReviewPage{}, err
+	}
+
+	page := model.ReviewPage{Items: reviews, Total: movie.RatingCount, Limit: query.Limit, Sort: query.Sort}
+	if len(reviews) > query.Limit {
+		page.Items = reviews[:query.Limit]
+		page.Next = model.CursorAfter(page.Items[query.Limit-1], query.Sort).Encode()
+	}
+	return page, nil
+}
+
+func (m reviewService) VoteReview(reviewId int, userId int, helpful bool) (model.Review, error) {
+	review, err := m.repository.Vote(model.Vote{ReviewId: reviewId, UserId: userId, Helpful: helpful, CreatedAt: time.Now()})
+	if err != nil {
+		fmt.Println("failed to vote on review:", err.Error())
+		return model.Review{}, err
+	}
+	return review, nil
+}
+
+func (m reviewService) ReportReview(reviewId int, userId int, request model.ReportRequest) error {
+	if !model.IsReportReason(request.ReasonCode) {
+		return fmt.Errorf("%w: unknown reason code %q", model.ErrInvalidReport, request.ReasonCode)
+	}
+	report := model.Report{
+		ReviewId:   reviewId,
+		UserId:     userId,
+		ReasonCode: request.ReasonCode,
+		Note:       strings.TrimSpace(request.Note),
+		CreatedAt:  time.Now(),
+	}
+	held, err := m.repository.Report(report, m.policy.ReportThreshold)
+	if err != nil {
+		fmt.Println("failed to report review:", err.Error())
+		return err
+	}
+	if held {
+		fmt.Println("review held for moderation after reports:", reviewId)
+	}
+	return nil
+}
+
+func (m reviewService) GetModerationQueue(limit int) ([]model.Review, error) {
+	if limit == 0 {
+		limit = model.DefaultLimit
+	}
+	if limit < 1 || limit > model.MaxLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalidQuery, model.MaxLimit)
+	}
+	reviews, err := m.repository.GetModerationQueue(limit)
+	if err != nil {
+		fmt.Println("failed to find pending reviews:", err.Error())
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ModerateReview approves or rejects a review. Rejections need a reason
+// code; approvals clear it.
+func (m reviewService) ModerateReview(reviewId int, moderatorId int, request model.ModerationRequest) (model.Review, error) {
+	if request.Status == model.StatusRejected && !model.IsRejectReason(request.ReasonCode) {
+		return model.Review{}, fmt.Errorf("%w: rejecting needs a reason code", model.ErrInvalidModeration)
+	}
+	if request.Status == model.StatusApproved && request.ReasonCode != "" {
+		return model.Review{}, fmt.Errorf("%w: approving takes no reason code", model.ErrInvalidModeration)
+	}
+	review, err := m.repository.Moderate(model.Moderation{
+		ReviewId:    reviewId,
+		ModeratorId: &moderatorId,
+		Status:      request.Status,
+		ReasonCode:  request.ReasonCode,
+		Note:        strings.TrimSpace(request.Note),
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		fmt.Println("failed to moderate review:", err.Error())
+		return model.Review{}, err
+	}
+	return review, nil
+}
+
+func (m reviewService) GetModerations(reviewId int) ([]model.Moderation, error) {
+	if _, err := m.repository.GetReview(reviewId); err != nil {
+		fmt.Println("failed to find review:", err.Error())
+		return nil, err
+	}
+	moderations, err := m.repository.GetModerations(reviewId)
+	if err != nil {
+		fmt.Println("failed to find moderations:", err.Error())
+		return nil, err
+	}
+	return moderations, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/config"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/review/mocks"
+	"movie-rent/pkg/review/model"
+	"testing"
+	"time"
+)
+
+type ReviewServiceTestSuite struct {
+	suite.Suite
+	mockController      *gomock.Controller
+	mockRepository      *mocks.MockReviewRepository
+	mockMovieRepository *movieMocks.MockMovieRepository
+
+	reviewService ReviewService
+}
+
+func TestReviewServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceTestSuite))
+}
+
+func (suite *ReviewServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockReviewRepository(suite.mockController)
+	suite.mockMovieRepository = movieMocks.NewMockMovieRepository(suite.mockController)
+
+	policy := config.ReviewConfig{BannedWords: []string{"darn", "heck"}, ReportThreshold: 3}
+	suite.reviewService = NewReviewService(suite.mockRepository, suite.mockMovieRepository, policy)
+}
+
+func (suite *ReviewServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *ReviewServiceTestSuite) Test_AddReview_ShouldTrimBody() {
+	suite.mockRepository.EXPECT().AddReview(gomock.Any(), nil).DoAndReturn(func(review model.Review, _ *model.Moderation) (int, error) {
+		suite.Equal("Loved it", review.Body)
+		suite.Equal(model.StatusApproved, review.Status)
+		suite.Equal(7, review.MovieId)
+		suite.Equal(3, review.UserId)
+		return 12, nil
+	}).Times(1)
+
+	review, err := suite.reviewService.AddReview(7, 3, model.ReviewRequest{Rating: 5, Body: "  Loved it \n"})
+
+	suite.Nil(err)
+	suite.Equal(12, review.Id)
+	suite.Equal(5, review.Rating)
+}
+
+func (suite *ReviewServiceTestSuite) Test_AddReview_ShouldHoldReviewWithBannedWords() {
+	suite.mockRepository.EXPECT().AddReview(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(review model.Review, hold *model.Moderation) (int, error) {
+			suite.Equal(model.StatusPending, review.Status)
+			suite.Equal(model.ReasonBannedWords, review.ReasonCode)
+			suite.Nil(hold.ModeratorId)
+			suite.Equal("matched: heck, darn", hold.Note)
+			return 12, nil
+		}).Times(1)
+
+	review, err := suite.reviewService.AddReview(7, 3, model.ReviewRequest{Rating: 1, Body: "What the Heck. Darn."})
+
+	suite.Nil(err)
+	suite.Equal(model.StatusPending, review.Status)
+}
+
+func (suite *ReviewServiceTestSuite) Test_AddReview_ShouldReturnRepositoryError() {
+	suite.mockRepository.EXPECT().AddReview(gomock.Any(), nil).Return(0, model.ErrReviewNotAllowed).Times(1)
+
+	_, err := suite.reviewService.AddReview(7, 3, model.ReviewRequest{Rating: 5})
+
+	suite.ErrorIs(err, model.ErrReviewNotAllowed)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetReviews_ShouldLinkNextPage() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	reviews := []model.Review{
+		{Id: 12, MovieId: 7, Rating: 4, HelpfulCount: 5, CreatedAt: createdAt},
+		{Id: 9, MovieId: 7, Rating: 2, HelpfulCount: 3, CreatedAt: createdAt},
+		{Id: 4, MovieId: 7, Rating: 5, HelpfulCount: 1, CreatedAt: createdAt},
+	}
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{Id: 7, RatingCount: 6}, nil).Times(1)
+	suite.mockRepository.EXPECT().GetReviews(model.ReviewQuery{MovieId: 7, Sort: model.SortHelpful, Limit: 2}).
+		Return(reviews, nil).Times(1)
+
+	page, err := suite.reviewService.GetReviews(7, model.SortHelpful, 2, "")
+
+	suite.Nil(err)
+	suite.Equal(reviews[:2], page.Items)
+	suite.Equal(6, page.Total)
+	suite.Equal(model.CursorAfter(reviews[1], model.SortHelpful).Encode(), page.Next)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetReviews_ShouldOmitNextOnLastPage() {
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{Id: 7}, nil).Times(1)
+	suite.mockRepository.EXPECT().GetReviews(gomock.Any()).Return([]model.Review{}, nil).Times(1)
+
+	page, err := suite.reviewService.GetReviews(7, "", 0, "")
+
+	suite.Nil(err)
+	suite.Equal(model.ReviewPage{Items: []model.Review{}, Limit: model.DefaultLimit, Sort: model.SortNewest}, page)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetReviews_ShouldReturnMovieNotFound() {
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{}, movieModel.ErrMovieNotFound).Times(1)
+
+	_, err := suite.reviewService.GetReviews(7, "", 0, "")
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetReviews_ShouldRejectInvalidQuery() {
+	_, err := suite.reviewService.GetReviews(7, "oldest", 0, "")
+
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+}
+
+func (suite *ReviewServiceTestSuite) Test_VoteReview_ShouldRecordVote() {
+	suite.mockRepository.EXPECT().Vote(gomock.Any()).DoAndReturn(func(vote model.Vote) (model.Review, error) {
+		suite.Equal(model.Vote{ReviewId: 12, UserId: 4, Helpful: true, CreatedAt: vote.CreatedAt}, vote)
+		return model.Review{Id: 12, HelpfulCount: 1}, nil
+	}).Times(1)
+
+	review, err := suite.reviewService.VoteReview(12, 4, true)
+
+	suite.Nil(err)
+	suite.Equal(1, review.HelpfulCount)
+}
+
+func (suite *ReviewServiceTestSuite) Test_ReportReview_ShouldUseConfiguredThreshold() {
+	suite.mockRepository.EXPECT().Report(gomock.Any(), 3).DoAndReturn(func(report model.Report, _ int) (bool, error) {
+		suite.Equal(model.ReasonSpoiler, report.ReasonCode)
+		suite.Equal("Ending in line one", report.Note)
+		return true, nil
+	}).Times(1)
+
+	err := suite.reviewService.ReportReview(12, 4, model.ReportRequest{ReasonCode: model.ReasonSpoiler, Note: " Ending in line one "})
+
+	suite.Nil(err)
+}
+
+func (suite *ReviewServiceTestSuite) Test_ReportReview_ShouldRejectSystemReasonCode() {
+	err := suite.reviewService.ReportReview(12, 4, model.ReportRequest{ReasonCode: model.ReasonReported})
+
+	suite.ErrorIs(err, model.ErrInvalidReport)
+}
+
+func (suite *ReviewServiceTestSuite) Test_ModerateReview_ShouldRecordModerator() {
+	suite.mockRepository.EXPECT().Moderate(gomock.Any()).DoAndReturn(func(moderation model.Moderation) (model.Review, error) {
+		suite.Equal(50, *moderation.ModeratorId)
+		suite.Equal(model.StatusRejected, moderation.Status)
+		suite.Equal(model.ReasonSpam, moderation.ReasonCode)
+		return model.Review{Id: 12, Status: model.StatusRejected, ReasonCode: model.ReasonSpam}, nil
+	}).Times(1)
+
+	review, err := suite.reviewService.ModerateReview(12, 50, model.ModerationRequest{Status: model.StatusRejected, ReasonCode: model.ReasonSpam})
+
+	suite.Nil(err)
+	suite.Equal(model.StatusRejected, review.Status)
+}
+
+func (suite *ReviewServiceTestSuite) Test_ModerateReview_ShouldRequireReasonToReject() {
+	_, err := suite.reviewService.ModerateReview(12, 50, model.ModerationRequest{Status: model.StatusRejected})
+
+	suite.ErrorIs(err, model.ErrInvalidModeration)
+}
+
+func (suite *ReviewServiceTestSuite) Test_ModerateReview_ShouldRejectReasonOnApproval() {
+	_, err := suite.reviewService.ModerateReview(12, 50, model.ModerationRequest{Status: model.StatusApproved, ReasonCode: model.ReasonSpam})
+
+	suite.ErrorIs(err, model.ErrInvalidModeration)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetModerationQueue_ShouldRejectLimitAboveMax() {
+	_, err := suite.reviewService.GetModerationQueue(model.MaxLimit + 1)
+
+	suite.ErrorIs(err, model.ErrInvalidQuery)
+}
+
+func (suite *ReviewServiceTestSuite) Test_GetModerations_ShouldReturnNotFoundForUnknownReview() {
+	suite.mockRepository.EXPECT().GetReview(12).Return(model.Review{}, model.ErrReviewNotFound).Times(1)
+
+	_, err := suite.reviewService.GetModerations(12)
+
+	suite.ErrorIs(err, model.ErrReviewNotFound)
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// Every runs job on a ticker in the background until stop is closed. A
+// failing run is logged and retried on the next tick.
+func Every(interval time.Duration, name string, job func() error, stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := job(); err != nil {
+					fmt.Println("scheduled job failed:", name, err.Error())
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
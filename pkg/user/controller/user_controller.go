@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"movie-rent/pkg/user/model"
+	"movie-rent/pkg/user/service"
+	"net/http"
+	"strconv"
+)
+
+type UserController struct {
+	service service.UserService
+}
+
+func NewUserController(service service.UserService) UserController {
+	return UserController{service: service}
+}
+
+func (m *UserController) Register(ctx *gin.Context) {
+	var request model.RegisterRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	user, err := m.service.Register(request)
+	if errors.Is(err, model.ErrDuplicateEmail) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, user)
+}
+
+func (m *UserController) GetUser(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "id is invalid")
+		return
+	}
+
+	user, err := m.service.GetUser(userId)
+	if errors.Is(err, model.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+func (m *UserController) UpdateProfile(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "id is invalid")
+		return
+	}
+	var request model.UpdateProfileRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	user, err := m.service.UpdateProfile(userId, request)
+	if errors.Is(err, model.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateEmail) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+func (m *UserController) SetRole(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "id is invalid")
+		return
+	}
+	var request model.RoleRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	user, err := m.service.SetRole(userId, request.Role)
+	if errors.Is(err, model.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/pkg/user/mocks"
+	"movie-rent/pkg/user/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type UserControllerTestSuite struct {
+	suite.Suite
+	context         *gin.Context
+	recorder        *httptest.ResponseRecorder
+	mockController  *gomock.Controller
+	mockUserService *mocks.MockUserService
+	testController  UserController
+}
+
+func TestUserControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(UserControllerTestSuite))
+}
+
+func (suite *UserControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockUserService = mocks.NewMockUserService(suite.mockController)
+	suite.testController = NewUserController(suite.mockUserService)
+}
+
+func (suite *UserControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *UserControllerTestSuite) Test_Register_ShouldReturnBadRequestWhenPasswordTooShort() {
+	body := `{"email":"ada@example.com","name":"Ada","password":"short"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+
+	suite.testController.Register(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_Register_ShouldReturnConflictWhenEmailTaken() {
+	request := model.RegisterRequest{Email: "ada@example.com", Name: "Ada", Password: "correct horse"}
+	body := `{"email":"ada@example.com","name":"Ada","password":"correct horse"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	suite.mockUserService.EXPECT().Register(request).Return(model.User{}, model.ErrDuplicateEmail).Times(1)
+
+	suite.testController.Register(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_Register_ShouldNotExposePasswordHash() {
+	request := model.RegisterRequest{Email: "ada@example.com", Name: "Ada", Password: "correct horse"}
+	body := `{"email":"ada@example.com","name":"Ada","password":"correct horse"}`
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	suite.mockUserService.EXPECT().Register(request).Return(model.User{Id: 7, Email: "ada@example.com", PasswordHash: "hash"}, nil).Times(1)
+
+	suite.testController.Register(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.NotContains(suite.recorder.Body.String(), "hash")
+}
+
+func (suite *UserControllerTestSuite) Test_GetUser_ShouldReturnNotFound() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockUserService.EXPECT().GetUser(7).Return(model.User{}, model.ErrUserNotFound).Times(1)
+
+	suite.testController.GetUser(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_UpdateProfile_ShouldReturnBadRequestWhenEmailInvalid() {
+	suite.context.Request = httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"email":"nope"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+
+	suite.testController.UpdateProfile(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_UpdateProfile_ShouldUpdateUser() {
+	name := "Ada Lovelace"
+	suite.context.Request = httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"name":"Ada Lovelace"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockUserService.EXPECT().UpdateProfile(7, model.UpdateProfileRequest{Name: &name}).Return(model.User{Id: 7, Name: name}, nil).Times(1)
+
+	suite.testController.UpdateProfile(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_SetRole_ShouldReturnBadRequestForUnknownRole() {
+	suite.context.Request = httptest.NewRequest(http.MethodPut, "/users/7/role", strings.NewReader(`{"role":"owner"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+
+	suite.testController.SetRole(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserControllerTestSuite) Test_SetRole_ShouldReturnNotFound() {
+	suite.context.Request = httptest.NewRequest(http.MethodPut, "/users/7/role", strings.NewReader(`{"role":"staff"}`))
+	suite.context.Params = gin.Params{{Key: "id", Value: "7"}}
+	suite.mockUserService.EXPECT().SetRole(7, model.RoleStaff).Return(model.User{}, model.ErrUserNotFound).Times(1)
+
+	suite.testController.SetRole(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
@@ -0,0 +1,8 @@
+package model
+
+import "errors"
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrDuplicateEmail = errors.New("email is already registered")
+)
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	RoleCustomer = "customer"
+	RoleStaff    = "staff"
+	RoleAdmin    = "admin"
+)
+
+type User struct {
+	Id           int       `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Passwords are capped at 72 bytes, the most bcrypt will hash.
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Name     string `json:"name" binding:"required,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type UpdateProfileRequest struct {
+	Email *string `json:"email" binding:"omitempty,email"`
+	Name  *string `json:"name" binding:"omitempty,min=1,max=255"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=customer staff admin"`
+}
+
+// NormalizeEmail lower-cases and trims the address, so that uniqueness and
+// lookups ignore case.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
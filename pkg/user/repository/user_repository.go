@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/user/model"
+	"time"
+)
+
+const (
+	UserColumns          = `id, email, name, role, password_hash, created_at, updated_at`
+	InsertUserSQL        = `INSERT INTO users(email, name, role, password_hash, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $5) RETURNING id`
+	SelectUserByIdSQL    = `SELECT ` + UserColumns + ` FROM users WHERE id = $1`
+	SelectUserByEmailSQL = `SELECT ` + UserColumns + ` FROM users WHERE email = $1`
+	UpdateUserSQL        = `UPDATE users SET email = $1, name = $2, updated_at = $3 WHERE id = $4`
+	UpdateUserRoleSQL    = `UPDATE users SET role = $1, updated_at = $2 WHERE id = $3`
+)
+
+type UserRepository interface {
+	CreateUser(user model.User) (int, error)
+	GetUser(userId int) (model.User, error)
+	GetUserByEmail(email string) (model.User, error)
+	UpdateUser(user model.User) error
+	UpdateRole(userId int, role string, updatedAt time.Time) error
+}
+
+type userRepo struct {
+	db *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) UserRepository {
+	return &userRepo{db: db}
+}
+
+func (m userRepo) CreateUser(user model.User) (int, error) {
+	var id int
+	err := m.db.QueryRow(InsertUserSQL, user.Email, user.Name, user.Role, user.PasswordHash, user.CreatedAt).Scan(&id)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return 0, model.ErrDuplicateEmail
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert user: %w", err)
+	}
+	fmt.Println("Successfully inserted user. Id:", id)
+	return id, nil
+}
+
+func (m userRepo) GetUser(userId int) (model.User, error) {
+	return m.getUser(SelectUserByIdSQL, userId)
+}
+
+func (m userRepo) GetUserByEmail(email string) (model.User, error) {
+	return m.getUser(SelectUserByEmailSQL, email)
+}
+
+func (m userRepo) getUser(query string, arg any) (model.User, error) {
+	var u model.User
+	err := m.db.QueryRow(query, arg).Scan(&u.Id, &u.Email, &u.Name, &u.Role, &u.PasswordHash, &u.CreatedAt, &u.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.User{}, model.ErrUserNotFound
+	}
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return u, nil
+}
+
+func (m userRepo) UpdateUser(user model.User) error {
+	res, err := m.db.Exec(UpdateUserSQL, user.Email, user.Name, user.UpdatedAt, user.Id)
+
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return model.ErrDuplicateEmail
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
+
+func (m userRepo) UpdateRole(userId int, role string, updatedAt time.Time) error {
+	res, err := m.db.Exec(UpdateUserRoleSQL, role, updatedAt, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
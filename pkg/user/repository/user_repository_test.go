@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/user/model"
+	"testing"
+	"time"
+)
+
+type UserRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository UserRepository
+}
+
+func TestUserRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UserRepositoryTestSuite))
+}
+
+func (suite *UserRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewUserRepository(suite.mockedDB)
+}
+
+func (suite *UserRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+func (suite *UserRepositoryTestSuite) Test_CreateUser_ShouldReturnDuplicateEmail() {
+	now := time.Now()
+	suite.mockDB.ExpectQuery(InsertUserSQL).WithArgs("ada@example.com", "Ada", "customer", "hash", now).
+		WillReturnError(&pq.Error{Code: postgres.UniqueViolation})
+
+	_, err := suite.testRepository.CreateUser(model.User{Email: "ada@example.com", Name: "Ada", Role: model.RoleCustomer, PasswordHash: "hash", CreatedAt: now})
+
+	suite.ErrorIs(err, model.ErrDuplicateEmail)
+}
+
+func (suite *UserRepositoryTestSuite) Test_GetUserByEmail_ShouldReturnUser() {
+	now := time.Now()
+	suite.mockDB.ExpectQuery(SelectUserByEmailSQL).WithArgs("ada@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "role", "password_hash", "created_at", "updated_at"}).
+			AddRow(7, "ada@example.com", "Ada", "staff", "hash", now, now))
+
+	user, err := suite.testRepository.GetUserByEmail("ada@example.com")
+
+	suite.Nil(err)
+	suite.Equal(7, user.Id)
+	suite.Equal("hash", user.PasswordHash)
+	suite.Equal(model.RoleStaff, user.Role)
+}
+
+func (suite *UserRepositoryTestSuite) Test_GetUser_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectUserByIdSQL).WithArgs(7).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetUser(7)
+
+	suite.ErrorIs(err, model.ErrUserNotFound)
+}
+
+func (suite *UserRepositoryTestSuite) Test_UpdateUser_ShouldReturnNotFoundWhenNoRowUpdated() {
+	now := time.Now()
+	suite.mockDB.ExpectExec(UpdateUserSQL).WithArgs("ada@example.com", "Ada", now, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.UpdateUser(model.User{Id: 7, Email: "ada@example.com", Name: "Ada", UpdatedAt: now})
+
+	suite.ErrorIs(err, model.ErrUserNotFound)
+}
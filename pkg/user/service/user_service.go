@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"movie-rent/pkg/user/model"
+	"movie-rent/pkg/user/repository"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/user/service/user_service.go -destination=pkg/user/mocks/user_service_mock.go -package=mocks
+
+type UserService interface {
+	Register(request model.RegisterRequest) (model.User, error)
+	GetUser(userId int) (model.User, error)
+	UpdateProfile(userId int, request model.UpdateProfileRequest) (model.User, error)
+	SetRole(userId int, role string) (model.User, error)
+}
+
+type userService struct {
+	repository repository.UserRepository
+}
+
+func NewUserService(repository repository.UserRepository) UserService {
+	return userService{repository: repository}
+}
+
+func (m userService) Register(request model.RegisterRequest) (model.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		fmt.Println("failed to hash password:", err.Error())
+		return model.User{}, err
+	}
+
+	now := time.Now()
+	user := model.User{
+		Email:        model.NormalizeEmail(request.Email),
+		Name:         request.Name,
+		Role:         model.RoleCustomer,
+		PasswordHash: string(hash),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	user.Id, err = m.repository.CreateUser(user)
+	if err != nil {
+		fmt.Println("failed to register user:", err.Error())
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (m userService) GetUser(userId int) (model.User, error) {
+	return m.repository.GetUser(userId)
+}
+
+func (m userService) UpdateProfile(userId int, request model.UpdateProfileRequest) (model.User, error) {
+	user, err := m.repository.GetUser(userId)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	if request.Email != nil {
+		user.Email = model.NormalizeEmail(*request.Email)
+	}
+	if request.Name != nil {
+		user.Name = *request.Name
+	}
+	user.UpdatedAt = time.Now()
+
+	if err = m.repository.UpdateUser(user); err != nil {
+		fmt.Println("failed to update user:", err.Error())
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (m userService) SetRole(userId int, role string) (model.User, error) {
+	now := time.Now()
+	if err := m.repository.UpdateRole(userId, role, now); err != nil {
+		fmt.Println("failed to update user role:", err.Error())
+		return model.User{}, err
+	}
+	return m.repository.GetUser(userId)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"movie-rent/pkg/user/mocks"
+	"movie-rent/pkg/user/model"
+	"testing"
+)
+
+type UserServiceTestSuite struct {
+	suite.Suite
+	mockController *gomock.Controller
+	mockRepository *mocks.MockUserRepository
+	userService    UserService
+}
+
+func TestUserServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UserServiceTestSuite))
+}
+
+func (suite *UserServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockUserRepository(suite.mockController)
+	suite.userService = NewUserService(suite.mockRepository)
+}
+
+func (suite *UserServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *UserServiceTestSuite) Test_Register_ShouldHashPasswordAndNormalizeEmail() {
+	var stored model.User
+	suite.mockRepository.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user model.User) (int, error) {
+		stored = user
+		return 7, nil
+	}).Times(1)
+
+	user, err := suite.userService.Register(model.RegisterRequest{Email: " Ada@Example.com ", Name: "Ada", Password: "correct horse"})
+
+	suite.Nil(err)
+	suite.Equal(7, user.Id)
+	suite.Equal("ada@example.com", stored.Email)
+	suite.Equal(model.RoleCustomer, stored.Role)
+	suite.NotEqual("correct horse", stored.PasswordHash)
+	suite.Nil(bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("correct horse")))
+}
+
+func (suite *UserServiceTestSuite) Test_Register_ShouldReturnErrorWhenEmailTaken() {
+	suite.mockRepository.EXPECT().CreateUser(gomock.Any()).Return(0, model.ErrDuplicateEmail).Times(1)
+
+	_, err := suite.userService.Register(model.RegisterRequest{Email: "ada@example.com", Name: "Ada", Password: "correct horse"})
+
+	suite.ErrorIs(err, model.ErrDuplicateEmail)
+}
+
+func (suite *UserServiceTestSuite) Test_UpdateProfile_ShouldOnlyChangeGivenFields() {
+	existing := model.User{Id: 7, Email: "ada@example.com", Name: "Ada", PasswordHash: "hash"}
+	name := "Ada Lovelace"
+	suite.mockRepository.EXPECT().GetUser(7).Return(existing, nil).Times(1)
+	suite.mockRepository.EXPECT().UpdateUser(gomock.Any()).Return(nil).Times(1)
+
+	user, err := suite.userService.UpdateProfile(7, model.UpdateProfileRequest{Name: &name})
+
+	suite.Nil(err)
+	suite.Equal("Ada Lovelace", user.Name)
+	suite.Equal("ada@example.com", user.Email)
+	suite.Equal("hash", user.PasswordHash)
+}
+
+func (suite *UserServiceTestSuite) Test_UpdateProfile_ShouldReturnErrorWhenUserNotFound() {
+	suite.mockRepository.EXPECT().GetUser(7).Return(model.User{}, model.ErrUserNotFound).Times(1)
+
+	_, err := suite.userService.UpdateProfile(7, model.UpdateProfileRequest{})
+
+	suite.ErrorIs(err, model.ErrUserNotFound)
+}
+
+func (suite *UserServiceTestSuite) Test_SetRole_ShouldReturnUpdatedUser() {
+	suite.mockRepository.EXPECT().UpdateRole(7, model.RoleStaff, gomock.Any()).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetUser(7).Return(model.User{Id: 7, Role: model.RoleStaff}, nil).Times(1)
+
+	user, err := suite.userService.SetRole(7, model.RoleStaff)
+
+	suite.Nil(err)
+	suite.Equal(model.RoleStaff, user.Role)
+}
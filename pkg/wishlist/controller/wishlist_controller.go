@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	cartModel "movie-rent/pkg/cart/model"
+	inventoryModel "movie-rent/pkg/inventory/model"
+	movieModel "movie-rent/pkg/movie/model"
+	userModel "movie-rent/pkg/user/model"
+	"movie-rent/pkg/wishlist/model"
+	"movie-rent/pkg/wishlist/service"
+	"net/http"
+	"strconv"
+)
+
+type WishlistController struct {
+	service service.WishlistService
+}
+
+func NewWishlistController(service service.WishlistService) WishlistController {
+	return WishlistController{service: service}
+}
+
+func (m *WishlistController) GetWishlist(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	items, err := m.service.GetWishlist(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, items)
+}
+
+func (m *WishlistController) AddToWishlist(ctx *gin.Context) {
+	userId, movieId, ok := wishlistItem(ctx)
+	if !ok {
+		return
+	}
+	var request model.WishlistRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	item, err := m.service.AddToWishlist(userId, movieId, request.Note)
+	if errors.Is(err, movieModel.ErrMovieNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrDuplicateWishlistItem) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusCreated, item)
+}
+
+func (m *WishlistController) UpdateItem(ctx *gin.Context) {
+	userId, movieId, ok := wishlistItem(ctx)
+	if !ok {
+		return
+	}
+	var update model.WishlistUpdate
+	bindErr := ctx.ShouldBindJSON(&update)
+	if bindErr != nil {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	item, err := m.service.UpdateItem(userId, movieId, update)
+	if errors.Is(err, model.ErrWishlistItemNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, item)
+}
+
+func (m *WishlistController) RemoveFromWishlist(ctx *gin.Context) {
+	userId, movieId, ok := wishlistItem(ctx)
+	if !ok {
+		return
+	}
+
+	err := m.service.RemoveFromWishlist(userId, movieId)
+	if errors.Is(err, model.ErrWishlistItemNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (m *WishlistController) MoveToCart(ctx *gin.Context) {
+	userId, movieId, ok := wishlistItem(ctx)
+	if !ok {
+		return
+	}
+	var request model.MoveToCartRequest
+	bindErr := ctx.ShouldBindJSON(&request)
+	if bindErr != nil && !errors.Is(bindErr, io.EOF) {
+		fmt.Println("Invalid request body", bindErr.Error())
+		ctx.JSON(http.StatusBadRequest, bindErr.Error())
+		return
+	}
+
+	id, err := m.service.MoveToCart(userId, movieId, request.RentalDays)
+	if errors.Is(err, cartModel.ErrInvalidRentalDays) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, model.ErrWishlistItemNotFound) || errors.Is(err, movieModel.ErrMovieNotFound) ||
+		errors.Is(err, userModel.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, cartModel.ErrDuplicateCartItem) || errors.Is(err, inventoryModel.ErrOutOfStock) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, id)
+}
+
+// wishlistItem reads the user and movie ids from the path. RequireSelf has
+// already checked that the wishlist is the caller's own.
+func wishlistItem(ctx *gin.Context) (int, int, bool) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid id")
+		return 0, 0, false
+	}
+	movieId, err := strconv.Atoi(ctx.Param("movieId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, "Invalid movie id")
+		return 0, 0, false
+	}
+	return userId, movieId, true
+}
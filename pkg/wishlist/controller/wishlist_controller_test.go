@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	cartModel "movie-rent/pkg/cart/model"
+	inventoryModel "movie-rent/pkg/inventory/model"
+	"movie-rent/pkg/wishlist/mocks"
+	"movie-rent/pkg/wishlist/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type WishlistControllerTestSuite struct {
+	suite.Suite
+	context             *gin.Context
+	recorder            *httptest.ResponseRecorder
+	mockController      *gomock.Controller
+	mockWishlistService *mocks.MockWishlistService
+	testController      WishlistController
+}
+
+func TestWishlistControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(WishlistControllerTestSuite))
+}
+
+func (suite *WishlistControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockWishlistService = mocks.NewMockWishlistService(suite.mockController)
+	suite.testController = NewWishlistController(suite.mockWishlistService)
+}
+
+func (suite *WishlistControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *WishlistControllerTestSuite) Test_AddToWishlist_ShouldReturnCreatedItem() {
+	addedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7", strings.NewReader(`{"note":"Date night"}`))
+	suite.mockWishlistService.EXPECT().AddToWishlist(1001, 7, "Date night").
+		Return(model.WishlistItem{UserId: 1001, MovieId: 7, Title: "Hero", ReleaseYear: 2002, Note: "Date night", AddedAt: addedAt}, nil).Times(1)
+
+	suite.testController.AddToWishlist(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.JSONEq(`{"userId":1001,"movieId":7,"title":"Hero","releaseYear":2002,"availableCopies":0,"position":0,"note":"Date night",`+
+		`"addedAt":"2024-05-01T12:00:00Z"}`, suite.recorder.Body.String())
+}
+
+func (suite *WishlistControllerTestSuite) Test_AddToWishlist_ShouldAcceptEmptyBody() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7", nil)
+	suite.mockWishlistService.EXPECT().AddToWishlist(1001, 7, "").Return(model.WishlistItem{UserId: 1001, MovieId: 7}, nil).Times(1)
+
+	suite.testController.AddToWishlist(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_AddToWishlist_ShouldReturnConflictForDuplicate() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7", nil)
+	suite.mockWishlistService.EXPECT().AddToWishlist(1001, 7, "").Return(model.WishlistItem{}, model.ErrDuplicateWishlistItem).Times(1)
+
+	suite.testController.AddToWishlist(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_UpdateItem_ShouldRejectNegativePosition() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPatch, "/users/1001/wishlist/7", strings.NewReader(`{"position":-1}`))
+
+	suite.testController.UpdateItem(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_RemoveFromWishlist_ShouldReturnNotFound() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.mockWishlistService.EXPECT().RemoveFromWishlist(1001, 7).Return(model.ErrWishlistItemNotFound).Times(1)
+
+	suite.testController.RemoveFromWishlist(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_RemoveFromWishlist_ShouldReturnBadRequestForInvalidMovieId() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "hero"}}
+
+	suite.testController.RemoveFromWishlist(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_MoveToCart_ShouldReturnCartItemId() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7/cart", strings.NewReader(`{"rentalDays":3}`))
+	suite.mockWishlistService.EXPECT().MoveToCart(1001, 7, 3).Return(41, nil).Times(1)
+
+	suite.testController.MoveToCart(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("41", suite.recorder.Body.String())
+}
+
+func (suite *WishlistControllerTestSuite) Test_MoveToCart_ShouldReturnBadRequestForInvalidRentalDays() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7/cart", strings.NewReader(`{"rentalDays":5}`))
+	suite.mockWishlistService.EXPECT().MoveToCart(1001, 7, 5).Return(0, cartModel.ErrInvalidRentalDays).Times(1)
+
+	suite.testController.MoveToCart(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *WishlistControllerTestSuite) Test_MoveToCart_ShouldReturnConflictWhenOutOfStock() {
+	suite.context.Params = gin.Params{{Key: "id", Value: "1001"}, {Key: "movieId", Value: "7"}}
+	suite.context.Request = httptest.NewRequest(http.MethodPost, "/users/1001/wishlist/7/cart", nil)
+	suite.mockWishlistService.EXPECT().MoveToCart(1001, 7, 0).Return(0, inventoryModel.ErrOutOfStock).Times(1)
+
+	suite.testController.MoveToCart(suite.context)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/wishlist/repository/wishlist_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/wishlist/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWishlistRepository is a mock of WishlistRepository interface.
+type MockWishlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistRepositoryMockRecorder
+}
+
+// MockWishlistRepositoryMockRecorder is the mock recorder for MockWishlistRepository.
+type MockWishlistRepositoryMockRecorder struct {
+	mock *MockWishlistRepository
+}
+
+// NewMockWishlistRepository creates a new mock instance.
+func NewMockWishlistRepository(ctrl *gomock.Controller) *MockWishlistRepository {
+	mock := &MockWishlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWishlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistRepository) EXPECT() *MockWishlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockWishlistRepository) AddItem(item model.WishlistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockWishlistRepositoryMockRecorder) AddItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockWishlistRepository)(nil).AddItem), item)
+}
+
+// GetItem mocks base method.
+func (m *MockWishlistRepository) GetItem(userId, movieId int) (model.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", userId, movieId)
+	ret0, _ := ret[0].(model.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockWishlistRepositoryMockRecorder) GetItem(userId, movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockWishlistRepository)(nil).GetItem), userId, movieId)
+}
+
+// GetWishlist mocks base method.
+func (m *MockWishlistRepository) GetWishlist(userId int) ([]model.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", userId)
+	ret0, _ := ret[0].([]model.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockWishlistRepositoryMockRecorder) GetWishlist(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockWishlistRepository)(nil).GetWishlist), userId)
+}
+
+// Move mocks base method.
+func (m *MockWishlistRepository) Move(userId, movieId, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, movieId, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockWishlistRepositoryMockRecorder) Move(userId, movieId, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockWishlistRepository)(nil).Move), userId, movieId, position)
+}
+
+// RemoveItem mocks base method.
+func (m *MockWishlistRepository) RemoveItem(userId, movieId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", userId, movieId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockWishlistRepositoryMockRecorder) RemoveItem(userId, movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockWishlistRepository)(nil).RemoveItem), userId, movieId)
+}
+
+// SetNote mocks base method.
+func (m *MockWishlistRepository) SetNote(userId, movieId int, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNote", userId, movieId, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNote indicates an expected call of SetNote.
+func (mr *MockWishlistRepositoryMockRecorder) SetNote(userId, movieId, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNote", reflect.TypeOf((*MockWishlistRepository)(nil).SetNote), userId, movieId, note)
+}
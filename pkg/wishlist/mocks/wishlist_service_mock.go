@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/wishlist/service/wishlist_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "movie-rent/pkg/wishlist/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWishlistService is a mock of WishlistService interface.
+type MockWishlistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistServiceMockRecorder
+}
+
+// MockWishlistServiceMockRecorder is the mock recorder for MockWishlistService.
+type MockWishlistServiceMockRecorder struct {
+	mock *MockWishlistService
+}
+
+// NewMockWishlistService creates a new mock instance.
+func NewMockWishlistService(ctrl *gomock.Controller) *MockWishlistService {
+	mock := &MockWishlistService{ctrl: ctrl}
+	mock.recorder = &MockWishlistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistService) EXPECT() *MockWishlistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddToWishlist mocks base method.
+func (m *MockWishlistService) AddToWishlist(userId, movieId int, note string) (model.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWishlist", userId, movieId, note)
+	ret0, _ := ret[0].(model.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWishlist indicates an expected call of AddToWishlist.
+func (mr *MockWishlistServiceMockRecorder) AddToWishlist(userId, movieId, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWishlist", reflect.TypeOf((*MockWishlistService)(nil).AddToWishlist), userId, movieId, note)
+}
+
+// GetWishlist mocks base method.
+func (m *MockWishlistService) GetWishlist(userId int) ([]model.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", userId)
+	ret0, _ := ret[0].([]model.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockWishlistServiceMockRecorder) GetWishlist(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockWishlistService)(nil).GetWishlist), userId)
+}
+
+// MoveToCart mocks base method.
+func (m *MockWishlistService) MoveToCart(userId, movieId, rentalDays int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToCart", userId, movieId, rentalDays)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveToCart indicates an expected call of MoveToCart.
+func (mr *MockWishlistServiceMockRecorder) MoveToCart(userId, movieId, rentalDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToCart", reflect.TypeOf((*MockWishlistService)(nil).MoveToCart), userId, movieId, rentalDays)
+}
+
+// RemoveFromWishlist mocks base method.
+func (m *MockWishlistService) RemoveFromWishlist(userId, movieId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWishlist", userId, movieId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWishlist indicates an expected call of RemoveFromWishlist.
+func (mr *MockWishlistServiceMockRecorder) RemoveFromWishlist(userId, movieId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWishlist", reflect.TypeOf((*MockWishlistService)(nil).RemoveFromWishlist), userId, movieId)
+}
+
+// UpdateItem mocks base method.
+func (m *MockWishlistService) UpdateItem(userId, movieId int, update model.WishlistUpdate) (model.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", userId, movieId, update)
+	ret0, _ := ret[0].(model.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockWishlistServiceMockRecorder) UpdateItem(userId, movieId, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockWishlistService)(nil).UpdateItem), userId, movieId, update)
+}
@@ -0,0 +1,8 @@
+package model
+
+import "errors"
+
+var (
+	ErrWishlistItemNotFound  = errors.New("movie is not on the wishlist")
+	ErrDuplicateWishlistItem = errors.New("movie is already on the wishlist")
+)
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// WishlistItem is a movie a user wants to remember, apart from the cart.
+// Items are listed by Position, lowest first.
+type WishlistItem struct {
+	UserId          int       `json:"userId"`
+	MovieId         int       `json:"movieId"`
+	Title           string    `json:"title"`
+	ReleaseYear     int       `json:"releaseYear"`
+	AvailableCopies int       `json:"availableCopies"`
+	Position        int       `json:"position"`
+	Note            string    `json:"note,omitempty"`
+	AddedAt         time.Time `json:"addedAt"`
+}
+
+type WishlistRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// WishlistUpdate changes an item's note, its place in the list, or both.
+// A position past the end moves the item last.
+type WishlistUpdate struct {
+	Note     *string `json:"note" binding:"omitempty,max=500"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+type MoveToCartRequest struct {
+	RentalDays int `json:"rentalDays"`
+}
+
+// Reorder returns the movie ids with movieId moved to position, shifting
+// the others along. It returns false when movieId is not in the list.
+func Reorder(movieIds []int, movieId int, position int) ([]int, bool) {
+	rest := make([]int, 0, len(movieIds))
+	for _, id := range movieIds {
+		if id != movieId {
+			rest = append(rest, id)
+		}
+	}
+	if len(rest) == len(movieIds) {
+		return nil, false
+	}
+	if position > len(rest) {
+		position = len(rest)
+	}
+	ordered := append(append(append(make([]int, 0, len(movieIds)), rest[:position]...), movieId), rest[position:]...)
+	return ordered, true
+}
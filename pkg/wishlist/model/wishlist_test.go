@@ -0,0 +1,41 @@
+package model
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type WishlistTestSuite struct {
+	suite.Suite
+}
+
+func TestWishlistTestSuite(t *testing.T) {
+	suite.Run(t, new(WishlistTestSuite))
+}
+
+func (suite *WishlistTestSuite) Test_Reorder_ShouldMoveItemForward() {
+	ordered, ok := Reorder([]int{1, 2, 3, 4}, 4, 1)
+
+	suite.True(ok)
+	suite.Equal([]int{1, 4, 2, 3}, ordered)
+}
+
+func (suite *WishlistTestSuite) Test_Reorder_ShouldMoveItemBack() {
+	ordered, ok := Reorder([]int{1, 2, 3, 4}, 1, 2)
+
+	suite.True(ok)
+	suite.Equal([]int{2, 3, 1, 4}, ordered)
+}
+
+func (suite *WishlistTestSuite) Test_Reorder_ShouldClampPositionToEnd() {
+	ordered, ok := Reorder([]int{1, 2, 3}, 2, 10)
+
+	suite.True(ok)
+	suite.Equal([]int{1, 3, 2}, ordered)
+}
+
+func (suite *WishlistTestSuite) Test_Reorder_ShouldReportMissingItem() {
+	_, ok := Reorder([]int{1, 2, 3}, 9, 0)
+
+	suite.False(ok)
+}
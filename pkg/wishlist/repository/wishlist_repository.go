@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/wishlist/model"
+)
+
+const (
+	WishlistColumns = `w.user_id, w.movie_id, m.title, m.release_year, ` +
+		`(SELECT COUNT(*) FROM movie_copies c WHERE c.movie_id = m.id AND c.status = 'available'), ` +
+		`w.position, COALESCE(w.note, ''), w.created_at`
+	SelectWishlistSQL = `SELECT ` + WishlistColumns + ` FROM wishlist_items w JOIN movies m ON m.id = w.movie_id ` +
+		`WHERE w.user_id = $1 AND m.deleted_at IS NULL ORDER BY w.position, w.created_at`
+	SelectWishlistItemSQL = `SELECT ` + WishlistColumns + ` FROM wishlist_items w JOIN movies m ON m.id = w.movie_id ` +
+		`WHERE w.user_id = $1 AND w.movie_id = $2 AND m.deleted_at IS NULL`
+	InsertWishlistItemSQL = `INSERT INTO wishlist_items(user_id, movie_id, position, note, created_at) ` +
+		`SELECT $1, $2, COALESCE(MAX(position) + 1, 0), NULLIF($3, ''), $4 FROM wishlist_items WHERE user_id = $1`
+	UpdateWishlistNoteSQL      = `UPDATE wishlist_items SET note = NULLIF($1, '') WHERE user_id = $2 AND movie_id = $3`
+	LockWishlistSQL            = `SELECT movie_id FROM wishlist_items WHERE user_id = $1 ORDER BY position, created_at FOR UPDATE`
+	UpdateWishlistPositionsSQL = `UPDATE wishlist_items w SET position = o.position - 1 ` +
+		`FROM unnest($2::int[]) WITH ORDINALITY AS o(movie_id, position) WHERE w.user_id = $1 AND w.movie_id = o.movie_id`
+	DeleteWishlistItemSQL = `DELETE FROM wishlist_items WHERE user_id = $1 AND movie_id = $2`
+)
+
+type WishlistRepository interface {
+	GetWishlist(userId int) ([]model.WishlistItem, error)
+	GetItem(userId int, movieId int) (model.WishlistItem, error)
+	AddItem(item model.WishlistItem) error
+	SetNote(userId int, movieId int, note string) error
+	Move(userId int, movieId int, position int) error
+	RemoveItem(userId int, movieId int) error
+}
+
+type wishlistRepo struct {
+	db *sqlx.DB
+}
+
+func NewWishlistRepository(db *sqlx.DB) WishlistRepository {
+	return &wishlistRepo{db: db}
+}
+
+// GetWishlist lists the user's items in order. Movies removed from the
+// catalog are left out.
+func (m wishlistRepo) GetWishlist(userId int) ([]model.WishlistItem, error) {
+	rows, err := m.db.Query(SelectWishlistSQL, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	items := []model.WishlistItem{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (m wishlistRepo) GetItem(userId int, movieId int) (model.WishlistItem, error) {
+	item, err := scanItem(m.db.QueryRow(SelectWishlistItemSQL, userId, movieId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.WishlistItem{}, model.ErrWishlistItemNotFound
+	}
+	if err != nil {
+		return model.WishlistItem{}, fmt.Errorf("failed to fetch wishlist item: %w", err)
+	}
+	return item, nil
+}
+
+// AddItem puts the movie at the end of the user's wishlist.
+func (m wishlistRepo) AddItem(item model.WishlistItem) error {
+	_, err := m.db.Exec(InsertWishlistItemSQL, item.UserId, item.MovieId, item.Note, item.AddedAt)
+	if postgres.IsViolation(err, postgres.UniqueViolation) {
+		return model.ErrDuplicateWishlistItem
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add wishlist item: %w", err)
+	}
+	return nil
+}
+
+func (m wishlistRepo) SetNote(userId int, movieId int, note string) error {
+	res, err := m.db.Exec(UpdateWishlistNoteSQL, note, userId, movieId)
+	if err != nil {
+		return fmt.Errorf("failed to update wishlist note: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+// Move places the movie at position and renumbers the whole list from 0,
+// with the user's items locked so concurrent moves cannot interleave.
+func (m wishlistRepo) Move(userId int, movieId int, position int) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin wishlist move: %w", err)
+	}
+	defer tx.Rollback()
+
+	movieIds, err := lockWishlist(tx, userId)
+	if err != nil {
+		return err
+	}
+	ordered, ok := model.Reorder(movieIds, movieId, position)
+	if !ok {
+		return model.ErrWishlistItemNotFound
+	}
+	if _, err = tx.Exec(UpdateWishlistPositionsSQL, userId, pq.Array(ordered)); err != nil {
+		return fmt.Errorf("failed to reorder wishlist: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wishlist move: %w", err)
+	}
+	return nil
+}
+
+func (m wishlistRepo) RemoveItem(userId int, movieId int) error {
+	res, err := m.db.Exec(DeleteWishlistItemSQL, userId, movieId)
+	if err != nil {
+		return fmt.Errorf("failed to remove wishlist item: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return model.ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+func lockWishlist(tx *sqlx.Tx, userId int) ([]int, error) {
+	rows, err := tx.Query(LockWishlistSQL, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	movieIds := []int{}
+	for rows.Next() {
+		var movieId int
+		if err := rows.Scan(&movieId); err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist item: %w", err)
+		}
+		movieIds = append(movieIds, movieId)
+	}
+	return movieIds, rows.Err()
+}
+
+func scanItem(row postgres.Scanner) (model.WishlistItem, error) {
+	var item model.WishlistItem
+	err := row.Scan(&item.UserId, &item.MovieId, &item.Title, &item.ReleaseYear, &item.AvailableCopies, &item.Position, &item.Note,
+		&item.AddedAt)
+	return item, err
+}
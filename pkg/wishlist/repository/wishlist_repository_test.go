@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"movie-rent/db/postgres"
+	"movie-rent/pkg/wishlist/model"
+	"testing"
+	"time"
+)
+
+type WishlistRepositoryTestSuite struct {
+	suite.Suite
+	mockedDB       *sqlx.DB
+	mockDB         sqlmock.Sqlmock
+	testRepository WishlistRepository
+}
+
+func TestWishlistRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(WishlistRepositoryTestSuite))
+}
+
+func (suite *WishlistRepositoryTestSuite) SetupTest() {
+	var sqlDB *sql.DB
+	var err error
+	sqlDB, suite.mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		panic(fmt.Sprintf("failed to open mock sql connection: %v", err))
+	}
+	suite.mockedDB = sqlx.NewDb(sqlDB, "postgres")
+
+	suite.testRepository = NewWishlistRepository(suite.mockedDB)
+}
+
+func (suite *WishlistRepositoryTestSuite) TearDownTest() {
+	suite.Nil(suite.mockDB.ExpectationsWereMet())
+	suite.mockedDB.Close()
+}
+
+var wishlistRowColumns = []string{"user_id", "movie_id", "title", "release_year", "available", "position", "note", "created_at"}
+
+func (suite *WishlistRepositoryTestSuite) Test_GetWishlist_ShouldReturnItemsInOrder() {
+	addedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectQuery(SelectWishlistSQL).WithArgs(3).WillReturnRows(sqlmock.NewRows(wishlistRowColumns).
+		AddRow(3, 7, "Hero", 2002, 2, 0, "For movie night", addedAt).
+		AddRow(3, 9, "Alien", 1979, 0, 1, "", addedAt))
+
+	items, err := suite.testRepository.GetWishlist(3)
+
+	suite.Nil(err)
+	suite.Equal([]model.WishlistItem{
+		{UserId: 3, MovieId: 7, Title: "Hero", ReleaseYear: 2002, AvailableCopies: 2, Note: "For movie night", AddedAt: addedAt},
+		{UserId: 3, MovieId: 9, Title: "Alien", ReleaseYear: 1979, Position: 1, AddedAt: addedAt},
+	}, items)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_GetItem_ShouldReturnNotFound() {
+	suite.mockDB.ExpectQuery(SelectWishlistItemSQL).WithArgs(3, 7).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.testRepository.GetItem(3, 7)
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_AddItem_ShouldReturnDuplicate() {
+	addedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockDB.ExpectExec(InsertWishlistItemSQL).WithArgs(3, 7, "", addedAt).WillReturnError(&pq.Error{Code: postgres.UniqueViolation})
+
+	err := suite.testRepository.AddItem(model.WishlistItem{UserId: 3, MovieId: 7, AddedAt: addedAt})
+
+	suite.ErrorIs(err, model.ErrDuplicateWishlistItem)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_SetNote_ShouldReturnNotFound() {
+	suite.mockDB.ExpectExec(UpdateWishlistNoteSQL).WithArgs("later", 3, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.SetNote(3, 7, "later")
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_Move_ShouldRenumberList() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockWishlistSQL).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"movie_id"}).AddRow(7).AddRow(9).AddRow(11))
+	suite.mockDB.ExpectExec(UpdateWishlistPositionsSQL).WithArgs(3, pq.Array([]int{11, 7, 9})).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	suite.mockDB.ExpectCommit()
+
+	err := suite.testRepository.Move(3, 11, 0)
+
+	suite.Nil(err)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_Move_ShouldReturnNotFound() {
+	suite.mockDB.ExpectBegin()
+	suite.mockDB.ExpectQuery(LockWishlistSQL).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"movie_id"}).AddRow(7))
+	suite.mockDB.ExpectRollback()
+
+	err := suite.testRepository.Move(3, 11, 0)
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}
+
+func (suite *WishlistRepositoryTestSuite) Test_RemoveItem_ShouldReturnNotFound() {
+	suite.mockDB.ExpectExec(DeleteWishlistItemSQL).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.testRepository.RemoveItem(3, 7)
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}
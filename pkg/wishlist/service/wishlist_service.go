@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	cartModel "movie-rent/pkg/cart/model"
+	cartService "movie-rent/pkg/cart/service"
+	movieRepository "movie-rent/pkg/movie/repository"
+	"movie-rent/pkg/wishlist/model"
+	"movie-rent/pkg/wishlist/repository"
+	"strings"
+	"time"
+)
+
+// go:generate mockgen -source=pkg/wishlist/service/wishlist_service.go -destination=pkg/wishlist/mocks/wishlist_service_mock.go -package=mocks
+
+type WishlistService interface {
+	GetWishlist(userId int) ([]model.WishlistItem, error)
+	AddToWishlist(userId int, movieId int, note string) (model.WishlistItem, error)
+	UpdateItem(userId int, movieId int, update model.WishlistUpdate) (model.WishlistItem, error)
+	RemoveFromWishlist(userId int, movieId int) error
+	MoveToCart(userId int, movieId int, rentalDays int) (int, error)
+}
+
+type wishlistService struct {
+	repository      repository.WishlistRepository
+	movieRepository movieRepository.MovieRepository
+	cartService     cartService.CartService
+}
+
+func NewWishlistService(repository repository.WishlistRepository, movieRepository movieRepository.MovieRepository,
+	cartService cartService.CartService) WishlistService {
+	return wishlistService{repository: repository, movieRepository: movieRepository, cartService: cartService}
+}
+
+func (m wishlistService) GetWishlist(userId int) ([]model.WishlistItem, error) {
+	items, err := m.repository.GetWishlist(userId)
+	if err != nil {
+		fmt.Println("failed to find wishlist:", err.Error())
+		return []model.WishlistItem{}, err
+	}
+	return items, nil
+}
+
+func (m wishlistService) AddToWishlist(userId int, movieId int, note string) (model.WishlistItem, error) {
+	if _, err := m.movieRepository.GetMovieBy(movieId); err != nil {
+		fmt.Println("failed to find movie for wishlist:", err.Error())
+		return model.WishlistItem{}, err
+	}
+	err := m.repository.AddItem(model.WishlistItem{
+		UserId:  userId,
+		MovieId: movieId,
+		Note:    strings.TrimSpace(note),
+		AddedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Println("failed to add to wishlist:", err.Error())
+		return model.WishlistItem{}, err
+	}
+	return m.repository.GetItem(userId, movieId)
+}
+
+func (m wishlistService) UpdateItem(userId int, movieId int, update model.WishlistUpdate) (model.WishlistItem, error) {
+	if update.Note != nil {
+		if err := m.repository.SetNote(userId, movieId, strings.TrimSpace(*update.Note)); err != nil {
+			fmt.Println("failed to update wishlist note:", err.Error())
+			return model.WishlistItem{}, err
+		}
+	}
+	if update.Position != nil {
+		if err := m.repository.Move(userId, movieId, *update.Position); err != nil {
+			fmt.Println("failed to move wishlist item:", err.Error())
+			return model.WishlistItem{}, err
+		}
+	}
+	return m.repository.GetItem(userId, movieId)
+}
+
+func (m wishlistService) RemoveFromWishlist(userId int, movieId int) error {
+	if err := m.repository.RemoveItem(userId, movieId); err != nil {
+		fmt.Println("failed to remove from wishlist:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// MoveToCart adds a wishlisted movie to the cart, with the cart's own checks
+// on rental days, stock and duplicates, then takes it off the wishlist. The
+// cart item stands even if the wishlist cannot be updated.
+func (m wishlistService) MoveToCart(userId int, movieId int, rentalDays int) (int, error) {
+	if _, err := m.repository.GetItem(userId, movieId); err != nil {
+		fmt.Println("failed to find wishlist item:", err.Error())
+		return 0, err
+	}
+	id, err := m.cartService.AddToCart(cartModel.CartRequest{UserId: userId, MovieId: movieId, RentalDays: rentalDays})
+	if err != nil {
+		fmt.Println("failed to move wishlist item to cart:", err.Error())
+		return 0, err
+	}
+	if err = m.repository.RemoveItem(userId, movieId); err != nil {
+		fmt.Println("failed to remove moved wishlist item:", err.Error())
+	}
+	return id, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	cartMocks "movie-rent/pkg/cart/mocks"
+	cartModel "movie-rent/pkg/cart/model"
+	movieMocks "movie-rent/pkg/movie/mocks"
+	movieModel "movie-rent/pkg/movie/model"
+	"movie-rent/pkg/wishlist/mocks"
+	"movie-rent/pkg/wishlist/model"
+	"testing"
+)
+
+type WishlistServiceTestSuite struct {
+	suite.Suite
+	mockController      *gomock.Controller
+	mockRepository      *mocks.MockWishlistRepository
+	mockMovieRepository *movieMocks.MockMovieRepository
+	mockCartService     *cartMocks.MockCartService
+
+	wishlistService WishlistService
+}
+
+func TestWishlistServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WishlistServiceTestSuite))
+}
+
+func (suite *WishlistServiceTestSuite) SetupTest() {
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockRepository = mocks.NewMockWishlistRepository(suite.mockController)
+	suite.mockMovieRepository = movieMocks.NewMockMovieRepository(suite.mockController)
+	suite.mockCartService = cartMocks.NewMockCartService(suite.mockController)
+
+	suite.wishlistService = NewWishlistService(suite.mockRepository, suite.mockMovieRepository, suite.mockCartService)
+}
+
+func (suite *WishlistServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
+
+func (suite *WishlistServiceTestSuite) Test_AddToWishlist_ShouldTrimNote() {
+	item := model.WishlistItem{UserId: 3, MovieId: 7, Title: "Hero", Note: "Date night"}
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{Id: 7}, nil).Times(1)
+	suite.mockRepository.EXPECT().AddItem(gomock.Any()).DoAndReturn(func(added model.WishlistItem) error {
+		suite.Equal("Date night", added.Note)
+		return nil
+	}).Times(1)
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(item, nil).Times(1)
+
+	result, err := suite.wishlistService.AddToWishlist(3, 7, "  Date night ")
+
+	suite.Nil(err)
+	suite.Equal(item, result)
+}
+
+func (suite *WishlistServiceTestSuite) Test_AddToWishlist_ShouldReturnMovieNotFound() {
+	suite.mockMovieRepository.EXPECT().GetMovieBy(7).Return(movieModel.Movie{}, movieModel.ErrMovieNotFound).Times(1)
+
+	_, err := suite.wishlistService.AddToWishlist(3, 7, "")
+
+	suite.ErrorIs(err, movieModel.ErrMovieNotFound)
+}
+
+func (suite *WishlistServiceTestSuite) Test_UpdateItem_ShouldSetNoteAndMove() {
+	note := "Watch first"
+	position := 0
+	suite.mockRepository.EXPECT().SetNote(3, 7, note).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().Move(3, 7, 0).Return(nil).Times(1)
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(model.WishlistItem{MovieId: 7, Note: note}, nil).Times(1)
+
+	item, err := suite.wishlistService.UpdateItem(3, 7, model.WishlistUpdate{Note: &note, Position: &position})
+
+	suite.Nil(err)
+	suite.Equal(note, item.Note)
+}
+
+func (suite *WishlistServiceTestSuite) Test_UpdateItem_ShouldReturnNotFound() {
+	position := 2
+	suite.mockRepository.EXPECT().Move(3, 7, 2).Return(model.ErrWishlistItemNotFound).Times(1)
+
+	_, err := suite.wishlistService.UpdateItem(3, 7, model.WishlistUpdate{Position: &position})
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}
+
+func (suite *WishlistServiceTestSuite) Test_MoveToCart_ShouldAddToCartThenRemove() {
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(model.WishlistItem{UserId: 3, MovieId: 7}, nil).Times(1)
+	suite.mockCartService.EXPECT().AddToCart(cartModel.CartRequest{UserId: 3, MovieId: 7, RentalDays: 3}).Return(41, nil).Times(1)
+	suite.mockRepository.EXPECT().RemoveItem(3, 7).Return(nil).Times(1)
+
+	id, err := suite.wishlistService.MoveToCart(3, 7, 3)
+
+	suite.Nil(err)
+	suite.Equal(41, id)
+}
+
+func (suite *WishlistServiceTestSuite) Test_MoveToCart_ShouldKeepItemWhenCartRejectsIt() {
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(model.WishlistItem{UserId: 3, MovieId: 7}, nil).Times(1)
+	suite.mockCartService.EXPECT().AddToCart(cartModel.CartRequest{UserId: 3, MovieId: 7, RentalDays: 5}).
+		Return(0, cartModel.ErrInvalidRentalDays).Times(1)
+
+	_, err := suite.wishlistService.MoveToCart(3, 7, 5)
+
+	suite.ErrorIs(err, cartModel.ErrInvalidRentalDays)
+}
+
+func (suite *WishlistServiceTestSuite) Test_MoveToCart_ShouldKeepCartItemWhenRemovalFails() {
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(model.WishlistItem{UserId: 3, MovieId: 7}, nil).Times(1)
+	suite.mockCartService.EXPECT().AddToCart(gomock.Any()).Return(41, nil).Times(1)
+	suite.mockRepository.EXPECT().RemoveItem(3, 7).Return(errors.New("connection reset")).Times(1)
+
+	id, err := suite.wishlistService.MoveToCart(3, 7, 0)
+
+	suite.Nil(err)
+	suite.Equal(41, id)
+}
+
+func (suite *WishlistServiceTestSuite) Test_MoveToCart_ShouldReturnNotFoundForMissingItem() {
+	suite.mockRepository.EXPECT().GetItem(3, 7).Return(model.WishlistItem{}, model.ErrWishlistItemNotFound).Times(1)
+
+	_, err := suite.wishlistService.MoveToCart(3, 7, 0)
+
+	suite.ErrorIs(err, model.ErrWishlistItemNotFound)
+}